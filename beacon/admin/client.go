@@ -0,0 +1,305 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	"github.com/rocket-pool/node-manager-core/beacon"
+)
+
+// An error returned by the admin API
+type Error struct {
+	// The HTTP status code of the response
+	Code int
+
+	// The error message from the response
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("admin request failed with code %d: %s", e.Code, e.Message)
+}
+
+// A client for the admin API of a Beacon mock server, such as osha-bn, that's running in another process. Each method
+// sends the JSON POST variant of its admin route.
+type Client struct {
+	baseUrl    string
+	httpClient *http.Client
+}
+
+// Create a new admin client for the Beacon mock server at the given URL, such as http://localhost:48812
+func NewClient(baseUrl string, timeout time.Duration) *Client {
+	return &Client{
+		baseUrl: strings.TrimSuffix(baseUrl, "/"),
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// Add a new validator with the given pubkey and withdrawal credentials, returning its index
+func (c *Client) AddValidator(ctx context.Context, pubkey beacon.ValidatorPubkey, withdrawalCredentials common.Hash) (uint64, error) {
+	var response api.AddValidatorResponse
+	err := c.sendRequest(ctx, api.AddValidatorRoute, api.AddValidatorRequest{
+		Pubkey:                pubkey,
+		WithdrawalCredentials: withdrawalCredentials,
+	}, &response)
+	if err != nil {
+		return 0, err
+	}
+	return response.Index, nil
+}
+
+// Increment the chain's slot, proposing a block for the current slot if validated is true or missing it otherwise
+func (c *Client) CommitBlock(ctx context.Context, validated bool) error {
+	return c.sendRequest(ctx, api.CommitBlockRoute, api.CommitBlockRequest{
+		Validated: validated,
+	}, nil)
+}
+
+// Set the balance of a validator, in gwei
+func (c *Client) SetBalance(ctx context.Context, id string, balance uint64) error {
+	return c.sendRequest(ctx, api.SetBalanceRoute, api.SetBalanceRequest{
+		ID:      id,
+		Balance: balance,
+	}, nil)
+}
+
+// Set the status of a validator
+func (c *Client) SetStatus(ctx context.Context, id string, status beacon.ValidatorState) error {
+	return c.sendRequest(ctx, api.SetStatusRoute, api.SetStatusRequest{
+		ID:     id,
+		Status: status,
+	}, nil)
+}
+
+// Set the highest slot on the chain, for simulating syncing conditions
+func (c *Client) SetHighestSlot(ctx context.Context, slot uint64) error {
+	return c.sendRequest(ctx, api.SetHighestSlotRoute, api.SetHighestSlotRequest{
+		Slot: slot,
+	}, nil)
+}
+
+// Slash a validator as if it had been caught by the current slot's proposer, applying the spec's penalties
+func (c *Client) Slash(ctx context.Context, id string) error {
+	return c.sendRequest(ctx, api.SlashRoute, api.SlashRequest{
+		ID: id,
+	}, nil)
+}
+
+// Slash a validator like Slash, but with a custom penalty (in gwei) instead of the spec's penalties and rewards
+func (c *Client) SlashWithPenalty(ctx context.Context, id string, penalty uint64) error {
+	return c.sendRequest(ctx, api.SlashRoute, api.SlashRequest{
+		ID:      id,
+		Penalty: &penalty,
+	}, nil)
+}
+
+// Stall or resume finality. While stalled, the justified and finalized checkpoints won't advance.
+func (c *Client) SetFinalityStalled(ctx context.Context, stalled bool) error {
+	return c.sendRequest(ctx, api.SetFinalityStalledRoute, api.SetFinalityStalledRequest{
+		Stalled: stalled,
+	}, nil)
+}
+
+// Immediately justify and finalize the given epoch, which must have ended
+func (c *Client) ForceFinalization(ctx context.Context, epoch uint64) error {
+	return c.sendRequest(ctx, api.ForceFinalizationRoute, api.ForceFinalizationRequest{
+		Epoch: epoch,
+	}, nil)
+}
+
+// Force a validator to be the proposer for the given slot
+func (c *Client) SetProposer(ctx context.Context, id string, slot uint64) error {
+	return c.sendRequest(ctx, api.SetProposerRoute, api.SetProposerRequest{
+		ID:   id,
+		Slot: slot,
+	}, nil)
+}
+
+// Force a validator into the sync committee for the current period
+func (c *Client) AddToSyncCommittee(ctx context.Context, id string) error {
+	return c.sendRequest(ctx, api.AddToSyncCommitteeRoute, api.AddToSyncCommitteeRequest{
+		ID: id,
+	}, nil)
+}
+
+// Enable or disable rewards and penalties at each epoch transition
+func (c *Client) SetRewardsEnabled(ctx context.Context, enabled bool) error {
+	return c.sendRequest(ctx, api.SetRewardsRoute, api.SetRewardsRequest{
+		Enabled: &enabled,
+	}, nil)
+}
+
+// Set the annual percentage rate active validators earn with full participation (e.g. 0.03 for 3%)
+func (c *Client) SetRewardsApr(ctx context.Context, apr float64) error {
+	return c.sendRequest(ctx, api.SetRewardsRoute, api.SetRewardsRequest{
+		Apr: &apr,
+	}, nil)
+}
+
+// Set a validator's attestation participation rate, from 0 (offline) to 1 (perfect)
+func (c *Client) SetParticipation(ctx context.Context, id string, participation float64) error {
+	return c.sendRequest(ctx, api.SetParticipationRoute, api.SetParticipationRequest{
+		ID:            id,
+		Participation: participation,
+	}, nil)
+}
+
+// Queue a partial withdrawal of the given amount, in gwei, from a validator
+func (c *Client) AddPendingPartialWithdrawal(ctx context.Context, id string, amount uint64) error {
+	return c.sendRequest(ctx, api.AddPendingPartialWithdrawalRoute, api.AddPendingPartialWithdrawalRequest{
+		ID:     id,
+		Amount: amount,
+	}, nil)
+}
+
+// Queue a consolidation of the source validator into the target validator
+func (c *Client) AddPendingConsolidation(ctx context.Context, source string, target string) error {
+	return c.sendRequest(ctx, api.AddPendingConsolidationRoute, api.AddPendingConsolidationRequest{
+		Source: source,
+		Target: target,
+	}, nil)
+}
+
+// Replace the blocks in the last depth slots with a new branch, proposing blocks in the first newBlocks slots of it and
+// missing the rest
+func (c *Client) Reorg(ctx context.Context, depth uint64, newBlocks uint64) error {
+	return c.sendRequest(ctx, api.ReorgRoute, api.ReorgRequest{
+		Depth:  depth,
+		Blocks: newBlocks,
+	}, nil)
+}
+
+// Set the client version string the node reports
+func (c *Client) SetNodeVersion(ctx context.Context, version string) error {
+	return c.sendRequest(ctx, api.SetNodeVersionRoute, api.SetNodeVersionRequest{
+		Version: version,
+	}, nil)
+}
+
+// Set the status code the health route responds with. Use 200 (ready), 206 (syncing), or 503 (not ready), or 0 to
+// derive it from the sync status again.
+func (c *Client) SetNodeHealth(ctx context.Context, status int) error {
+	return c.sendRequest(ctx, api.SetNodeHealthRoute, api.SetNodeHealthRequest{
+		Status: status,
+	}, nil)
+}
+
+// Set the number of connected peers the node reports, up to db.MaxPeerCount
+func (c *Client) SetPeerCount(ctx context.Context, count uint64) error {
+	return c.sendRequest(ctx, api.SetPeerCountRoute, api.SetPeerCountRequest{
+		Count: count,
+	}, nil)
+}
+
+// Set the networking identity the node reports
+func (c *Client) SetNodeIdentity(ctx context.Context, identity db.NodeIdentity) error {
+	return c.sendRequest(ctx, api.SetNodeIdentityRoute, api.SetNodeIdentityRequest{
+		PeerID:             identity.PeerID,
+		Enr:                identity.Enr,
+		P2PAddresses:       identity.P2PAddresses,
+		DiscoveryAddresses: identity.DiscoveryAddresses,
+	}, nil)
+}
+
+// Add a fault to inject into the responses of an API route
+func (c *Client) AddFault(ctx context.Context, request api.AddFaultRequest) error {
+	return c.sendRequest(ctx, api.AddFaultRoute, request, nil)
+}
+
+// Remove the faults from an API route
+func (c *Client) RemoveFaults(ctx context.Context, route string) error {
+	return c.sendRequest(ctx, api.ClearFaultsRoute, api.ClearFaultsRequest{
+		Route: route,
+	}, nil)
+}
+
+// Remove every fault from every API route
+func (c *Client) ClearFaults(ctx context.Context) error {
+	return c.sendRequest(ctx, api.ClearFaultsRoute, api.ClearFaultsRequest{}, nil)
+}
+
+// Get the API requests the server has recorded, optionally filtered by method, route, and validator IDs
+func (c *Client) GetRecordedRequests(ctx context.Context, filter api.GetRecordedRequestsRequest) ([]api.RecordedRequest, error) {
+	var response api.RecordedRequestsResponse
+	err := c.sendRequest(ctx, api.GetRecordedRequestsRoute, filter, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Data, nil
+}
+
+// Remove every API request the server has recorded
+func (c *Client) ClearRecordedRequests(ctx context.Context) error {
+	return c.sendRequest(ctx, api.ClearRecordedRequestsRoute, nil, nil)
+}
+
+// Set the number of requests the server records before dropping the oldest ones, or 0 to stop recording requests
+func (c *Client) SetRecordedRequestsCapacity(ctx context.Context, capacity int) error {
+	return c.sendRequest(ctx, api.SetRecordedRequestsCapacityRoute, api.SetRecordedRequestsCapacityRequest{
+		Capacity: capacity,
+	}, nil)
+}
+
+// Send a request to an admin route with the given body, deserializing the response into the provided object if it's
+// not nil
+func (c *Client) sendRequest(ctx context.Context, route string, body any, response any) error {
+	// Serialize the body
+	bodyBytes := []byte{}
+	if body != nil {
+		var err error
+		bodyBytes, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error serializing request body: %w", err)
+		}
+	}
+
+	// Send the request
+	url := fmt.Sprintf("%s/admin/%s", c.baseUrl, route)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Set("Content-Type", api.JsonContentType)
+	httpResponse, err := c.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("error sending request to [%s]: %w", route, err)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response from [%s]: %w", route, err)
+	}
+
+	// Handle errors
+	if httpResponse.StatusCode != http.StatusOK {
+		var errorResponse api.ErrorResponse
+		err = json.Unmarshal(responseBytes, &errorResponse)
+		if err != nil || errorResponse.Message == "" {
+			errorResponse.Message = http.StatusText(httpResponse.StatusCode)
+		}
+		return &Error{
+			Code:    httpResponse.StatusCode,
+			Message: errorResponse.Message,
+		}
+	}
+
+	// Deserialize the response
+	if response != nil {
+		err = json.Unmarshal(responseBytes, response)
+		if err != nil {
+			return fmt.Errorf("error deserializing response from [%s]: %w", route, err)
+		}
+	}
+	return nil
+}
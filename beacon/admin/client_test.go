@@ -0,0 +1,199 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	"github.com/nodeset-org/osha/beacon/internal/test"
+	"github.com/nodeset-org/osha/beacon/server"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/stretchr/testify/require"
+)
+
+// Various singleton variables used for testing
+var (
+	logger      *slog.Logger             = slog.Default()
+	mockServer  *server.BeaconMockServer = nil
+	wg          *sync.WaitGroup          = nil
+	adminClient *Client                  = nil
+	baseUrl     string                   = ""
+)
+
+// Initialize a server for the client to talk to
+func TestMain(m *testing.M) {
+	// Create the server
+	var err error
+	mockServer, err = server.NewBeaconMockServer(logger, "localhost", 0, db.NewDefaultConfig())
+	if err != nil {
+		logger.Error(fmt.Sprintf("error creating server: %v", err))
+		os.Exit(1)
+	}
+
+	// Start it
+	wg = &sync.WaitGroup{}
+	err = mockServer.Start(wg)
+	if err != nil {
+		logger.Error(fmt.Sprintf("error starting server: %v", err))
+		os.Exit(1)
+	}
+	baseUrl = fmt.Sprintf("http://localhost:%d", mockServer.GetPort())
+	adminClient = NewClient(baseUrl, 5*time.Second)
+
+	// Run tests
+	code := m.Run()
+
+	// Stop the server
+	_ = mockServer.Stop()
+	wg.Wait()
+	os.Exit(code)
+}
+
+// Test adding and updating a validator with the client
+func TestValidatorOperations(t *testing.T) {
+	ctx := context.Background()
+
+	// Add a validator
+	pubkey, err := beacon.HexToValidatorPubkey(test.Pubkey0String)
+	require.NoError(t, err)
+	creds := common.HexToHash(test.WithdrawalCredentialsString)
+	index, err := adminClient.AddValidator(ctx, pubkey, creds)
+	require.NoError(t, err)
+	t.Logf("Added validator %d", index)
+
+	// Update it
+	id := fmt.Sprint(index)
+	require.NoError(t, adminClient.SetBalance(ctx, id, 33e9))
+	require.NoError(t, adminClient.SetStatus(ctx, id, beacon.ValidatorState_ActiveOngoing))
+	require.NoError(t, adminClient.SetParticipation(ctx, id, 0.5))
+	require.NoError(t, adminClient.SetRewardsEnabled(ctx, false))
+	require.NoError(t, adminClient.SetRewardsApr(ctx, 0.04))
+	require.NoError(t, adminClient.CommitBlock(ctx, true))
+
+	// Make sure the changes were applied
+	var response api.ValidatorResponse
+	getApiResponse(t, fmt.Sprintf(api.ValidatorRouteTemplate, "head", id), &response)
+	require.Equal(t, pubkey[:], []byte(response.Data.Validator.Pubkey))
+	require.Equal(t, uint64(33e9), uint64(response.Data.Balance))
+	require.Equal(t, string(beacon.ValidatorState_ActiveOngoing), response.Data.Status)
+	t.Log("Validator was updated")
+
+	// Adding it again should fail with a structured error
+	_, err = adminClient.AddValidator(ctx, pubkey, creds)
+	var adminErr *Error
+	require.True(t, errors.As(err, &adminErr))
+	require.Equal(t, http.StatusBadRequest, adminErr.Code)
+	require.NotEmpty(t, adminErr.Message)
+	t.Logf("Adding a duplicate validator failed with: %s", adminErr.Message)
+}
+
+// Test updating the node's information and the request journal with the client
+func TestNodeOperations(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, adminClient.ClearRecordedRequests(ctx))
+
+	// Set the node version and check it
+	require.NoError(t, adminClient.SetNodeVersion(ctx, "Test/v1.2.3"))
+	var versionResponse api.NodeVersionResponse
+	getApiResponse(t, api.NodeVersionRoute, &versionResponse)
+	require.Equal(t, "Test/v1.2.3", versionResponse.Data.Version)
+	t.Log("Node version was updated")
+
+	// Set the peer count and check it
+	require.NoError(t, adminClient.SetPeerCount(ctx, 3))
+	var peerCountResponse api.PeerCountResponse
+	getApiResponse(t, api.NodePeerCountRoute, &peerCountResponse)
+	require.Equal(t, uint64(3), uint64(peerCountResponse.Data.Connected))
+	t.Log("Peer count was updated")
+
+	// Invalid settings should be rejected
+	var adminErr *Error
+	require.True(t, errors.As(adminClient.SetNodeHealth(ctx, 418), &adminErr))
+	require.Equal(t, http.StatusBadRequest, adminErr.Code)
+	require.Equal(t, "invalid health status [418]", adminErr.Message)
+	t.Log("Invalid health status was rejected")
+
+	// The requests above should have been recorded
+	requests, err := adminClient.GetRecordedRequests(ctx, api.GetRecordedRequestsRequest{
+		Route: api.NodeVersionRoute,
+	})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	require.NoError(t, adminClient.ClearRecordedRequests(ctx))
+	requests, err = adminClient.GetRecordedRequests(ctx, api.GetRecordedRequestsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, requests)
+	t.Log("Recorded requests were retrieved and cleared")
+}
+
+// Test adding and clearing faults with the client
+func TestFaultOperations(t *testing.T) {
+	ctx := context.Background()
+
+	// Add a fault
+	err := adminClient.AddFault(ctx, api.AddFaultRequest{
+		Route:  api.NodeVersionRoute,
+		Type:   string(server.FaultType_Status),
+		Status: http.StatusServiceUnavailable,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, getApiStatusCode(t, api.NodeVersionRoute))
+	t.Log("Fault was injected")
+
+	// Remove it
+	require.NoError(t, adminClient.RemoveFaults(ctx, api.NodeVersionRoute))
+	require.Equal(t, http.StatusOK, getApiStatusCode(t, api.NodeVersionRoute))
+	t.Log("Fault was removed")
+
+	// Latency faults need a valid duration
+	var adminErr *Error
+	err = adminClient.AddFault(ctx, api.AddFaultRequest{
+		Route:   api.NodeVersionRoute,
+		Type:    string(server.FaultType_Latency),
+		Latency: "soon",
+	})
+	require.True(t, errors.As(err, &adminErr))
+	require.Equal(t, http.StatusBadRequest, adminErr.Code)
+	require.NoError(t, adminClient.ClearFaults(ctx))
+	t.Log("Invalid fault was rejected")
+}
+
+// Send a GET request to a Beacon API route and deserialize the response
+func getApiResponse(t *testing.T, route string, response any) {
+	httpResponse, err := http.Get(fmt.Sprintf("%s/eth/%s", baseUrl, route))
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	defer httpResponse.Body.Close()
+	require.Equal(t, http.StatusOK, httpResponse.StatusCode)
+
+	bytes, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	err = json.Unmarshal(bytes, response)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+}
+
+// Send a GET request to a Beacon API route and get the status code of the response
+func getApiStatusCode(t *testing.T, route string) int {
+	httpResponse, err := http.Get(fmt.Sprintf("%s/eth/%s", baseUrl, route))
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	httpResponse.Body.Close()
+	return httpResponse.StatusCode
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/beacon"
+)
+
+// JSON bodies for the POST variants of the admin routes. Each field's name matches the query parameter the GET variant
+// of the route uses; optional fields are omitted when nil or empty.
+
+type AddValidatorRequest struct {
+	Pubkey                beacon.ValidatorPubkey `json:"pubkey"`
+	WithdrawalCredentials common.Hash            `json:"creds"`
+}
+
+type CommitBlockRequest struct {
+	Validated bool `json:"validated"`
+}
+
+type SetBalanceRequest struct {
+	ID      string `json:"id"`
+	Balance uint64 `json:"balance"`
+}
+
+type SetStatusRequest struct {
+	ID     string                `json:"id"`
+	Status beacon.ValidatorState `json:"status"`
+}
+
+type SetHighestSlotRequest struct {
+	Slot uint64 `json:"slot"`
+}
+
+type SlashRequest struct {
+	ID      string  `json:"id"`
+	Penalty *uint64 `json:"penalty,omitempty"`
+}
+
+type SetFinalityStalledRequest struct {
+	Stalled bool `json:"stalled"`
+}
+
+type ForceFinalizationRequest struct {
+	Epoch uint64 `json:"epoch"`
+}
+
+type SetProposerRequest struct {
+	ID   string `json:"id"`
+	Slot uint64 `json:"slot"`
+}
+
+type AddToSyncCommitteeRequest struct {
+	ID string `json:"id"`
+}
+
+type SetRewardsRequest struct {
+	Enabled *bool    `json:"enabled,omitempty"`
+	Apr     *float64 `json:"apr,omitempty"`
+}
+
+type SetParticipationRequest struct {
+	ID            string  `json:"id"`
+	Participation float64 `json:"participation"`
+}
+
+type AddPendingPartialWithdrawalRequest struct {
+	ID     string `json:"id"`
+	Amount uint64 `json:"amount"`
+}
+
+type AddPendingConsolidationRequest struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+type ReorgRequest struct {
+	Depth  uint64 `json:"depth"`
+	Blocks uint64 `json:"blocks"`
+}
+
+type SetNodeVersionRequest struct {
+	Version string `json:"version"`
+}
+
+type SetNodeHealthRequest struct {
+	Status int `json:"status"`
+}
+
+type SetPeerCountRequest struct {
+	Count uint64 `json:"count"`
+}
+
+type SetNodeIdentityRequest struct {
+	PeerID             string   `json:"peer_id"`
+	Enr                string   `json:"enr"`
+	P2PAddresses       []string `json:"p2p_address,omitempty"`
+	DiscoveryAddresses []string `json:"discovery_address,omitempty"`
+}
+
+type AddFaultRequest struct {
+	// The route to add the fault to, such as ValidatorsRoute, or "*" for every route
+	Route string `json:"route"`
+
+	// The kind of fault: latency, status, malformed_json, drop, or timeout
+	Type string `json:"type"`
+
+	// How long to delay the request for latency faults, as a Go duration string such as "500ms"
+	Latency string `json:"latency,omitempty"`
+
+	// The status code to respond with for status faults
+	Status int `json:"status,omitempty"`
+
+	// The number of requests to inject the fault into before it's removed, or 0 for no limit
+	Count uint64 `json:"count,omitempty"`
+
+	// The chance of injecting the fault into each request, from 0 to 1, or 0 to always inject it
+	Probability float64 `json:"probability,omitempty"`
+}
+
+type ClearFaultsRequest struct {
+	Route string `json:"route,omitempty"`
+}
+
+type GetRecordedRequestsRequest struct {
+	Method string   `json:"method,omitempty"`
+	Route  string   `json:"route,omitempty"`
+	IDs    []string `json:"id,omitempty"`
+}
+
+type SetRecordedRequestsCapacityRequest struct {
+	Capacity int `json:"capacity"`
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+// Topics that can be subscribed to on the event stream
+const (
+	HeadTopic                string = "head"
+	BlockTopic               string = "block"
+	FinalizedCheckpointTopic string = "finalized_checkpoint"
+	ChainReorgTopic          string = "chain_reorg"
+	VoluntaryExitTopic       string = "voluntary_exit"
+)
+
+type HeadEvent struct {
+	Slot                      utils.Uinteger `json:"slot"`
+	Block                     common.Hash    `json:"block"`
+	State                     common.Hash    `json:"state"`
+	EpochTransition           bool           `json:"epoch_transition"`
+	PreviousDutyDependentRoot common.Hash    `json:"previous_duty_dependent_root"`
+	CurrentDutyDependentRoot  common.Hash    `json:"current_duty_dependent_root"`
+	ExecutionOptimistic       bool           `json:"execution_optimistic"`
+}
+
+type BlockEvent struct {
+	Slot                utils.Uinteger `json:"slot"`
+	Block               common.Hash    `json:"block"`
+	ExecutionOptimistic bool           `json:"execution_optimistic"`
+}
+
+type FinalizedCheckpointEvent struct {
+	Block               common.Hash    `json:"block"`
+	State               common.Hash    `json:"state"`
+	Epoch               utils.Uinteger `json:"epoch"`
+	ExecutionOptimistic bool           `json:"execution_optimistic"`
+}
+
+type ChainReorgEvent struct {
+	Slot                utils.Uinteger `json:"slot"`
+	Depth               utils.Uinteger `json:"depth"`
+	OldHeadBlock        common.Hash    `json:"old_head_block"`
+	NewHeadBlock        common.Hash    `json:"new_head_block"`
+	OldHeadState        common.Hash    `json:"old_head_state"`
+	NewHeadState        common.Hash    `json:"new_head_state"`
+	Epoch               utils.Uinteger `json:"epoch"`
+	ExecutionOptimistic bool           `json:"execution_optimistic"`
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"net/url"
+	"time"
+)
+
+// A request the server received, recorded in its journal
+type RecordedRequest struct {
+	Method        string            `json:"method"`
+	Route         string            `json:"route"`
+	Path          string            `json:"path"`
+	PathVars      map[string]string `json:"path_vars"`
+	Query         url.Values        `json:"query"`
+	Body          string            `json:"body"`
+	BodyTruncated bool              `json:"body_truncated,omitempty"`
+	StatusCode    int               `json:"status_code"`
+	Timestamp     time.Time         `json:"timestamp"`
+}
+
+type RecordedRequestsResponse struct {
+	Data []RecordedRequest `json:"data"`
+}
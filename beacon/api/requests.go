@@ -1,5 +1,40 @@
 package api
 
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
 type ValidatorsRequest struct {
-	IDs []string `json:"ids"`
+	IDs      []string `json:"ids"`
+	Statuses []string `json:"statuses"`
+}
+
+type SignedBeaconBlockHeader struct {
+	Message   BlockHeaderMessage `json:"message"`
+	Signature utils.ByteArray    `json:"signature"`
+}
+
+type ProposerSlashing struct {
+	SignedHeader1 SignedBeaconBlockHeader `json:"signed_header_1"`
+	SignedHeader2 SignedBeaconBlockHeader `json:"signed_header_2"`
+}
+
+type AttestationData struct {
+	Slot            utils.Uinteger `json:"slot"`
+	Index           utils.Uinteger `json:"index"`
+	BeaconBlockRoot common.Hash    `json:"beacon_block_root"`
+	Source          Checkpoint     `json:"source"`
+	Target          Checkpoint     `json:"target"`
+}
+
+type IndexedAttestation struct {
+	AttestingIndices []utils.Uinteger `json:"attesting_indices"`
+	Data             AttestationData  `json:"data"`
+	Signature        utils.ByteArray  `json:"signature"`
+}
+
+type AttesterSlashing struct {
+	Attestation1 IndexedAttestation `json:"attestation_1"`
+	Attestation2 IndexedAttestation `json:"attestation_2"`
 }
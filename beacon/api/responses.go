@@ -1,7 +1,24 @@
 package api
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/node-manager-core/beacon/client"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	// Content types that can be requested with the Accept header. Routes without an SSZ form respond with JSON. Most
+	// SSZ responses use the spec's types, but the spec doesn't define any for ValidatorsRoute or ConfigSpecRoute, so
+	// the mock uses its own encodings there:
+	//   - Validators: a list of containers holding the index (uint64), balance (uint64), status (List[uint8, 32]),
+	//     and spec Validator of each validator
+	//   - Config spec: a list of containers holding each key and value (both List[uint8]), sorted by key
+	// DebugStateRoute is only available as SSZ.
+	JsonContentType string = "application/json"
+	SszContentType  string = "application/octet-stream"
+
+	// The header holding the name of the fork a response's data belongs to
+	ConsensusVersionHeader string = "Eth-Consensus-Version"
 )
 
 type ErrorResponse struct {
@@ -16,3 +33,209 @@ type ValidatorResponse struct {
 type AddValidatorResponse struct {
 	Index uint64 `json:"index"`
 }
+
+type ConfigSpecResponse struct {
+	Data map[string]string `json:"data"`
+}
+
+type Fork struct {
+	PreviousVersion utils.ByteArray `json:"previous_version"`
+	CurrentVersion  utils.ByteArray `json:"current_version"`
+	Epoch           utils.Uinteger  `json:"epoch"`
+}
+
+type ForkScheduleResponse struct {
+	Data []Fork `json:"data"`
+}
+
+type StateForkResponse struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
+	Data                Fork `json:"data"`
+}
+
+type Checkpoint struct {
+	Epoch utils.Uinteger `json:"epoch"`
+	Root  common.Hash    `json:"root"`
+}
+
+type FinalityCheckpointsResponse struct {
+	Data struct {
+		PreviousJustified Checkpoint `json:"previous_justified"`
+		CurrentJustified  Checkpoint `json:"current_justified"`
+		Finalized         Checkpoint `json:"finalized"`
+	} `json:"data"`
+}
+
+type BlockHeaderMessage struct {
+	Slot          utils.Uinteger `json:"slot"`
+	ProposerIndex string         `json:"proposer_index"`
+	ParentRoot    common.Hash    `json:"parent_root"`
+	StateRoot     common.Hash    `json:"state_root"`
+	BodyRoot      common.Hash    `json:"body_root"`
+}
+
+type BlockHeaderResponse struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
+	Data                struct {
+		Root      common.Hash `json:"root"`
+		Canonical bool        `json:"canonical"`
+		Header    struct {
+			Message   BlockHeaderMessage `json:"message"`
+			Signature utils.ByteArray    `json:"signature"`
+		} `json:"header"`
+	} `json:"data"`
+}
+
+type Eth1Data struct {
+	DepositRoot  common.Hash    `json:"deposit_root"`
+	DepositCount utils.Uinteger `json:"deposit_count"`
+	BlockHash    common.Hash    `json:"block_hash"`
+}
+
+type Withdrawal struct {
+	Index          utils.Uinteger `json:"index"`
+	ValidatorIndex utils.Uinteger `json:"validator_index"`
+	Address        common.Address `json:"address"`
+	Amount         utils.Uinteger `json:"amount"`
+}
+
+type ExecutionPayload struct {
+	FeeRecipient common.Address `json:"fee_recipient"`
+	BlockNumber  utils.Uinteger `json:"block_number"`
+	BlockHash    common.Hash    `json:"block_hash"`
+	Timestamp    utils.Uinteger `json:"timestamp"`
+	Withdrawals  []Withdrawal   `json:"withdrawals"`
+}
+
+type BlockResponse struct {
+	Version             string `json:"version"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+	Finalized           bool   `json:"finalized"`
+	Data                struct {
+		Message struct {
+			Slot          utils.Uinteger `json:"slot"`
+			ProposerIndex string         `json:"proposer_index"`
+			ParentRoot    common.Hash    `json:"parent_root"`
+			StateRoot     common.Hash    `json:"state_root"`
+			Body          struct {
+				Eth1Data         Eth1Data             `json:"eth1_data"`
+				Attestations     []client.Attestation `json:"attestations"`
+				ExecutionPayload ExecutionPayload     `json:"execution_payload"`
+			} `json:"body"`
+		} `json:"message"`
+		Signature utils.ByteArray `json:"signature"`
+	} `json:"data"`
+}
+
+type VoluntaryExitsResponse struct {
+	Data []client.VoluntaryExitRequest `json:"data"`
+}
+
+type BlsToExecutionChangesResponse struct {
+	Data []client.BLSToExecutionChangeRequest `json:"data"`
+}
+
+type ProposerDuty struct {
+	Pubkey         utils.ByteArray `json:"pubkey"`
+	ValidatorIndex string          `json:"validator_index"`
+	Slot           utils.Uinteger  `json:"slot"`
+}
+
+type ProposerDutiesResponse struct {
+	DependentRoot       common.Hash    `json:"dependent_root"`
+	ExecutionOptimistic bool           `json:"execution_optimistic"`
+	Data                []ProposerDuty `json:"data"`
+}
+
+type SyncDutiesResponse struct {
+	ExecutionOptimistic bool              `json:"execution_optimistic"`
+	Data                []client.SyncDuty `json:"data"`
+}
+
+type PendingPartialWithdrawal struct {
+	ValidatorIndex    string         `json:"validator_index"`
+	Amount            utils.Uinteger `json:"amount"`
+	WithdrawableEpoch utils.Uinteger `json:"withdrawable_epoch"`
+}
+
+type PendingPartialWithdrawalsResponse struct {
+	Data []PendingPartialWithdrawal `json:"data"`
+}
+
+type PendingConsolidation struct {
+	SourceIndex string `json:"source_index"`
+	TargetIndex string `json:"target_index"`
+}
+
+type PendingConsolidationsResponse struct {
+	Data []PendingConsolidation `json:"data"`
+}
+
+type ValidatorBalance struct {
+	Index   string         `json:"index"`
+	Balance utils.Uinteger `json:"balance"`
+}
+
+type ValidatorBalancesResponse struct {
+	ExecutionOptimistic bool               `json:"execution_optimistic"`
+	Finalized           bool               `json:"finalized"`
+	Data                []ValidatorBalance `json:"data"`
+}
+
+type ProposerSlashingsResponse struct {
+	Data []ProposerSlashing `json:"data"`
+}
+
+type AttesterSlashingsResponse struct {
+	Data []AttesterSlashing `json:"data"`
+}
+
+type NodeVersionResponse struct {
+	Data struct {
+		Version string `json:"version"`
+	} `json:"data"`
+}
+
+type Peer struct {
+	PeerID             string `json:"peer_id"`
+	Enr                string `json:"enr"`
+	LastSeenP2PAddress string `json:"last_seen_p2p_address"`
+	State              string `json:"state"`
+	Direction          string `json:"direction"`
+}
+
+type PeersResponse struct {
+	Data []Peer `json:"data"`
+	Meta struct {
+		Count utils.Uinteger `json:"count"`
+	} `json:"meta"`
+}
+
+type PeerCountResponse struct {
+	Data struct {
+		Disconnected  utils.Uinteger `json:"disconnected"`
+		Connecting    utils.Uinteger `json:"connecting"`
+		Connected     utils.Uinteger `json:"connected"`
+		Disconnecting utils.Uinteger `json:"disconnecting"`
+	} `json:"data"`
+}
+
+type NodeIdentityMetadata struct {
+	SeqNumber utils.Uinteger  `json:"seq_number"`
+	Attnets   utils.ByteArray `json:"attnets"`
+	Syncnets  utils.ByteArray `json:"syncnets"`
+}
+
+type NodeIdentity struct {
+	PeerID             string               `json:"peer_id"`
+	Enr                string               `json:"enr"`
+	P2PAddresses       []string             `json:"p2p_addresses"`
+	DiscoveryAddresses []string             `json:"discovery_addresses"`
+	Metadata           NodeIdentityMetadata `json:"metadata"`
+}
+
+type NodeIdentityResponse struct {
+	Data NodeIdentity `json:"data"`
+}
@@ -3,25 +3,91 @@ package api
 const (
 	StateID     string = "state_id"
 	ValidatorID string = "validator_id"
+	BlockID     string = "block_id"
+	Epoch       string = "epoch"
 
 	// Beacon API routes
-	ValidatorsRouteTemplate      string = "v1/beacon/states/%s/validators"
-	ValidatorsRoute              string = "v1/beacon/states/{state_id}/validators"
-	ValidatorRouteTemplate       string = "v1/beacon/states/%s/validators/%s"
-	PendingDepositsRouteTemplate string = "v1/beacon/states/%s/pending_deposits"
-	ValidatorRoute               string = "v1/beacon/states/{state_id}/validators/{validator_id}"
-	SyncingRoute                 string = "v1/node/syncing"
-	PendingDepositsRoute         string = "v1/beacon/states/{state_id}/pending_deposits"
-	DepositContractRoute         string = "v1/config/deposit_contract"
-	ConfigSpecRoute              string = "v1/config/spec"
-	BeaconGenesisRoute           string = "v1/beacon/genesis"
-	FinalityCheckpointsRoute     string = "v1/beacon/states/{state_id}/finality_checkpoints"
+	ValidatorsRouteTemplate          string = "v1/beacon/states/%s/validators"
+	ValidatorsRoute                  string = "v1/beacon/states/{state_id}/validators"
+	ValidatorRouteTemplate           string = "v1/beacon/states/%s/validators/%s"
+	PendingDepositsRouteTemplate     string = "v1/beacon/states/%s/pending_deposits"
+	ValidatorRoute                   string = "v1/beacon/states/{state_id}/validators/{validator_id}"
+	FinalityCheckpointsRouteTemplate string = "v1/beacon/states/%s/finality_checkpoints"
+	SyncingRoute                     string = "v1/node/syncing"
+	PendingDepositsRoute             string = "v1/beacon/states/{state_id}/pending_deposits"
+	DepositContractRoute             string = "v1/config/deposit_contract"
+	ConfigSpecRoute                  string = "v1/config/spec"
+	ForkScheduleRoute                string = "v1/config/fork_schedule"
+	StateForkRouteTemplate           string = "v1/beacon/states/%s/fork"
+	StateForkRoute                   string = "v1/beacon/states/{state_id}/fork"
+	BeaconGenesisRoute               string = "v1/beacon/genesis"
+	FinalityCheckpointsRoute         string = "v1/beacon/states/{state_id}/finality_checkpoints"
+	BlockHeaderRouteTemplate         string = "v1/beacon/headers/%s"
+	BlockHeaderRoute                 string = "v1/beacon/headers/{block_id}"
+	BlockRouteTemplate               string = "v2/beacon/blocks/%s"
+	BlockRoute                       string = "v2/beacon/blocks/{block_id}"
+	VoluntaryExitsRoute              string = "v1/beacon/pool/voluntary_exits"
+	BlsToExecutionChangesRoute       string = "v1/beacon/pool/bls_to_execution_changes"
+	ProposerSlashingsRoute           string = "v1/beacon/pool/proposer_slashings"
+	AttesterSlashingsRoute           string = "v1/beacon/pool/attester_slashings"
+	EventsRoute                      string = "v1/events"
+	ProposerDutiesRouteTemplate      string = "v1/validator/duties/proposer/%s"
+	ProposerDutiesRoute              string = "v1/validator/duties/proposer/{epoch}"
+	SyncDutiesRouteTemplate          string = "v1/validator/duties/sync/%s"
+	SyncDutiesRoute                  string = "v1/validator/duties/sync/{epoch}"
+
+	// Beacon API routes for the Electra pending queues
+	PendingPartialWithdrawalsRouteTemplate string = "v1/beacon/states/%s/pending_partial_withdrawals"
+	PendingPartialWithdrawalsRoute         string = "v1/beacon/states/{state_id}/pending_partial_withdrawals"
+	PendingConsolidationsRouteTemplate     string = "v1/beacon/states/%s/pending_consolidations"
+	PendingConsolidationsRoute             string = "v1/beacon/states/{state_id}/pending_consolidations"
+
+	// Beacon API routes for validator balances
+	ValidatorBalancesRouteTemplate string = "v1/beacon/states/%s/validator_balances"
+	ValidatorBalancesRoute         string = "v1/beacon/states/{state_id}/validator_balances"
+
+	// Beacon API routes for debugging
+	DebugStateRouteTemplate string = "v2/debug/beacon/states/%s"
+	DebugStateRoute         string = "v2/debug/beacon/states/{state_id}"
+
+	// Beacon API routes for node information
+	NodeVersionRoute   string = "v1/node/version"
+	NodeHealthRoute    string = "v1/node/health"
+	NodePeersRoute     string = "v1/node/peers"
+	NodePeerCountRoute string = "v1/node/peer_count"
+	NodeIdentityRoute  string = "v1/node/identity"
 
 	// Admin routes
-	AddValidatorRoute   string = "add-validator"
-	CommitBlockRoute    string = "commit-block"
-	SetBalanceRoute     string = "set-balance"
-	SetStatusRoute      string = "set-status"
-	SetHighestSlotRoute string = "set-highest-slot"
-	SlashRoute          string = "slash"
+	AddValidatorRoute       string = "add-validator"
+	CommitBlockRoute        string = "commit-block"
+	SetBalanceRoute         string = "set-balance"
+	SetStatusRoute          string = "set-status"
+	SetHighestSlotRoute     string = "set-highest-slot"
+	SlashRoute              string = "slash"
+	SetFinalityStalledRoute string = "set-finality-stalled"
+	ForceFinalizationRoute  string = "force-finalization"
+	SetProposerRoute        string = "set-proposer"
+	AddToSyncCommitteeRoute string = "add-to-sync-committee"
+	SetRewardsRoute         string = "set-rewards"
+	SetParticipationRoute   string = "set-participation"
+	ReorgRoute              string = "reorg"
+
+	// Admin routes for the Electra pending queues
+	AddPendingPartialWithdrawalRoute string = "add-pending-partial-withdrawal"
+	AddPendingConsolidationRoute     string = "add-pending-consolidation"
+
+	// Admin routes for node information
+	SetNodeVersionRoute  string = "set-node-version"
+	SetNodeHealthRoute   string = "set-node-health"
+	SetPeerCountRoute    string = "set-peer-count"
+	SetNodeIdentityRoute string = "set-node-identity"
+
+	// Admin routes for fault injection
+	AddFaultRoute    string = "add-fault"
+	ClearFaultsRoute string = "clear-faults"
+
+	// Admin routes for the request journal
+	GetRecordedRequestsRoute         string = "get-recorded-requests"
+	ClearRecordedRequestsRoute       string = "clear-recorded-requests"
+	SetRecordedRequestsCapacityRoute string = "set-recorded-requests-capacity"
 )
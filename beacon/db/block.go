@@ -0,0 +1,172 @@
+package db
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// A block proposed on the Beacon chain
+type Block struct {
+	// The slot the block was proposed in
+	Slot uint64
+
+	// The index of the validator that proposed the block
+	ProposerIndex uint64
+
+	// The block's root
+	Root common.Hash
+
+	// The root of the block's parent
+	ParentRoot common.Hash
+
+	// The root of the Beacon state after the block was processed
+	StateRoot common.Hash
+
+	// The root of the block's body
+	BodyRoot common.Hash
+
+	// The number of the Execution block included in the block's payload
+	ExecutionBlockNumber uint64
+
+	// The hash of the Execution block included in the block's payload, if known
+	ExecutionBlockHash common.Hash
+
+	// The timestamp of the Execution block included in the block's payload, if known
+	ExecutionBlockTimestamp uint64
+
+	// The fee recipient of the Execution block included in the block's payload, if known
+	FeeRecipient common.Address
+
+	// The withdrawals processed in the block's payload
+	Withdrawals []Withdrawal
+}
+
+// Get the block proposed in the given slot. Returns nil if the slot was missed or hasn't been reached yet.
+func (db *Database) GetBlockBySlot(slot uint64) *Block {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.blockMap[slot]
+}
+
+// Get a block by its root. Returns nil if it doesn't exist.
+func (db *Database) GetBlockByRoot(root common.Hash) *Block {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	for _, block := range db.blockMap {
+		if block.Root == root {
+			return block
+		}
+	}
+	return nil
+}
+
+// Get the latest block proposed at or before the given slot. Returns nil if there isn't one.
+func (db *Database) GetLatestBlock(slot uint64) *Block {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.getLatestBlock(slot)
+}
+
+// Get the latest block on the chain. Returns nil if no blocks have been proposed yet.
+func (db *Database) GetHeadBlock() *Block {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.getLatestBlock(db.currentSlot)
+}
+
+// Set the details of the Execution block included in the block for the given slot. Blocks proposed afterwards are
+// linked to the Execution blocks after this one, so the chain stays in step with Execution blocks mined elsewhere.
+func (db *Database) SetExecutionBlockDetails(slot uint64, number uint64, hash common.Hash, timestamp uint64, feeRecipient common.Address) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	block, exists := db.blockMap[slot]
+	if !exists {
+		return fmt.Errorf("no block was proposed in slot %d", slot)
+	}
+	block.ExecutionBlockNumber = number
+	db.executionBlockMap[slot] = number
+	db.nextExecutionBlockIndex = max(db.nextExecutionBlockIndex, number+1)
+	block.ExecutionBlockHash = hash
+	block.ExecutionBlockTimestamp = timestamp
+	block.FeeRecipient = feeRecipient
+	return nil
+}
+
+// Set the number of the latest block on the Execution chain, so the next proposed block is linked to the one after it
+func (db *Database) SetLatestExecutionBlockNumber(number uint64) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.nextExecutionBlockIndex = number + 1
+}
+
+// Propose a block in the given slot, linked to the next Execution block, and make it the new head.
+// The lock must be held by the caller.
+func (db *Database) addBlock(slot uint64) *Block {
+	block := db.proposeBlock(slot, db.nextExecutionBlockIndex)
+	cursor := withdrawalCursor{
+		withdrawalIndex: db.nextWithdrawalIndex,
+		validatorIndex:  db.nextWithdrawalValidatorIndex,
+	}
+	queue := db.pendingPartialWithdrawals
+	block.Withdrawals = db.processWithdrawals(slot / db.config.SlotsPerEpoch)
+	for _, pending := range queue[:len(queue)-len(db.pendingPartialWithdrawals)] {
+		cursor.processedPartialWithdrawals = append(cursor.processedPartialWithdrawals, *pending)
+	}
+	db.withdrawalCursorMap[slot] = cursor
+	db.executionBlockMap[slot] = db.nextExecutionBlockIndex
+	db.blockMap[slot] = block
+	db.headBlockRoot = block.Root
+	db.nextExecutionBlockIndex++
+	return block
+}
+
+// Create a new block for the given slot, building on the current head. The number of reorgs so far is mixed into the
+// root so blocks that replace reorged ones get new roots. The lock must be held by the caller.
+func (db *Database) proposeBlock(slot uint64, executionBlockIndex uint64) *Block {
+	buffer := make([]byte, common.HashLength+24)
+	copy(buffer, db.headBlockRoot[:])
+	binary.LittleEndian.PutUint64(buffer[common.HashLength:], slot)
+	binary.LittleEndian.PutUint64(buffer[common.HashLength+8:], executionBlockIndex)
+	binary.LittleEndian.PutUint64(buffer[common.HashLength+16:], db.reorgCount)
+	root := common.Hash(sha256.Sum256(buffer))
+
+	// Blocks proposed without any active validators are attributed to validator 0
+	proposerIndex, _ := db.getProposerIndex(slot, getActiveValidatorIndices(db.validators))
+	return &Block{
+		Slot:                 slot,
+		ProposerIndex:        proposerIndex,
+		Root:                 root,
+		ParentRoot:           db.headBlockRoot,
+		StateRoot:            deriveRoot(root, "state"),
+		BodyRoot:             deriveRoot(root, "body"),
+		ExecutionBlockNumber: executionBlockIndex,
+	}
+}
+
+// Get the latest block proposed at or before the given slot. Returns nil if there isn't one.
+// The lock must be held by the caller.
+func (db *Database) getLatestBlock(slot uint64) *Block {
+	for ; ; slot-- {
+		block, exists := db.blockMap[slot]
+		if exists {
+			return block
+		}
+		if slot == 0 {
+			return nil
+		}
+	}
+}
+
+// Derive a deterministic root from another root and a label
+func deriveRoot(root common.Hash, label string) common.Hash {
+	return sha256.Sum256(append(root.Bytes(), []byte(label)...))
+}
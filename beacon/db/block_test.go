@@ -0,0 +1,72 @@
+package db
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nodeset-org/osha/beacon/internal/test"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockChaining(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+
+	// Propose a block, miss a slot, then propose another
+	d.CommitBlock(true)
+	d.CommitBlock(false)
+	d.CommitBlock(true)
+	t.Log("Committed 2 blocks with a missed slot between them")
+
+	// Check the chain
+	genesis := d.GetBlockBySlot(0)
+	head := d.GetHeadBlock()
+	require.NotNil(t, genesis)
+	require.Nil(t, d.GetBlockBySlot(1))
+	require.Equal(t, uint64(2), head.Slot)
+	require.Equal(t, genesis.Root, head.ParentRoot)
+	require.Equal(t, uint64(1), head.ExecutionBlockNumber)
+	require.NotEqual(t, head.Root, head.StateRoot)
+	require.Equal(t, head, d.GetBlockByRoot(head.Root))
+	t.Log("Blocks are chained correctly")
+
+	// Set the execution block details
+	hash := common.HexToHash("0x1234")
+	feeRecipient := common.HexToAddress(test.WithdrawalCredentialsString)
+	err := d.SetExecutionBlockDetails(2, 5, hash, 1234, feeRecipient)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), head.ExecutionBlockNumber)
+	require.Equal(t, hash, head.ExecutionBlockHash)
+	require.Equal(t, uint64(1234), head.ExecutionBlockTimestamp)
+	require.Equal(t, feeRecipient, head.FeeRecipient)
+	require.Error(t, d.SetExecutionBlockDetails(1, 5, hash, 1234, feeRecipient))
+	t.Log("Execution block details set correctly")
+
+	// The next block should follow the Execution block that was set
+	d.CommitBlock(true)
+	require.Equal(t, uint64(6), d.GetHeadBlock().ExecutionBlockNumber)
+	t.Log("Next block follows the Execution chain")
+}
+
+func TestBlockProposers(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	for _, pubkey := range []string{test.Pubkey0String, test.Pubkey1String, test.Pubkey2String} {
+		v := addTestValidator(t, d, pubkey)
+		v.Status = beacon.ValidatorState_ActiveOngoing
+	}
+	pending := addTestValidator(t, d, test.Pubkey3String)
+
+	// Only active validators should propose, and the same slot should always get the same proposer
+	clone := d.Clone()
+	for i := uint64(0); i < config.SlotsPerEpoch; i++ {
+		d.CommitBlock(true)
+		clone.CommitBlock(true)
+		block := d.GetBlockBySlot(i)
+		require.NotEqual(t, pending.Index, block.ProposerIndex)
+		require.Equal(t, block.ProposerIndex, clone.GetBlockBySlot(i).ProposerIndex)
+	}
+	t.Log("Proposers are correct")
+}
@@ -1,6 +1,8 @@
 package db
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -15,3 +17,59 @@ func TestConfigClone(t *testing.T) {
 	require.Equal(t, c, clone)
 	t.Log("Configs are equal")
 }
+
+func TestLoadConfigFromFile(t *testing.T) {
+	files := map[string]string{
+		"config.yaml": "slotsPerEpoch: 8\nactivationDelay: 1\n",
+		"config.json": `{"slotsPerEpoch": 8, "activationDelay": 1}`,
+	}
+	for name, contents := range files {
+		path := filepath.Join(t.TempDir(), name)
+		err := os.WriteFile(path, []byte(contents), 0644)
+		require.NoError(t, err)
+
+		// Settings in the file should be loaded
+		c, err := LoadFromFile(path)
+		require.NoError(t, err)
+		require.Equal(t, uint64(8), c.SlotsPerEpoch)
+		require.Equal(t, uint64(1), c.ActivationDelay)
+		require.False(t, c.GenesisTime.IsZero())
+
+		// Missing settings should keep their defaults
+		defaults := NewDefaultConfig()
+		require.Equal(t, defaults.ChainID, c.ChainID)
+		require.Equal(t, defaults.MaxPendingDepositsPerEpoch, c.MaxPendingDepositsPerEpoch)
+		require.Equal(t, defaults.SyncCommitteeSize, c.SyncCommitteeSize)
+		require.Equal(t, defaults.RewardsApr, c.RewardsApr)
+		require.Equal(t, defaults.StateHistoryEpochs, c.StateHistoryEpochs)
+		t.Logf("Loaded %s with defaults for missing settings", name)
+	}
+}
+
+func TestForkSchedule(t *testing.T) {
+	c := NewDefaultConfig()
+	c.DenebForkEpoch = 10
+	c.ElectraForkEpoch = 20
+
+	// Electra is scheduled, so every fork is included
+	schedule := c.GetForkSchedule()
+	require.Len(t, schedule, 6)
+	require.Equal(t, c.GenesisForkVersion, schedule[0].CurrentVersion)
+	require.Equal(t, c.CapellaForkVersion, schedule[4].PreviousVersion)
+	require.Equal(t, c.DenebForkVersion, schedule[4].CurrentVersion)
+	require.Equal(t, uint64(10), schedule[4].Epoch)
+	t.Log("Fork schedule is correct")
+
+	// Get the active forks
+	require.Equal(t, c.CapellaForkVersion, c.GetForkVersion(9))
+	require.Equal(t, c.DenebForkVersion, c.GetForkVersion(10))
+	require.Equal(t, c.ElectraForkVersion, c.GetForkVersion(25))
+	require.Equal(t, "deneb", c.GetForkName(19))
+	t.Log("Active forks are correct")
+
+	// Unscheduled forks are left out
+	c.ElectraForkEpoch = FarFutureEpoch
+	require.Len(t, c.GetForkSchedule(), 5)
+	require.Equal(t, c.DenebForkVersion, c.GetFork(FarFutureEpoch).CurrentVersion)
+	t.Log("Unscheduled fork was left out")
+}
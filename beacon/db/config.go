@@ -3,6 +3,7 @@ package db
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"time"
@@ -23,6 +24,18 @@ var (
 	DefaultDepositContractAddress common.Address = common.HexToAddress(DefaultDepositContractAddressString)
 )
 
+// A fork in the chain's fork schedule
+type Fork struct {
+	// The version of the fork before this one
+	PreviousVersion utils.ByteArray
+
+	// The version of this fork
+	CurrentVersion utils.ByteArray
+
+	// The epoch this fork activates at
+	Epoch uint64
+}
+
 // Basic Beacon Chain configuration
 type Config struct {
 	// ==============================
@@ -57,12 +70,43 @@ type Config struct {
 	DenebForkVersion utils.ByteArray `json:"denebForkVersion" yaml:"denebForkVersion"`
 	DenebForkEpoch   uint64          `json:"denebForkEpoch" yaml:"denebForkEpoch"`
 
+	// Electra info
+	ElectraForkVersion utils.ByteArray `json:"electraForkVersion" yaml:"electraForkVersion"`
+	ElectraForkEpoch   uint64          `json:"electraForkEpoch" yaml:"electraForkEpoch"`
+
 	// ==============================
 	// === Mock-specific settings ===
 	// ==============================
 
 	// The index of the first execution layer block to be linked to in a Beacon chain slot
 	FirstExecutionBlockIndex uint64
+
+	// The number of epochs after the one following a validator's dequeue from the activation queue before it becomes
+	// active. The spec uses MAX_SEED_LOOKAHEAD.
+	ActivationDelay uint64 `json:"activationDelay" yaml:"activationDelay"`
+
+	// The number of epochs a validator must be active for before it can voluntarily exit. The spec uses
+	// SHARD_COMMITTEE_PERIOD.
+	ShardCommitteePeriod uint64 `json:"shardCommitteePeriod" yaml:"shardCommitteePeriod"`
+
+	// The maximum number of pending deposits that can be processed in a single epoch
+	MaxPendingDepositsPerEpoch uint64 `json:"maxPendingDepositsPerEpoch" yaml:"maxPendingDepositsPerEpoch"`
+
+	// The number of positions in each sync committee; validators can hold more than one if there aren't enough of them
+	SyncCommitteeSize uint64 `json:"syncCommitteeSize" yaml:"syncCommitteeSize"`
+
+	// True to apply attestation rewards and penalties to validator balances at each epoch transition
+	RewardsEnabled bool `json:"rewardsEnabled" yaml:"rewardsEnabled"`
+
+	// The annual percentage rate active validators earn with full participation (e.g. 0.03 for 3%)
+	RewardsApr float64 `json:"rewardsApr" yaml:"rewardsApr"`
+
+	// Map of validator indices to their attestation participation rates, from 0 (offline) to 1 (perfect).
+	// Validators that aren't in the map have perfect participation.
+	Participation map[uint64]float64 `json:"participation" yaml:"participation"`
+
+	// The number of epochs before the finalized checkpoint to keep historical states for; older states are pruned
+	StateHistoryEpochs uint64 `json:"stateHistoryEpochs" yaml:"stateHistoryEpochs"`
 }
 
 // Creates a new default config instance
@@ -84,6 +128,16 @@ func NewDefaultConfig() *Config {
 		CapellaForkEpoch:             0,
 		DenebForkVersion:             common.FromHex("0x90de5e74"),
 		DenebForkEpoch:               0,
+		ElectraForkVersion:           common.FromHex("0x90de5e75"),
+		ElectraForkEpoch:             FarFutureEpoch,
+		ActivationDelay:              MaxSeedLookahead,
+		ShardCommitteePeriod:         ShardCommitteePeriod,
+		MaxPendingDepositsPerEpoch:   16,
+		SyncCommitteeSize:            512,
+		RewardsEnabled:               false,
+		RewardsApr:                   0.03,
+		Participation:                map[uint64]float64{},
+		StateHistoryEpochs:           64,
 	}
 	return defaultConfig
 }
@@ -102,13 +156,15 @@ func LoadFromFile(path string) (*Config, error) {
 		return nil, fmt.Errorf("error reading config file [%s]: %w", path, err)
 	}
 
-	// Unmarshal the config
-	var config Config
+	// Unmarshal the config on top of the defaults so settings missing from the file, such as ones added after the file
+	// was written, keep their default values instead of being zero
+	config := NewDefaultConfig()
+	config.GenesisTime = time.Time{}
 	switch filepath.Ext(path) {
 	case ".json":
-		err = json.Unmarshal(bytes, &config)
+		err = json.Unmarshal(bytes, config)
 	case ".yaml", ".yml":
-		err = yaml.Unmarshal(bytes, &config)
+		err = yaml.Unmarshal(bytes, config)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("error unmarshalling config file [%s]: %w", path, err)
@@ -119,7 +175,7 @@ func LoadFromFile(path string) (*Config, error) {
 		config.GenesisTime = time.Now().Truncate(time.Second)
 	}
 
-	return &config, nil
+	return config, nil
 }
 
 // Clones a config into a new instance
@@ -141,5 +197,88 @@ func (c *Config) Clone() *Config {
 		CapellaForkEpoch:             c.CapellaForkEpoch,
 		DenebForkVersion:             c.DenebForkVersion,
 		DenebForkEpoch:               c.DenebForkEpoch,
+		ElectraForkVersion:           c.ElectraForkVersion,
+		ElectraForkEpoch:             c.ElectraForkEpoch,
+		FirstExecutionBlockIndex:     c.FirstExecutionBlockIndex,
+		ActivationDelay:              c.ActivationDelay,
+		ShardCommitteePeriod:         c.ShardCommitteePeriod,
+		MaxPendingDepositsPerEpoch:   c.MaxPendingDepositsPerEpoch,
+		SyncCommitteeSize:            c.SyncCommitteeSize,
+		RewardsEnabled:               c.RewardsEnabled,
+		RewardsApr:                   c.RewardsApr,
+		Participation:                maps.Clone(c.Participation),
+		StateHistoryEpochs:           c.StateHistoryEpochs,
+	}
+}
+
+// Get the name of the fork that's active at the given epoch
+func (c *Config) GetForkName(epoch uint64) string {
+	switch {
+	case epoch >= c.ElectraForkEpoch:
+		return "electra"
+	case epoch >= c.DenebForkEpoch:
+		return "deneb"
+	case epoch >= c.CapellaForkEpoch:
+		return "capella"
+	case epoch >= c.BellatrixForkEpoch:
+		return "bellatrix"
+	case epoch >= c.AltairForkEpoch:
+		return "altair"
+	default:
+		return "phase0"
+	}
+}
+
+// Get the version of the fork that's active at the given epoch
+func (c *Config) GetForkVersion(epoch uint64) utils.ByteArray {
+	return c.GetFork(epoch).CurrentVersion
+}
+
+// Get the fork that's active at the given epoch
+func (c *Config) GetFork(epoch uint64) Fork {
+	schedule := c.GetForkSchedule()
+	for i := len(schedule) - 1; i > 0; i-- {
+		if epoch >= schedule[i].Epoch {
+			return schedule[i]
+		}
 	}
+	return schedule[0]
+}
+
+// Get the chain's forks in order, starting with genesis. Forks that aren't scheduled (i.e. are set to the far future
+// epoch) are left out.
+func (c *Config) GetForkSchedule() []Fork {
+	upgrades := []struct {
+		version utils.ByteArray
+		epoch   uint64
+	}{
+		{c.AltairForkVersion, c.AltairForkEpoch},
+		{c.BellatrixForkVersion, c.BellatrixForkEpoch},
+		{c.CapellaForkVersion, c.CapellaForkEpoch},
+		{c.DenebForkVersion, c.DenebForkEpoch},
+		{c.ElectraForkVersion, c.ElectraForkEpoch},
+	}
+	schedule := []Fork{
+		{
+			PreviousVersion: c.GenesisForkVersion,
+			CurrentVersion:  c.GenesisForkVersion,
+			Epoch:           0,
+		},
+	}
+	for _, upgrade := range upgrades {
+		if upgrade.epoch == FarFutureEpoch {
+			break
+		}
+		schedule = append(schedule, Fork{
+			PreviousVersion: schedule[len(schedule)-1].CurrentVersion,
+			CurrentVersion:  upgrade.version,
+			Epoch:           upgrade.epoch,
+		})
+	}
+	return schedule
+}
+
+// Check if Electra's balance and churn rules apply at the given epoch
+func (c *Config) IsElectraActive(epoch uint64) bool {
+	return epoch >= c.ElectraForkEpoch
 }
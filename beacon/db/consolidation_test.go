@@ -0,0 +1,81 @@
+package db
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/nodeset-org/osha/beacon/internal/test"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingConsolidations(t *testing.T) {
+	config := NewDefaultConfig()
+	config.ElectraForkEpoch = 0
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	source := addTestValidator(t, d, test.Pubkey0String)
+	source.Status = beacon.ValidatorState_ActiveOngoing
+	target := addTestValidator(t, d, test.Pubkey1String)
+	target.Status = beacon.ValidatorState_ActiveOngoing
+	target.WithdrawalCredentials[0] = CompoundingWithdrawalPrefix
+
+	// Bad requests
+	_, err := d.AddPendingConsolidation(source.Index, source.Index)
+	require.Error(t, err)
+	_, err = d.AddPendingConsolidation(target.Index, source.Index)
+	require.Error(t, err)
+	_, err = d.AddPendingConsolidation(source.Index, 5)
+	require.Error(t, err)
+	t.Log("Invalid consolidations were rejected")
+
+	// Queue the consolidation
+	_, err = d.AddPendingConsolidation(source.Index, target.Index)
+	require.NoError(t, err)
+	require.Len(t, d.GetPendingConsolidations(), 1)
+	require.Equal(t, 1+MaxSeedLookahead, source.ExitEpoch)
+	t.Logf("Consolidation was queued and the source is exiting at epoch %d", source.ExitEpoch)
+
+	// The source can't be consolidated again once it's exiting
+	_, err = d.AddPendingConsolidation(source.Index, target.Index)
+	require.ErrorContains(t, err, "already initiated an exit")
+	require.Len(t, d.GetPendingConsolidations(), 1)
+	t.Log("Duplicate consolidation was rejected")
+
+	// Nothing happens until the source is withdrawable
+	commitEpochs(d, 1)
+	require.Equal(t, beacon.ValidatorState_ActiveExiting, source.Status)
+	require.Len(t, d.GetPendingConsolidations(), 1)
+	require.Equal(t, StartingBalance, target.Balance)
+	t.Log("Consolidation is waiting for the source to be withdrawable")
+
+	// Move the balance once it is
+	source.WithdrawableEpoch = 2
+	commitEpochs(d, 1)
+	require.Empty(t, d.GetPendingConsolidations())
+	require.Equal(t, uint64(0), source.Balance)
+	require.Equal(t, 2*StartingBalance, target.Balance)
+	t.Log("Source balance was moved to the target")
+}
+
+func TestPendingConsolidationsBeforeElectra(t *testing.T) {
+	config := NewDefaultConfig()
+	config.ElectraForkEpoch = 1
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	source := addTestValidator(t, d, test.Pubkey0String)
+	source.Status = beacon.ValidatorState_ActiveOngoing
+	target := addTestValidator(t, d, test.Pubkey1String)
+	target.Status = beacon.ValidatorState_ActiveOngoing
+	target.WithdrawalCredentials[0] = CompoundingWithdrawalPrefix
+
+	// Requests should be rejected until Electra
+	_, err := d.AddPendingConsolidation(source.Index, target.Index)
+	require.Error(t, err)
+	require.Empty(t, d.GetPendingConsolidations())
+	require.Equal(t, beacon.ValidatorState_ActiveOngoing, source.Status)
+	t.Log("Consolidation was rejected before Electra")
+
+	commitEpochs(d, 1)
+	_, err = d.AddPendingConsolidation(source.Index, target.Index)
+	require.NoError(t, err)
+	t.Log("Consolidation was queued after Electra")
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/beacon/ssz_types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// A signed request to change a validator's BLS withdrawal credentials to an execution address
+type BlsToExecutionChange struct {
+	// The index of the validator being changed
+	ValidatorIndex uint64
+
+	// The BLS withdrawal pubkey the validator's current credentials were derived from
+	FromBlsPubkey beacon.ValidatorPubkey
+
+	// The execution address to send withdrawals to
+	ToExecutionAddress common.Address
+
+	// The signature of the change message, made by the withdrawal key
+	Signature beacon.ValidatorSignature
+}
+
+// Get the BLS-to-execution changes in the pool that haven't been applied yet
+func (db *Database) GetBlsToExecutionChanges() []*BlsToExecutionChange {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	changes := make([]*BlsToExecutionChange, len(db.blsToExecutionChanges))
+	copy(changes, db.blsToExecutionChanges)
+	return changes
+}
+
+// Validate a signed BLS-to-execution change and, if it's valid, add it to the pool so it's applied at the next epoch
+func (db *Database) SubmitBlsToExecutionChange(change *BlsToExecutionChange) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	// Check the validator
+	if change.ValidatorIndex >= uint64(len(db.validators)) {
+		return fmt.Errorf("validator with index %d does not exist", change.ValidatorIndex)
+	}
+	validator := db.validators[change.ValidatorIndex]
+	if validator.WithdrawalCredentials[0] != BlsWithdrawalPrefix {
+		return fmt.Errorf("validator %d does not have BLS withdrawal credentials", change.ValidatorIndex)
+	}
+	expectedCredentials := common.Hash(sha256.Sum256(change.FromBlsPubkey[:]))
+	expectedCredentials[0] = BlsWithdrawalPrefix
+	if expectedCredentials != validator.WithdrawalCredentials {
+		return fmt.Errorf("pubkey %s does not match the withdrawal credentials of validator %d", change.FromBlsPubkey.HexWithPrefix(), change.ValidatorIndex)
+	}
+	for _, pendingChange := range db.blsToExecutionChanges {
+		if pendingChange.ValidatorIndex == change.ValidatorIndex {
+			return fmt.Errorf("validator %d already has a pending BLS-to-execution change", change.ValidatorIndex)
+		}
+	}
+
+	// Changes are always signed with the genesis fork version
+	domain, err := db.config.ComputeDomain(eth2types.DomainBlsToExecutionChange, db.config.GenesisForkVersion)
+	if err != nil {
+		return err
+	}
+
+	// Verify the signature
+	message := ssz_types.WithdrawalCredentialsChange{
+		ValidatorIndex:     change.ValidatorIndex,
+		FromBLSPubkey:      change.FromBlsPubkey,
+		ToExecutionAddress: change.ToExecutionAddress,
+	}
+	root, err := message.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("error computing change message root: %w", err)
+	}
+	err = verifySignature(change.FromBlsPubkey, root, domain, change.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid BLS-to-execution change signature: %w", err)
+	}
+
+	db.blsToExecutionChanges = append(db.blsToExecutionChanges, change)
+	return nil
+}
+
+// Apply the pending BLS-to-execution changes at the start of a new epoch. The lock must be held by the caller.
+func (db *Database) processBlsToExecutionChanges() {
+	for _, change := range db.blsToExecutionChanges {
+		validator := db.validators[change.ValidatorIndex]
+		credentials := common.Hash{}
+		credentials[0] = Eth1AddressWithdrawalPrefix
+		copy(credentials[12:], change.ToExecutionAddress[:])
+		validator.WithdrawalCredentials = credentials
+	}
+	db.blsToExecutionChanges = []*BlsToExecutionChange{}
+}
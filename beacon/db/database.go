@@ -3,6 +3,8 @@ package db
 import (
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -17,33 +19,153 @@ type Database struct {
 	// Pending deposits
 	pendingDeposits []*Deposit
 
+	// Partial withdrawals waiting to be swept after Electra
+	pendingPartialWithdrawals []*PendingPartialWithdrawal
+
+	// Consolidations waiting to be processed after Electra
+	pendingConsolidations []*PendingConsolidation
+
+	// Proposer slashings accepted into the pool
+	proposerSlashings []*ProposerSlashing
+
+	// Attester slashings accepted into the pool
+	attesterSlashings []*AttesterSlashing
+
+	// The total effective balance slashed in each epoch, indexed by epoch modulo the slashings vector length
+	slashings []uint64
+
+	// Voluntary exits accepted into the pool
+	voluntaryExits []*VoluntaryExit
+
+	// BLS-to-execution changes waiting to be applied at the next epoch
+	blsToExecutionChanges []*BlsToExecutionChange
+
+	// The index to assign to the next withdrawal
+	nextWithdrawalIndex uint64
+
+	// The validator index to start the next withdrawal sweep from
+	nextWithdrawalValidatorIndex uint64
+
+	// The earliest epoch a validator can exit in after Electra
+	earliestExitEpoch uint64
+
+	// The balance left in the exit churn of the earliest exit epoch after Electra, in gwei
+	exitBalanceToConsume uint64
+
+	// The deposit churn carried over from previous epochs after Electra, in gwei
+	depositBalanceToConsume uint64
+
+	// Map of slots to the validators forced to propose in them
+	proposerOverrides map[uint64]uint64
+
+	// Map of sync committee periods to the validators forced into their committees
+	syncCommitteeOverrides map[uint64][]uint64
+
 	// Lookup of validators by pubkey
 	validatorPubkeyMap map[beacon.ValidatorPubkey]*Validator
 
 	// Map of slot indices to execution block indices
 	executionBlockMap map[uint64]uint64
 
+	// Map of slot indices to the blocks proposed in them
+	blockMap map[uint64]*Block
+
+	// Map of slot indices to the withdrawal cursors from before the blocks in them were proposed
+	withdrawalCursorMap map[uint64]withdrawalCursor
+
 	// Current slot
 	currentSlot uint64
 
 	// Highest slot
 	highestSlot uint64
 
+	// Current finality checkpoints
+	finality FinalityCheckpoints
+
+	// True if finality is stalled, so checkpoints don't advance on epoch transitions
+	finalityStalled bool
+
+	// The information the node reports about itself
+	nodeInfo NodeInfo
+
+	// True if rewards and penalties are applied at each epoch transition
+	rewardsEnabled bool
+
+	// The annual percentage rate active validators earn with full participation
+	rewardsApr float64
+
+	// Map of validator indices to their attestation participation rates
+	participation map[uint64]float64
+
+	// The history of each validator, indexed by validator index
+	validatorHistories []history[Validator]
+
+	// The history of the pending deposit queue
+	depositHistory history[[]Deposit]
+
+	// The history of the pending partial withdrawal queue
+	partialWithdrawalHistory history[[]PendingPartialWithdrawal]
+
+	// The history of the pending consolidation queue
+	consolidationHistory history[[]PendingConsolidation]
+
+	// The history of the finality checkpoints
+	finalityHistory history[FinalityCheckpoints]
+
+	// Map of state roots to the slots of the states they belong to
+	stateRootMap map[common.Hash]uint64
+
+	// The oldest slot that hasn't been pruned from the state history
+	oldestStateSlot uint64
+
 	// Internal fields
+	config                  *Config
 	logger                  *slog.Logger
 	lock                    *sync.Mutex
 	nextExecutionBlockIndex uint64
+	headBlockRoot           common.Hash
+	reorgCount              uint64
 }
 
-// Create a new database instance
+// Create a new database instance with the default config, linking the first proposed block to the given Execution block
 func NewDatabase(logger *slog.Logger, firstExecutionBlockIndex uint64) *Database {
+	config := NewDefaultConfig()
+	config.FirstExecutionBlockIndex = firstExecutionBlockIndex
+	return NewDatabaseWithConfig(logger, config)
+}
+
+// Create a new database instance with the given config
+func NewDatabaseWithConfig(logger *slog.Logger, config *Config) *Database {
 	return &Database{
-		logger:                  logger,
-		lock:                    &sync.Mutex{},
-		nextExecutionBlockIndex: firstExecutionBlockIndex,
-		validators:              []*Validator{},
-		validatorPubkeyMap:      make(map[beacon.ValidatorPubkey]*Validator),
-		executionBlockMap:       make(map[uint64]uint64),
+		config:                    config,
+		logger:                    logger,
+		lock:                      &sync.Mutex{},
+		nextExecutionBlockIndex:   config.FirstExecutionBlockIndex,
+		validators:                []*Validator{},
+		pendingDeposits:           []*Deposit{},
+		pendingPartialWithdrawals: []*PendingPartialWithdrawal{},
+		pendingConsolidations:     []*PendingConsolidation{},
+		voluntaryExits:            []*VoluntaryExit{},
+		proposerSlashings:         []*ProposerSlashing{},
+		attesterSlashings:         []*AttesterSlashing{},
+		slashings:                 make([]uint64, EpochsPerSlashingsVector),
+		blsToExecutionChanges:     []*BlsToExecutionChange{},
+		proposerOverrides:         make(map[uint64]uint64),
+		rewardsEnabled:            config.RewardsEnabled,
+		rewardsApr:                config.RewardsApr,
+		participation:             maps.Clone(config.Participation),
+		syncCommitteeOverrides:    make(map[uint64][]uint64),
+		validatorPubkeyMap:        make(map[beacon.ValidatorPubkey]*Validator),
+		executionBlockMap:         make(map[uint64]uint64),
+		withdrawalCursorMap:       make(map[uint64]withdrawalCursor),
+		blockMap:                  make(map[uint64]*Block),
+		validatorHistories:        []history[Validator]{},
+		depositHistory:            history[[]Deposit]{},
+		partialWithdrawalHistory:  history[[]PendingPartialWithdrawal]{},
+		consolidationHistory:      history[[]PendingConsolidation]{},
+		finalityHistory:           history[FinalityCheckpoints]{},
+		stateRootMap:              make(map[common.Hash]uint64),
+		nodeInfo:                  newNodeInfo(),
 	}
 }
 
@@ -55,12 +177,7 @@ func (db *Database) AddValidator(pubkey beacon.ValidatorPubkey, withdrawalCreden
 	if _, exists := db.validatorPubkeyMap[pubkey]; exists {
 		return nil, fmt.Errorf("validator with pubkey %s already exists", pubkey.HexWithPrefix())
 	}
-
-	index := len(db.validators)
-	validator := NewValidator(pubkey, withdrawalCredentials, uint64(index))
-	db.validators = append(db.validators, validator)
-	db.validatorPubkeyMap[pubkey] = validator
-	return validator, nil
+	return db.addValidator(pubkey, withdrawalCredentials), nil
 }
 
 // Get a validator by its index. Returns nil if it doesn't exist.
@@ -100,6 +217,14 @@ func (db *Database) GetCurrentSlot() uint64 {
 	return db.currentSlot
 }
 
+// Get the epoch of the latest local head slot
+func (db *Database) GetCurrentEpoch() uint64 {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.currentSlot / db.config.SlotsPerEpoch
+}
+
 // Get the highest slot on the chain (the actual chain head)
 func (db *Database) GetHighestSlot() uint64 {
 	db.lock.Lock()
@@ -139,6 +264,34 @@ func (db *Database) RemovePendingDeposit(deposit *Deposit) {
 	db.pendingDeposits = newDeposits
 }
 
+// Remove the pending deposits made in the given slot or later, such as ones from Execution blocks that were reverted.
+// They're also removed from the recorded states of those slots. Returns the removed deposits.
+func (db *Database) RemovePendingDepositsSince(slot uint64) []*Deposit {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	removed := []*Deposit{}
+	newDeposits := make([]*Deposit, 0, len(db.pendingDeposits))
+	for _, deposit := range db.pendingDeposits {
+		if deposit.Slot >= slot {
+			removed = append(removed, deposit)
+			continue
+		}
+		newDeposits = append(newDeposits, deposit)
+	}
+	db.pendingDeposits = newDeposits
+
+	for i, depositVersion := range db.depositHistory {
+		if depositVersion.slot < slot {
+			continue
+		}
+		db.depositHistory[i].value = slices.DeleteFunc(slices.Clone(depositVersion.value), func(deposit Deposit) bool {
+			return deposit.Slot >= slot
+		})
+	}
+	return removed
+}
+
 // Add a new block to the chain.
 // Set slotValidated to true to "propose a block" for the current slot, linking it to the next Execution block's index.
 // Set it to false to "miss" the slot, so there was not block proposed for it.
@@ -147,13 +300,18 @@ func (db *Database) CommitBlock(slotValidated bool) {
 	defer db.lock.Unlock()
 
 	if slotValidated {
-		db.executionBlockMap[db.currentSlot] = db.nextExecutionBlockIndex
-		db.nextExecutionBlockIndex++
+		db.addBlock(db.currentSlot)
 	}
+	db.recordState(db.currentSlot)
 	db.currentSlot++
 	if db.currentSlot > db.highestSlot {
 		db.highestSlot = db.currentSlot
 	}
+
+	// Run the epoch transition if this is the first slot of a new epoch
+	if db.currentSlot%db.config.SlotsPerEpoch == 0 {
+		db.processEpochTransition(db.currentSlot / db.config.SlotsPerEpoch)
+	}
 }
 
 // Set the highest slot on the chain - useful for simulating syncing conditions
@@ -171,9 +329,24 @@ func (db *Database) Clone() *Database {
 	db.lock.Lock()
 	defer db.lock.Unlock()
 
-	clone := NewDatabase(db.logger, db.nextExecutionBlockIndex)
+	clone := NewDatabaseWithConfig(db.logger, db.config)
+	clone.nextExecutionBlockIndex = db.nextExecutionBlockIndex
 	clone.currentSlot = db.currentSlot
 	clone.highestSlot = db.highestSlot
+	clone.finality = db.finality
+	clone.finalityStalled = db.finalityStalled
+	clone.nodeInfo = db.nodeInfo
+	clone.nodeInfo.Identity = db.nodeInfo.Identity.Clone()
+	clone.headBlockRoot = db.headBlockRoot
+	clone.reorgCount = db.reorgCount
+	clone.nextWithdrawalIndex = db.nextWithdrawalIndex
+	clone.rewardsEnabled = db.rewardsEnabled
+	clone.rewardsApr = db.rewardsApr
+	clone.participation = maps.Clone(db.participation)
+	clone.nextWithdrawalValidatorIndex = db.nextWithdrawalValidatorIndex
+	clone.earliestExitEpoch = db.earliestExitEpoch
+	clone.exitBalanceToConsume = db.exitBalanceToConsume
+	clone.depositBalanceToConsume = db.depositBalanceToConsume
 
 	cloneValidators := make([]*Validator, len(db.validators))
 	for i, validator := range db.validators {
@@ -183,8 +356,83 @@ func (db *Database) Clone() *Database {
 	}
 	clone.validators = cloneValidators
 
+	cloneDeposits := make([]*Deposit, len(db.pendingDeposits))
+	for i, deposit := range db.pendingDeposits {
+		cloneDeposit := *deposit
+		cloneDeposits[i] = &cloneDeposit
+	}
+	clone.pendingDeposits = cloneDeposits
+
+	clone.pendingPartialWithdrawals = clonePointers(db.pendingPartialWithdrawals)
+	clone.pendingConsolidations = clonePointers(db.pendingConsolidations)
+
+	clone.proposerSlashings = clonePointers(db.proposerSlashings)
+	clone.attesterSlashings = clonePointers(db.attesterSlashings)
+	for _, slashing := range clone.attesterSlashings {
+		slashing.Attestation1.AttestingIndices = slices.Clone(slashing.Attestation1.AttestingIndices)
+		slashing.Attestation2.AttestingIndices = slices.Clone(slashing.Attestation2.AttestingIndices)
+	}
+	clone.slashings = slices.Clone(db.slashings)
+
+	cloneExits := make([]*VoluntaryExit, len(db.voluntaryExits))
+	for i, exit := range db.voluntaryExits {
+		cloneExit := *exit
+		cloneExits[i] = &cloneExit
+	}
+	clone.voluntaryExits = cloneExits
+
+	cloneChanges := make([]*BlsToExecutionChange, len(db.blsToExecutionChanges))
+	for i, change := range db.blsToExecutionChanges {
+		cloneChange := *change
+		cloneChanges[i] = &cloneChange
+	}
+	clone.blsToExecutionChanges = cloneChanges
+
+	clone.validatorHistories = make([]history[Validator], len(db.validatorHistories))
+	for i, validatorHistory := range db.validatorHistories {
+		clone.validatorHistories[i] = slices.Clone(validatorHistory)
+	}
+	clone.depositHistory = slices.Clone(db.depositHistory)
+	clone.partialWithdrawalHistory = slices.Clone(db.partialWithdrawalHistory)
+	clone.consolidationHistory = slices.Clone(db.consolidationHistory)
+	clone.finalityHistory = slices.Clone(db.finalityHistory)
+	clone.stateRootMap = maps.Clone(db.stateRootMap)
+	clone.oldestStateSlot = db.oldestStateSlot
+
+	for slot, index := range db.proposerOverrides {
+		clone.proposerOverrides[slot] = index
+	}
+	for period, indices := range db.syncCommitteeOverrides {
+		clone.syncCommitteeOverrides[period] = append([]uint64{}, indices...)
+	}
+
 	for slot, block := range db.executionBlockMap {
 		clone.executionBlockMap[slot] = block
 	}
+	clone.withdrawalCursorMap = maps.Clone(db.withdrawalCursorMap)
+	for slot, block := range db.blockMap {
+		cloneBlock := *block
+		cloneBlock.Withdrawals = append([]Withdrawal{}, block.Withdrawals...)
+		clone.blockMap[slot] = &cloneBlock
+	}
+	return clone
+}
+
+// Add a new validator to the database without checking if it already exists. The lock must be held by the caller.
+func (db *Database) addValidator(pubkey beacon.ValidatorPubkey, withdrawalCredentials common.Hash) *Validator {
+	index := len(db.validators)
+	validator := NewValidator(pubkey, withdrawalCredentials, uint64(index))
+	db.validators = append(db.validators, validator)
+	db.validatorPubkeyMap[pubkey] = validator
+	return validator
+}
+
+// Make a copy of a slice of pointers, with each element pointing to a copy of the original
+func clonePointers[T any](source []*T) []*T {
+	clone := make([]*T, len(source))
+	for i, element := range source {
+		elementCopy := *element
+		clone[i] = &elementCopy
+	}
 	return clone
 }
@@ -1,12 +1,47 @@
 package db
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
 	"github.com/rocket-pool/node-manager-core/beacon"
 	"github.com/rocket-pool/node-manager-core/beacon/client"
+	"github.com/rocket-pool/node-manager-core/beacon/ssz_types"
 	"github.com/rocket-pool/node-manager-core/utils"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+const (
+	// ABI of the deposit contract's DepositEvent
+	depositEventAbiString string = `[{"anonymous":false,"inputs":[{"indexed":false,"internalType":"bytes","name":"pubkey","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"withdrawal_credentials","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"amount","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"signature","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"index","type":"bytes"}],"name":"DepositEvent","type":"event"}]`
+	depositEventName      string = "DepositEvent"
 )
 
+var (
+	// Parsed ABI of the deposit contract's DepositEvent
+	depositEventAbi abi.ABI = mustParseAbi(depositEventAbiString)
+
+	// The topic of the deposit contract's DepositEvent
+	DepositEventTopic common.Hash = depositEventAbi.Events[depositEventName].ID
+)
+
+// Raw DepositEvent log data
+type depositEvent struct {
+	Pubkey                []byte
+	WithdrawalCredentials []byte
+	Amount                []byte
+	Signature             []byte
+	Index                 []byte
+}
+
 type Deposit struct {
 	// The validator's public key
 	Pubkey beacon.ValidatorPubkey
@@ -24,6 +59,40 @@ type Deposit struct {
 	Slot uint64
 }
 
+// Create a deposit from a DepositEvent log emitted by the deposit contract
+func NewDepositFromEventLog(log types.Log, slot uint64) (*Deposit, error) {
+	if len(log.Topics) == 0 || log.Topics[0] != DepositEventTopic {
+		return nil, fmt.Errorf("log is not a deposit event")
+	}
+
+	// Decode the event
+	var event depositEvent
+	err := depositEventAbi.UnpackIntoInterface(&event, depositEventName, log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding deposit event: %w", err)
+	}
+	if len(event.Pubkey) != beacon.ValidatorPubkeyLength {
+		return nil, fmt.Errorf("invalid pubkey length %d", len(event.Pubkey))
+	}
+	if len(event.WithdrawalCredentials) != common.HashLength {
+		return nil, fmt.Errorf("invalid withdrawal credentials length %d", len(event.WithdrawalCredentials))
+	}
+	if len(event.Amount) != 8 {
+		return nil, fmt.Errorf("invalid amount length %d", len(event.Amount))
+	}
+	if len(event.Signature) != beacon.ValidatorSignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d", len(event.Signature))
+	}
+
+	return &Deposit{
+		Pubkey:                beacon.ValidatorPubkey(event.Pubkey),
+		WithdrawalCredentials: common.BytesToHash(event.WithdrawalCredentials),
+		Amount:                binary.LittleEndian.Uint64(event.Amount),
+		Signature:             beacon.ValidatorSignature(event.Signature),
+		Slot:                  slot,
+	}, nil
+}
+
 func (d Deposit) ConvertToNativeFormat() client.PendingDeposit {
 	return client.PendingDeposit{
 		Pubkey:                d.Pubkey[:],
@@ -33,3 +102,232 @@ func (d Deposit) ConvertToNativeFormat() client.PendingDeposit {
 		Slot:                  utils.Uinteger(d.Slot),
 	}
 }
+
+// An entry in a deposit data file, such as the deposit_data-*.json files generated by staking-deposit-cli
+type DepositData struct {
+	Pubkey                utils.ByteArray `json:"pubkey"`
+	WithdrawalCredentials utils.ByteArray `json:"withdrawal_credentials"`
+	Amount                uint64          `json:"amount"`
+	Signature             utils.ByteArray `json:"signature"`
+	DepositMessageRoot    utils.ByteArray `json:"deposit_message_root"`
+	DepositDataRoot       utils.ByteArray `json:"deposit_data_root"`
+	ForkVersion           utils.ByteArray `json:"fork_version"`
+	NetworkName           string          `json:"network_name"`
+}
+
+// The result of importing an entry from a deposit data file
+type DepositDataResult struct {
+	// The position of the entry in the file
+	Index int
+
+	// The validator's public key, or zero if the entry didn't have a valid one
+	Pubkey beacon.ValidatorPubkey
+
+	// The reason the entry couldn't be imported, or nil if it was imported
+	Error error
+}
+
+// Load the entries of a deposit data file
+func LoadDepositDataFile(path string) ([]DepositData, error) {
+	// Make sure the file exists
+	_, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("deposit data file [%s] does not exist", path)
+	}
+
+	// Read the file
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading deposit data file [%s]: %w", path, err)
+	}
+	var entries []DepositData
+	err = json.Unmarshal(bytes, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling deposit data file [%s]: %w", path, err)
+	}
+	return entries, nil
+}
+
+// Check a deposit data entry's signature and roots, returning it as a deposit made in the given slot if it's valid
+func (c *Config) VerifyDepositData(data DepositData, slot uint64) (*Deposit, error) {
+	// Check the lengths of the fields
+	if len(data.Pubkey) != beacon.ValidatorPubkeyLength {
+		return nil, fmt.Errorf("invalid pubkey length %d", len(data.Pubkey))
+	}
+	if len(data.WithdrawalCredentials) != common.HashLength {
+		return nil, fmt.Errorf("invalid withdrawal credentials length %d", len(data.WithdrawalCredentials))
+	}
+	if len(data.Signature) != beacon.ValidatorSignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d", len(data.Signature))
+	}
+	if len(data.DepositMessageRoot) != common.HashLength {
+		return nil, fmt.Errorf("invalid deposit message root length %d", len(data.DepositMessageRoot))
+	}
+	if len(data.DepositDataRoot) != common.HashLength {
+		return nil, fmt.Errorf("invalid deposit data root length %d", len(data.DepositDataRoot))
+	}
+	if len(data.ForkVersion) > 0 && !bytes.Equal(data.ForkVersion, c.GenesisForkVersion) {
+		return nil, fmt.Errorf("fork version %s does not match the genesis fork version %s", utils.EncodeHexWithPrefix(data.ForkVersion), utils.EncodeHexWithPrefix(c.GenesisForkVersion))
+	}
+	deposit := &Deposit{
+		Pubkey:                beacon.ValidatorPubkey(data.Pubkey),
+		WithdrawalCredentials: common.BytesToHash(data.WithdrawalCredentials),
+		Amount:                data.Amount,
+		Signature:             beacon.ValidatorSignature(data.Signature),
+		Slot:                  slot,
+	}
+
+	// Check the message root
+	message := ssz_types.DepositDataNoSignature{
+		PublicKey:             data.Pubkey,
+		WithdrawalCredentials: data.WithdrawalCredentials,
+		Amount:                data.Amount,
+	}
+	messageRoot, err := message.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("error computing deposit message root: %w", err)
+	}
+	if !bytes.Equal(data.DepositMessageRoot, messageRoot[:]) {
+		return nil, fmt.Errorf("deposit message root %s does not match the computed root %s", utils.EncodeHexWithPrefix(data.DepositMessageRoot), utils.EncodeHexWithPrefix(messageRoot[:]))
+	}
+
+	// Verify the signature
+	err = c.verifyDepositSignature(deposit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the deposit data root
+	depositData := ssz_types.DepositData{
+		PublicKey:             data.Pubkey,
+		WithdrawalCredentials: data.WithdrawalCredentials,
+		Amount:                data.Amount,
+		Signature:             data.Signature,
+	}
+	dataRoot, err := depositData.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("error computing deposit data root: %w", err)
+	}
+	if !bytes.Equal(data.DepositDataRoot, dataRoot[:]) {
+		return nil, fmt.Errorf("deposit data root %s does not match the computed root %s", utils.EncodeHexWithPrefix(data.DepositDataRoot), utils.EncodeHexWithPrefix(dataRoot[:]))
+	}
+	return deposit, nil
+}
+
+// Verify a deposit's signature over its message. Deposits are always signed with the genesis fork version and a zero
+// genesis validators root.
+func (c *Config) verifyDepositSignature(deposit *Deposit) error {
+	message := ssz_types.DepositDataNoSignature{
+		PublicKey:             deposit.Pubkey[:],
+		WithdrawalCredentials: deposit.WithdrawalCredentials[:],
+		Amount:                deposit.Amount,
+	}
+	messageRoot, err := message.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("error computing deposit message root: %w", err)
+	}
+	domain, err := eth2types.ComputeDomain(eth2types.DomainDeposit, c.GenesisForkVersion, eth2types.ZeroGenesisValidatorsRoot)
+	if err != nil {
+		return fmt.Errorf("error computing deposit domain: %w", err)
+	}
+	err = verifySignature(deposit.Pubkey, messageRoot, domain, deposit.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid deposit signature: %w", err)
+	}
+	return nil
+}
+
+// Import the valid entries of a deposit data file, returning the result of each one. Entries become pending deposits
+// made in the current slot, or if preActivate is set, validators that are active as of the current epoch.
+func (db *Database) ImportDepositData(entries []DepositData, preActivate bool) []DepositDataResult {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	results := make([]DepositDataResult, len(entries))
+	epoch := db.currentSlot / db.config.SlotsPerEpoch
+	for i, entry := range entries {
+		results[i].Index = i
+		deposit, err := db.config.VerifyDepositData(entry, db.currentSlot)
+		if err != nil {
+			results[i].Error = fmt.Errorf("entry %d: %w", i, err)
+			continue
+		}
+		results[i].Pubkey = deposit.Pubkey
+
+		if !preActivate {
+			db.pendingDeposits = append(db.pendingDeposits, deposit)
+			continue
+		}
+
+		// Add the validator as if its deposit had already been processed and it had made it through the activation queue
+		if _, exists := db.validatorPubkeyMap[deposit.Pubkey]; exists {
+			results[i].Error = fmt.Errorf("entry %d: validator with pubkey %s already exists", i, deposit.Pubkey.HexWithPrefix())
+			continue
+		}
+		if deposit.Amount < MinActivationBalance {
+			results[i].Error = fmt.Errorf("entry %d: amount %d is less than the minimum activation balance", i, deposit.Amount)
+			continue
+		}
+		validator := db.addValidator(deposit.Pubkey, deposit.WithdrawalCredentials)
+		validator.Balance = deposit.Amount
+		validator.EffectiveBalance = min(deposit.Amount-deposit.Amount%EffectiveBalanceIncrement, db.getMaxEffectiveBalance(validator, epoch))
+		validator.Status = beacon.ValidatorState_ActiveOngoing
+		validator.ActivationEligibilityEpoch = epoch
+		validator.ActivationEpoch = epoch
+	}
+	return results
+}
+
+// Parse an ABI definition, panicking if it's invalid
+func mustParseAbi(abiString string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(abiString))
+	if err != nil {
+		panic(fmt.Sprintf("error parsing ABI: %v", err))
+	}
+	return parsed
+}
+
+// Process the queue of pending deposits at the start of a new epoch.
+// Deposits for new pubkeys create validators if their signatures are valid, and are dropped otherwise. Deposits for
+// existing ones top up their balances.
+// Only deposits made at or before the finalized slot are processed, up to the per-epoch limit.
+// After Electra, the deposited balance is also limited by the activation churn.
+func (db *Database) processPendingDeposits(epoch uint64) {
+	isElectra := db.config.IsElectraActive(epoch)
+	availableForProcessing := db.depositBalanceToConsume + db.getActivationExitChurnLimit()
+	processedAmount := uint64(0)
+	churnLimitReached := false
+
+	finalizedSlot := db.finality.Finalized.Epoch * db.config.SlotsPerEpoch
+	processedCount := 0
+	for _, deposit := range db.pendingDeposits {
+		if uint64(processedCount) >= db.config.MaxPendingDepositsPerEpoch || deposit.Slot > finalizedSlot {
+			break
+		}
+		if isElectra && processedAmount+deposit.Amount > availableForProcessing {
+			churnLimitReached = true
+			break
+		}
+
+		validator, exists := db.validatorPubkeyMap[deposit.Pubkey]
+		if exists {
+			validator.SetBalance(validator.Balance + deposit.Amount)
+		} else if err := db.config.verifyDepositSignature(deposit); err != nil {
+			db.logger.Warn("Dropped deposit for a new validator", "pubkey", deposit.Pubkey.HexWithPrefix(), "error", err)
+		} else {
+			validator = db.addValidator(deposit.Pubkey, deposit.WithdrawalCredentials)
+			validator.Balance = deposit.Amount
+			validator.EffectiveBalance = min(deposit.Amount-deposit.Amount%EffectiveBalanceIncrement, db.getMaxEffectiveBalance(validator, epoch))
+		}
+		processedAmount += deposit.Amount
+		processedCount++
+	}
+	db.pendingDeposits = db.pendingDeposits[processedCount:]
+
+	// Carry the unused churn over to the next epoch if the deposits were limited by it
+	if churnLimitReached {
+		db.depositBalanceToConsume = availableForProcessing - processedAmount
+	} else {
+		db.depositBalanceToConsume = 0
+	}
+}
@@ -0,0 +1,345 @@
+package db
+
+import (
+	"encoding/binary"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/internal/test"
+	"github.com/nodeset-org/osha/keys"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/node/validator"
+	"github.com/rocket-pool/node-manager-core/utils"
+	"github.com/stretchr/testify/require"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestDepositCreatesValidator(t *testing.T) {
+	d := NewDatabase(slog.Default(), 0)
+	withdrawalCreds := validator.GetWithdrawalCredsFromAddress(common.HexToAddress(test.WithdrawalCredentialsString))
+
+	// Add a deposit for a new validator
+	deposit := createDepositForTesting(t, d.config, 0, withdrawalCreds, 32e9)
+	pubkey := deposit.Pubkey
+	d.AddPendingDeposit(deposit)
+	require.Nil(t, d.GetValidatorByPubkey(pubkey))
+	t.Log("Added deposit")
+
+	// Process it
+	commitEpochs(d, 1)
+	v := d.GetValidatorByPubkey(pubkey)
+	require.NotNil(t, v)
+	require.Equal(t, uint64(32e9), v.Balance)
+	require.Equal(t, uint64(32e9), v.EffectiveBalance)
+	require.Equal(t, withdrawalCreds, v.WithdrawalCredentials)
+	require.Empty(t, d.GetPendingDeposits())
+	t.Log("Deposit created a new validator")
+
+	// Top it up
+	d.AddPendingDeposit(&Deposit{
+		Pubkey:                pubkey,
+		WithdrawalCredentials: withdrawalCreds,
+		Amount:                2e9,
+	})
+	commitEpochs(d, 1)
+	require.Equal(t, uint64(34e9), v.Balance)
+	require.Len(t, d.GetAllValidators(), 1)
+	require.Empty(t, d.GetPendingDeposits())
+	t.Log("Deposit topped up the validator")
+}
+
+func TestDepositLimit(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	count := config.MaxPendingDepositsPerEpoch + 4
+	for i := uint64(0); i < count; i++ {
+		d.AddPendingDeposit(createDepositForTesting(t, config, uint(i), common.Hash{}, 32e9))
+	}
+
+	// Only the limit should be processed in the first epoch
+	commitEpochs(d, 1)
+	require.Len(t, d.GetAllValidators(), int(config.MaxPendingDepositsPerEpoch))
+	require.Len(t, d.GetPendingDeposits(), 4)
+	t.Log("First batch was processed")
+
+	// The rest should be processed in the next one
+	commitEpochs(d, 1)
+	require.Len(t, d.GetAllValidators(), int(count))
+	require.Empty(t, d.GetPendingDeposits())
+	t.Log("Second batch was processed")
+}
+
+func TestElectraDeposits(t *testing.T) {
+	config := NewDefaultConfig()
+	config.ElectraForkEpoch = 0
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	compoundingCreds := validator.GetWithdrawalCredsFromAddress(common.HexToAddress(test.WithdrawalCredentialsString))
+	compoundingCreds[0] = CompoundingWithdrawalPrefix
+
+	// Add a compounding deposit with more than 32 ETH, followed by enough regular ones to exceed the churn
+	compoundingDeposit := createDepositForTesting(t, config, 4, compoundingCreds, 64e9)
+	d.AddPendingDeposit(compoundingDeposit)
+	for i := uint(0); i < 4; i++ {
+		d.AddPendingDeposit(createDepositForTesting(t, config, i, common.Hash{}, 32e9))
+	}
+
+	// Only 128 ETH of deposits fit in the churn
+	commitEpochs(d, 1)
+	require.Len(t, d.GetAllValidators(), 3)
+	require.Len(t, d.GetPendingDeposits(), 2)
+	compounding := d.GetValidatorByIndex(0)
+	require.Equal(t, uint64(64e9), compounding.EffectiveBalance)
+	t.Log("First batch was processed and the compounding validator has a 64 ETH effective balance")
+
+	// The rest should be processed in the next one
+	commitEpochs(d, 1)
+	require.Len(t, d.GetAllValidators(), 5)
+	require.Empty(t, d.GetPendingDeposits())
+	t.Log("Second batch was processed")
+
+	// The same deposit before Electra is capped at 32 ETH
+	config = NewDefaultConfig()
+	d = NewDatabaseWithConfig(slog.Default(), config)
+	d.AddPendingDeposit(createDepositForTesting(t, config, 4, compoundingCreds, 64e9))
+	commitEpochs(d, 1)
+	require.Equal(t, MaxEffectiveBalance, d.GetValidatorByIndex(0).EffectiveBalance)
+	t.Log("Compounding deposit was capped before Electra")
+}
+
+func TestDepositWaitsForFinality(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	commitEpochs(d, 1)
+
+	// Add a deposit in epoch 1
+	deposit := createDepositForTesting(t, config, 0, common.Hash{}, 32e9)
+	deposit.Slot = d.GetCurrentSlot() + 1
+	d.AddPendingDeposit(deposit)
+
+	// It shouldn't be processed until epoch 2 is finalized
+	commitEpochs(d, 2)
+	require.Empty(t, d.GetAllValidators())
+	require.Len(t, d.GetPendingDeposits(), 1)
+	t.Log("Deposit is pending before finality")
+
+	commitEpochs(d, 1)
+	require.Len(t, d.GetAllValidators(), 1)
+	require.Empty(t, d.GetPendingDeposits())
+	t.Log("Deposit was processed after finality")
+}
+
+func TestDepositWithInvalidSignature(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+
+	// Add a deposit whose amount doesn't match what was signed, followed by a valid one
+	invalid := createDepositForTesting(t, config, 0, common.Hash{}, 32e9)
+	invalid.Amount = 33e9
+	d.AddPendingDeposit(invalid)
+	valid := createDepositForTesting(t, config, 1, common.Hash{}, 32e9)
+	d.AddPendingDeposit(valid)
+
+	// Only the valid one should create a validator
+	commitEpochs(d, 1)
+	require.Len(t, d.GetAllValidators(), 1)
+	require.Nil(t, d.GetValidatorByPubkey(invalid.Pubkey))
+	require.NotNil(t, d.GetValidatorByPubkey(valid.Pubkey))
+	require.Empty(t, d.GetPendingDeposits())
+	t.Log("Deposit with an invalid signature was dropped")
+}
+
+func TestRemovePendingDepositsSince(t *testing.T) {
+	d := NewDatabase(slog.Default(), 0)
+	kept := &Deposit{Pubkey: beacon.ValidatorPubkey{0x01}, Amount: 32e9, Slot: 0}
+	d.AddPendingDeposit(kept)
+	d.CommitBlock(true)
+	d.AddPendingDeposit(&Deposit{Pubkey: beacon.ValidatorPubkey{0x02}, Amount: 32e9, Slot: 1})
+	d.CommitBlock(true)
+	require.Len(t, d.GetState(1).PendingDeposits, 2)
+
+	// Remove the deposits from slot 1 onward
+	removed := d.RemovePendingDepositsSince(1)
+	require.Len(t, removed, 1)
+	require.Equal(t, []*Deposit{kept}, d.GetPendingDeposits())
+	require.Len(t, d.GetState(0).PendingDeposits, 1)
+	require.Len(t, d.GetState(1).PendingDeposits, 1)
+	t.Log("Deposits from the removed slots are gone from the queue and the recorded states")
+}
+
+func TestDepositFromEventLog(t *testing.T) {
+	pubkey, err := beacon.HexToValidatorPubkey(test.Pubkey0String)
+	if err != nil {
+		t.Fatalf("Error parsing pubkey: %v", err)
+	}
+	withdrawalCreds := validator.GetWithdrawalCredsFromAddress(common.HexToAddress(test.WithdrawalCredentialsString))
+	signature := beacon.ValidatorSignature{0x01, 0x02}
+	amount := make([]byte, 8)
+	binary.LittleEndian.PutUint64(amount, 32e9)
+	index := make([]byte, 8)
+
+	// Build the log
+	data, err := depositEventAbi.Events[depositEventName].Inputs.Pack(pubkey[:], withdrawalCreds[:], amount, signature[:], index)
+	if err != nil {
+		t.Fatalf("Error packing event data: %v", err)
+	}
+	log := types.Log{
+		Address: DefaultDepositContractAddress,
+		Topics:  []common.Hash{DepositEventTopic},
+		Data:    data,
+	}
+	t.Log("Created deposit event log")
+
+	// Decode it
+	deposit, err := NewDepositFromEventLog(log, 12)
+	require.NoError(t, err)
+	require.Equal(t, pubkey, deposit.Pubkey)
+	require.Equal(t, withdrawalCreds, deposit.WithdrawalCredentials)
+	require.Equal(t, uint64(32e9), deposit.Amount)
+	require.Equal(t, signature, deposit.Signature)
+	require.Equal(t, uint64(12), deposit.Slot)
+	t.Log("Deposit decoded correctly")
+
+	// Make sure other events are rejected
+	log.Topics[0] = common.Hash{0x01}
+	_, err = NewDepositFromEventLog(log, 12)
+	require.Error(t, err)
+}
+
+func TestImportDepositData(t *testing.T) {
+	d := NewDatabase(slog.Default(), 0)
+	d.CommitBlock(true)
+	entries := []DepositData{
+		createDepositDataForTesting(t, d.config, 0),
+		createDepositDataForTesting(t, d.config, 1),
+	}
+
+	// Write the entries to a file the way staking-deposit-cli does, with hex values that don't have a 0x prefix
+	path := filepath.Join(t.TempDir(), "deposit_data-1.json")
+	bytes, err := json.Marshal(entries)
+	require.NoError(t, err)
+	contents := strings.ReplaceAll(string(bytes), `"0x`, `"`)
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+	loaded, err := LoadDepositDataFile(path)
+	require.NoError(t, err)
+	require.Equal(t, entries, loaded)
+	t.Log("Loaded deposit data file")
+
+	// Add some invalid entries
+	wrongSignature := loaded[0]
+	wrongSignature.Signature = loaded[1].Signature
+	wrongRoot := loaded[1]
+	wrongRoot.DepositDataRoot = loaded[0].DepositDataRoot
+	wrongFork := loaded[1]
+	wrongFork.ForkVersion = d.config.CapellaForkVersion
+	shortPubkey := loaded[1]
+	shortPubkey.Pubkey = shortPubkey.Pubkey[:47]
+	wrongMessageRoot := loaded[1]
+	wrongMessageRoot.DepositMessageRoot = loaded[0].DepositMessageRoot
+	missingDataRoot := loaded[1]
+	missingDataRoot.DepositDataRoot = nil
+	loaded = append(loaded, wrongSignature, wrongRoot, wrongFork, shortPubkey, wrongMessageRoot, missingDataRoot)
+
+	// Import them as pending deposits
+	results := d.ImportDepositData(loaded, false)
+	require.Len(t, results, 8)
+	require.NoError(t, results[0].Error)
+	require.NoError(t, results[1].Error)
+	require.ErrorContains(t, results[2].Error, "entry 2: invalid deposit signature")
+	require.ErrorContains(t, results[3].Error, "entry 3: deposit data root")
+	require.ErrorContains(t, results[4].Error, "entry 4: fork version")
+	require.ErrorContains(t, results[5].Error, "entry 5: invalid pubkey length 47")
+	require.ErrorContains(t, results[6].Error, "entry 6: deposit message root")
+	require.ErrorContains(t, results[7].Error, "entry 7: invalid deposit data root length 0")
+	require.Equal(t, 5, results[5].Index)
+	t.Log("Each invalid entry was reported")
+
+	deposits := d.GetPendingDeposits()
+	require.Len(t, deposits, 2)
+	for i, deposit := range deposits {
+		require.Equal(t, results[i].Pubkey, deposit.Pubkey)
+		require.Equal(t, uint64(32e9), deposit.Amount)
+		require.Equal(t, d.GetCurrentSlot(), deposit.Slot)
+	}
+	t.Log("Valid entries were added as pending deposits")
+}
+
+func TestImportDepositDataPreActivated(t *testing.T) {
+	d := NewDatabase(slog.Default(), 0)
+	commitEpochs(d, 2)
+	entry := createDepositDataForTesting(t, d.config, 0)
+
+	// Import the entry as an active validator
+	results := d.ImportDepositData([]DepositData{entry}, true)
+	require.NoError(t, results[0].Error)
+	v := d.GetValidatorByPubkey(results[0].Pubkey)
+	require.NotNil(t, v)
+	require.Equal(t, beacon.ValidatorState_ActiveOngoing, v.Status)
+	require.Equal(t, uint64(32e9), v.Balance)
+	require.Equal(t, uint64(32e9), v.EffectiveBalance)
+	require.Equal(t, d.GetCurrentEpoch(), v.ActivationEpoch)
+	require.Empty(t, d.GetPendingDeposits())
+	t.Log("Entry was imported as an active validator")
+
+	// It stays active through the next epoch
+	commitEpochs(d, 1)
+	require.Equal(t, beacon.ValidatorState_ActiveOngoing, v.Status)
+
+	// Importing it again should fail
+	results = d.ImportDepositData([]DepositData{entry}, true)
+	require.ErrorContains(t, results[0].Error, "already exists")
+	t.Log("Duplicate entry was rejected")
+}
+
+// Create a signed deposit data entry for a validator key derived from the default test mnemonic
+func createDepositDataForTesting(t *testing.T, config *Config, keyIndex uint) DepositData {
+	key := getBlsKeyForTesting(t, keyIndex)
+	withdrawalCreds := validator.GetWithdrawalCredsFromAddress(common.HexToAddress(test.WithdrawalCredentialsString))
+	data, err := validator.GetDepositData(key, withdrawalCreds, config.GenesisForkVersion, 32e9, "holesky")
+	if err != nil {
+		t.Fatalf("Error creating deposit data: %v", err)
+	}
+	return DepositData{
+		Pubkey:                utils.ByteArray(data.PublicKey),
+		WithdrawalCredentials: utils.ByteArray(data.WithdrawalCredentials),
+		Amount:                data.Amount,
+		Signature:             utils.ByteArray(data.Signature),
+		DepositMessageRoot:    utils.ByteArray(data.DepositMessageRoot),
+		DepositDataRoot:       utils.ByteArray(data.DepositDataRoot),
+		ForkVersion:           utils.ByteArray(data.ForkVersion),
+		NetworkName:           data.NetworkName,
+	}
+}
+
+// Create a signed deposit for a validator key derived from the default test mnemonic
+func createDepositForTesting(t *testing.T, config *Config, keyIndex uint, withdrawalCreds common.Hash, amount uint64) *Deposit {
+	key := getBlsKeyForTesting(t, keyIndex)
+	data, err := validator.GetDepositData(key, withdrawalCreds, config.GenesisForkVersion, amount, "holesky")
+	if err != nil {
+		t.Fatalf("Error creating deposit data: %v", err)
+	}
+	return &Deposit{
+		Pubkey:                beacon.ValidatorPubkey(data.PublicKey),
+		WithdrawalCredentials: withdrawalCreds,
+		Amount:                amount,
+		Signature:             beacon.ValidatorSignature(data.Signature),
+	}
+}
+
+// Get a BLS key derived from the default test mnemonic
+func getBlsKeyForTesting(t *testing.T, keyIndex uint) *eth2types.BLSPrivateKey {
+	keygen, err := keys.NewKeyGeneratorWithDefaults()
+	if err != nil {
+		t.Fatalf("Error creating key generator: %v", err)
+	}
+	key, err := keygen.GetBlsPrivateKey(keyIndex)
+	if err != nil {
+		t.Fatalf("Error getting BLS key %d: %v", keyIndex, err)
+	}
+	return key
+}
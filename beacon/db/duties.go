@@ -0,0 +1,151 @@
+package db
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"slices"
+)
+
+// A validator's assignment to propose the block in a slot
+type ProposerDuty struct {
+	// The slot to propose in
+	Slot uint64
+
+	// The index of the validator assigned to propose
+	ValidatorIndex uint64
+}
+
+// Get the validators assigned to propose in each slot of the given epoch. Proposers for past epochs are picked from the
+// validators that were active at the start of the epoch. Slots without any active validators to pick from have no duty.
+func (db *Database) GetProposerDuties(epoch uint64) []ProposerDuty {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	// Seed past epochs from the state at their first slot, falling back to the current validators if it was pruned
+	firstSlot := epoch * db.config.SlotsPerEpoch
+	validators := db.validators
+	if firstSlot < db.currentSlot/db.config.SlotsPerEpoch*db.config.SlotsPerEpoch {
+		state := db.getState(firstSlot)
+		if state != nil {
+			validators = state.Validators
+		}
+	}
+	activeIndices := getActiveValidatorIndices(validators)
+
+	duties := []ProposerDuty{}
+	for slot := firstSlot; slot < firstSlot+db.config.SlotsPerEpoch; slot++ {
+		index, exists := db.getProposerIndex(slot, activeIndices)
+		if exists {
+			duties = append(duties, ProposerDuty{Slot: slot, ValidatorIndex: index})
+		}
+	}
+	return duties
+}
+
+// Force a validator to be the proposer for the given slot
+func (db *Database) SetProposer(slot uint64, index uint64) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if index >= uint64(len(db.validators)) {
+		return fmt.Errorf("validator with index %d does not exist", index)
+	}
+	if slot < db.currentSlot {
+		return fmt.Errorf("slot %d has already passed (current slot: %d)", slot, db.currentSlot)
+	}
+	db.proposerOverrides[slot] = index
+	return nil
+}
+
+// Get the indices of the validators in each position of the sync committee for the given epoch
+func (db *Database) GetSyncCommittee(epoch uint64) []uint64 {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.getSyncCommittee(epoch / db.config.EpochsPerSyncCommitteePeriod)
+}
+
+// Force a validator into the sync committee for the current period
+func (db *Database) AddToSyncCommittee(index uint64) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if index >= uint64(len(db.validators)) {
+		return fmt.Errorf("validator with index %d does not exist", index)
+	}
+	period := db.currentSlot / db.config.SlotsPerEpoch / db.config.EpochsPerSyncCommitteePeriod
+	overrides := db.syncCommitteeOverrides[period]
+	if slices.Contains(overrides, index) {
+		return nil
+	}
+	if uint64(len(overrides)) >= db.config.SyncCommitteeSize {
+		return fmt.Errorf("the sync committee for period %d is already full of forced validators", period)
+	}
+	db.syncCommitteeOverrides[period] = append(overrides, index)
+	return nil
+}
+
+// Deterministically pick the proposer for a slot from the given active validators, using a seed for the slot's epoch.
+// Returns false if the slot's proposer wasn't overridden and there are no active validators to pick from.
+// The lock must be held by the caller.
+func (db *Database) getProposerIndex(slot uint64, activeIndices []uint64) (uint64, bool) {
+	index, exists := db.proposerOverrides[slot]
+	if exists {
+		return index, true
+	}
+
+	if len(activeIndices) == 0 {
+		return 0, false
+	}
+	seed := getSeed("proposer", slot/db.config.SlotsPerEpoch)
+	return activeIndices[getRandomValue(seed, slot)%uint64(len(activeIndices))], true
+}
+
+// Deterministically pick the sync committee for a period from the active validators, putting any forced validators first.
+// The lock must be held by the caller.
+func (db *Database) getSyncCommittee(period uint64) []uint64 {
+	activeIndices := getActiveValidatorIndices(db.validators)
+	overrides := db.syncCommitteeOverrides[period]
+	if len(activeIndices) == 0 && len(overrides) == 0 {
+		return []uint64{}
+	}
+
+	committee := make([]uint64, db.config.SyncCommitteeSize)
+	seed := getSeed("sync", period)
+	for i := range committee {
+		if i < len(overrides) {
+			committee[i] = overrides[i]
+			continue
+		}
+		if len(activeIndices) == 0 {
+			return committee[:i]
+		}
+		committee[i] = activeIndices[getRandomValue(seed, uint64(i))%uint64(len(activeIndices))]
+	}
+	return committee
+}
+
+// Get the indices of the active validators in a list of validators
+func getActiveValidatorIndices(validators []*Validator) []uint64 {
+	activeIndices := []uint64{}
+	for _, validator := range validators {
+		if validator.IsActive() {
+			activeIndices = append(activeIndices, validator.Index)
+		}
+	}
+	return activeIndices
+}
+
+// Get a deterministic seed for a duty type and period
+func getSeed(label string, period uint64) [32]byte {
+	buffer := binary.LittleEndian.AppendUint64([]byte(label), period)
+	return sha256.Sum256(buffer)
+}
+
+// Deterministically get a pseudorandom value for a position using a seed
+func getRandomValue(seed [32]byte, position uint64) uint64 {
+	buffer := binary.LittleEndian.AppendUint64(seed[:], position)
+	hash := sha256.Sum256(buffer)
+	return binary.LittleEndian.Uint64(hash[:8])
+}
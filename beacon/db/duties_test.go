@@ -0,0 +1,103 @@
+package db
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/nodeset-org/osha/beacon/internal/test"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProposerDuties(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	pending := addTestValidator(t, d, test.Pubkey0String)
+	for _, pubkey := range []string{test.Pubkey1String, test.Pubkey2String, test.Pubkey3String} {
+		v := addTestValidator(t, d, pubkey)
+		v.Status = beacon.ValidatorState_ActiveOngoing
+	}
+
+	// Proposers should be deterministic and only come from active validators
+	duties := d.GetProposerDuties(1)
+	require.Len(t, duties, int(config.SlotsPerEpoch))
+	require.Equal(t, duties, d.Clone().GetProposerDuties(1))
+	for i, duty := range duties {
+		require.Equal(t, config.SlotsPerEpoch+uint64(i), duty.Slot)
+		require.NotEqual(t, pending.Index, duty.ValidatorIndex)
+	}
+	t.Log("Proposers are deterministic")
+
+	// Override a slot
+	slot := config.SlotsPerEpoch + 3
+	err := d.SetProposer(slot, pending.Index)
+	require.NoError(t, err)
+	require.Equal(t, pending.Index, d.GetProposerDuties(1)[3].ValidatorIndex)
+	t.Log("Proposer override applied")
+
+	// Blocks should use the overridden proposer
+	commitEpochs(d, 1)
+	for i := uint64(0); i < 4; i++ {
+		d.CommitBlock(true)
+	}
+	require.Equal(t, pending.Index, d.GetBlockBySlot(slot).ProposerIndex)
+	require.Error(t, d.SetProposer(slot, pending.Index))
+	t.Log("Block was proposed by the overridden proposer")
+}
+
+func TestProposerDutiesWithoutActiveValidators(t *testing.T) {
+	d := NewDatabase(slog.Default(), 0)
+	require.Empty(t, d.GetProposerDuties(0))
+	addTestValidator(t, d, test.Pubkey0String)
+	require.Empty(t, d.GetProposerDuties(0))
+	t.Log("No duties without active validators")
+}
+
+func TestPastProposerDuties(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	for _, pubkey := range []string{test.Pubkey0String, test.Pubkey1String} {
+		v := addTestValidator(t, d, pubkey)
+		v.Status = beacon.ValidatorState_ActiveOngoing
+	}
+	commitEpochs(d, 1)
+	duties := d.GetProposerDuties(1)
+
+	// Activate more validators in the next epoch
+	commitEpochs(d, 1)
+	for _, pubkey := range []string{test.Pubkey2String, test.Pubkey3String} {
+		v := addTestValidator(t, d, pubkey)
+		v.Status = beacon.ValidatorState_ActiveOngoing
+	}
+
+	// Duties for the past epoch shouldn't change
+	require.Equal(t, duties, d.GetProposerDuties(1))
+	t.Log("Past duties come from the state at the start of their epoch")
+}
+
+func TestSyncCommittee(t *testing.T) {
+	config := NewDefaultConfig()
+	config.SyncCommitteeSize = 8
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	pending := addTestValidator(t, d, test.Pubkey0String)
+	for _, pubkey := range []string{test.Pubkey1String, test.Pubkey2String, test.Pubkey3String} {
+		v := addTestValidator(t, d, pubkey)
+		v.Status = beacon.ValidatorState_ActiveOngoing
+	}
+
+	// The committee should be the same for every epoch in the period
+	committee := d.GetSyncCommittee(0)
+	require.Len(t, committee, 8)
+	require.Equal(t, committee, d.GetSyncCommittee(config.EpochsPerSyncCommitteePeriod-1))
+	require.NotContains(t, committee, pending.Index)
+	t.Log("Sync committee is deterministic")
+
+	// Force the pending validator in
+	err := d.AddToSyncCommittee(pending.Index)
+	require.NoError(t, err)
+	committee = d.GetSyncCommittee(0)
+	require.Len(t, committee, 8)
+	require.Equal(t, pending.Index, committee[0])
+	require.NotContains(t, d.GetSyncCommittee(config.EpochsPerSyncCommitteePeriod), pending.Index)
+	t.Log("Sync committee override applied to the current period only")
+}
@@ -0,0 +1,29 @@
+package db
+
+// Run the state transition at the start of a new epoch. The lock must be held by the caller.
+func (db *Database) processEpochTransition(epoch uint64) {
+	db.processFinality(epoch)
+	db.processRewardsAndPenalties()
+	db.processRegistryUpdates(epoch)
+	db.processSlashings(epoch)
+	db.processPendingDeposits(epoch)
+	db.processPendingConsolidations(epoch)
+	db.processEffectiveBalanceUpdates(epoch)
+	db.processBlsToExecutionChanges()
+	db.pruneStates()
+}
+
+// Update each validator's effective balance if its balance has moved far enough away from it, rounding down to a whole
+// increment and capping it based on its withdrawal credentials. The lock must be held by the caller.
+// See https://github.com/ethereum/consensus-specs/blob/dev/specs/electra/beacon-chain.md#effective-balances-updates
+func (db *Database) processEffectiveBalanceUpdates(epoch uint64) {
+	hysteresisIncrement := EffectiveBalanceIncrement / HysteresisQuotient
+	downwardThreshold := hysteresisIncrement * HysteresisDownwardMultiplier
+	upwardThreshold := hysteresisIncrement * HysteresisUpwardMultiplier
+	for _, validator := range db.validators {
+		balance := validator.Balance
+		if balance+downwardThreshold < validator.EffectiveBalance || validator.EffectiveBalance+upwardThreshold < balance {
+			validator.EffectiveBalance = min(balance-balance%EffectiveBalanceIncrement, db.getMaxEffectiveBalance(validator, epoch))
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/nodeset-org/osha/beacon/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveBalanceUpdates(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	v := addTestValidator(t, d, test.Pubkey0String)
+	compounding := addTestValidator(t, d, test.Pubkey1String)
+	compounding.WithdrawalCredentials[0] = CompoundingWithdrawalPrefix
+
+	// Setting the balance doesn't change the effective balance until the next epoch
+	v.SetBalance(31.74e9)
+	require.Equal(t, StartingBalance, v.EffectiveBalance)
+	missSlots(d, config.SlotsPerEpoch)
+	require.Equal(t, uint64(31e9), v.EffectiveBalance)
+	t.Log("Effective balance dropped by a full increment")
+
+	// Small drops stay within the hysteresis
+	v.SetBalance(30.76e9)
+	missSlots(d, config.SlotsPerEpoch)
+	require.Equal(t, uint64(31e9), v.EffectiveBalance)
+	v.SetBalance(30.74e9)
+	missSlots(d, config.SlotsPerEpoch)
+	require.Equal(t, uint64(30e9), v.EffectiveBalance)
+	t.Log("Effective balance ignored drops below the downward threshold")
+
+	// Increases need to pass the upward threshold
+	v.SetBalance(31.25e9)
+	missSlots(d, config.SlotsPerEpoch)
+	require.Equal(t, uint64(30e9), v.EffectiveBalance)
+	v.SetBalance(31.26e9)
+	missSlots(d, config.SlotsPerEpoch)
+	require.Equal(t, uint64(31e9), v.EffectiveBalance)
+	t.Log("Effective balance ignored increases below the upward threshold")
+
+	// Both validators are capped at 32 ETH before Electra
+	v.SetBalance(40e9)
+	compounding.SetBalance(40e9)
+	missSlots(d, config.SlotsPerEpoch)
+	require.Equal(t, MaxEffectiveBalance, v.EffectiveBalance)
+	require.Equal(t, MaxEffectiveBalance, compounding.EffectiveBalance)
+	t.Log("Effective balances were capped before Electra")
+
+	// Compounding validators can go higher after Electra
+	config.ElectraForkEpoch = 0
+	missSlots(d, config.SlotsPerEpoch)
+	require.Equal(t, MinActivationBalance, v.EffectiveBalance)
+	require.Equal(t, uint64(40e9), compounding.EffectiveBalance)
+	t.Log("Compounding validator's effective balance was raised after Electra")
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/beacon/ssz_types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// A signed voluntary exit submitted to the Beacon chain
+type VoluntaryExit struct {
+	// The earliest epoch the exit can be processed in
+	Epoch uint64
+
+	// The index of the exiting validator
+	ValidatorIndex uint64
+
+	// The signature of the exit message
+	Signature beacon.ValidatorSignature
+}
+
+// Get the voluntary exits that have been accepted into the pool
+func (db *Database) GetVoluntaryExits() []*VoluntaryExit {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	exits := make([]*VoluntaryExit, len(db.voluntaryExits))
+	copy(exits, db.voluntaryExits)
+	return exits
+}
+
+// Validate a signed voluntary exit and, if it's valid, start the validator's exit and add it to the pool
+func (db *Database) SubmitVoluntaryExit(exit *VoluntaryExit) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	// Check the validator
+	if exit.ValidatorIndex >= uint64(len(db.validators)) {
+		return fmt.Errorf("validator with index %d does not exist", exit.ValidatorIndex)
+	}
+	validator := db.validators[exit.ValidatorIndex]
+	if validator.Status != beacon.ValidatorState_ActiveOngoing {
+		return fmt.Errorf("validator %d is not active (status: %s)", exit.ValidatorIndex, validator.Status)
+	}
+	if db.getPendingBalanceToWithdraw(exit.ValidatorIndex) > 0 {
+		return fmt.Errorf("validator %d has pending partial withdrawals", exit.ValidatorIndex)
+	}
+	currentEpoch := db.currentSlot / db.config.SlotsPerEpoch
+	if exit.Epoch > currentEpoch {
+		return fmt.Errorf("exit epoch %d is in the future (current epoch: %d)", exit.Epoch, currentEpoch)
+	}
+	if currentEpoch < validator.ActivationEpoch+db.config.ShardCommitteePeriod {
+		return fmt.Errorf("validator %d can't exit until epoch %d", exit.ValidatorIndex, validator.ActivationEpoch+db.config.ShardCommitteePeriod)
+	}
+
+	// Exits are always signed with the Capella fork version as of Deneb (EIP-7044)
+	forkVersion := db.config.GetForkVersion(exit.Epoch)
+	if currentEpoch >= db.config.DenebForkEpoch {
+		forkVersion = db.config.CapellaForkVersion
+	}
+	domain, err := db.config.ComputeDomain(eth2types.DomainVoluntaryExit, forkVersion)
+	if err != nil {
+		return err
+	}
+
+	// Verify the signature
+	message := ssz_types.VoluntaryExit{
+		Epoch:          exit.Epoch,
+		ValidatorIndex: exit.ValidatorIndex,
+	}
+	root, err := message.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("error computing exit message root: %w", err)
+	}
+	err = verifySignature(validator.Pubkey, root, domain, exit.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid voluntary exit signature: %w", err)
+	}
+
+	// Start the exit
+	db.initiateValidatorExit(validator, currentEpoch)
+	validator.Status = beacon.ValidatorState_ActiveExiting
+	db.voluntaryExits = append(db.voluntaryExits, exit)
+	return nil
+}
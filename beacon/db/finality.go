@@ -0,0 +1,110 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// A finality checkpoint, pointing to the block at the start of an epoch
+type Checkpoint struct {
+	Epoch uint64
+	Root  common.Hash
+}
+
+// The set of finality checkpoints for the chain
+type FinalityCheckpoints struct {
+	PreviousJustified Checkpoint
+	CurrentJustified  Checkpoint
+	Finalized         Checkpoint
+}
+
+// Get the current finality checkpoints
+func (db *Database) GetFinalityCheckpoints() FinalityCheckpoints {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.finality
+}
+
+// Check if finality is currently stalled
+func (db *Database) IsFinalityStalled() bool {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.finalityStalled
+}
+
+// Stall or resume finality. While stalled, the checkpoints won't advance on epoch transitions.
+func (db *Database) SetFinalityStalled(stalled bool) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.finalityStalled = stalled
+}
+
+// Immediately justify and finalize the given epoch, regardless of whether or not finality is stalled.
+// The epoch must have ended, and can't be older than the currently finalized one.
+func (db *Database) ForceFinalization(epoch uint64) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	currentEpoch := db.currentSlot / db.config.SlotsPerEpoch
+	if epoch >= currentEpoch {
+		return fmt.Errorf("epoch %d hasn't ended yet (current epoch: %d)", epoch, currentEpoch)
+	}
+	if epoch < db.finality.Finalized.Epoch {
+		return fmt.Errorf("epoch %d is older than the finalized epoch (%d)", epoch, db.finality.Finalized.Epoch)
+	}
+
+	checkpoint := Checkpoint{
+		Epoch: epoch,
+		Root:  db.getCheckpointRoot(epoch),
+	}
+	if db.finality.CurrentJustified.Epoch < epoch {
+		db.finality.PreviousJustified = db.finality.CurrentJustified
+		db.finality.CurrentJustified = checkpoint
+	}
+	db.finality.Finalized = checkpoint
+	return nil
+}
+
+// Update the finality checkpoints at the start of a new epoch.
+// Under normal conditions the previous epoch is justified and the one before it is finalized.
+func (db *Database) processFinality(epoch uint64) {
+	if db.finalityStalled || epoch == 0 {
+		return
+	}
+
+	// Justify the previous epoch
+	justifiedEpoch := epoch - 1
+	if justifiedEpoch > db.finality.CurrentJustified.Epoch {
+		db.finality.PreviousJustified = db.finality.CurrentJustified
+		db.finality.CurrentJustified = Checkpoint{
+			Epoch: justifiedEpoch,
+			Root:  db.getCheckpointRoot(justifiedEpoch),
+		}
+	}
+
+	// Finalize the one before it
+	if epoch < 2 {
+		return
+	}
+	finalizedEpoch := epoch - 2
+	if finalizedEpoch > db.finality.Finalized.Epoch {
+		db.finality.Finalized = Checkpoint{
+			Epoch: finalizedEpoch,
+			Root:  db.getCheckpointRoot(finalizedEpoch),
+		}
+	}
+}
+
+// Get the root of the checkpoint block for an epoch, which is the latest block proposed at or before the epoch's first slot.
+// Returns an empty hash if no blocks have been proposed by then.
+func (db *Database) getCheckpointRoot(epoch uint64) common.Hash {
+	block := db.getLatestBlock(epoch * db.config.SlotsPerEpoch)
+	if block == nil {
+		return common.Hash{}
+	}
+	return block.Root
+}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinalityProgression(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+
+	// Run through 3 epochs
+	for i := uint64(0); i < 3*config.SlotsPerEpoch; i++ {
+		d.CommitBlock(true)
+	}
+	t.Log("Committed 3 epochs of blocks")
+
+	// Epoch 2 should be justified and epoch 1 should be finalized
+	checkpoints := d.GetFinalityCheckpoints()
+	require.Equal(t, uint64(1), checkpoints.PreviousJustified.Epoch)
+	require.Equal(t, uint64(2), checkpoints.CurrentJustified.Epoch)
+	require.Equal(t, uint64(1), checkpoints.Finalized.Epoch)
+	require.Equal(t, d.blockMap[config.SlotsPerEpoch].Root, checkpoints.Finalized.Root)
+	require.Equal(t, d.blockMap[2*config.SlotsPerEpoch].Root, checkpoints.CurrentJustified.Root)
+	require.NotEqual(t, common.Hash{}, checkpoints.Finalized.Root)
+	t.Log("Checkpoints are correct")
+}
+
+func TestFinalityWithMissedSlots(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+
+	// Propose the first slot of epoch 1, then miss the rest of the slots
+	for i := uint64(0); i < config.SlotsPerEpoch; i++ {
+		d.CommitBlock(true)
+	}
+	d.CommitBlock(true)
+	proposedRoot := d.blockMap[config.SlotsPerEpoch].Root
+	for i := uint64(0); i < 2*config.SlotsPerEpoch-1; i++ {
+		d.CommitBlock(false)
+	}
+	t.Log("Committed 3 epochs with missed slots")
+
+	// Epoch 2's checkpoint should use the last proposed block
+	checkpoints := d.GetFinalityCheckpoints()
+	require.Equal(t, uint64(2), checkpoints.CurrentJustified.Epoch)
+	require.Equal(t, proposedRoot, checkpoints.CurrentJustified.Root)
+	require.Equal(t, proposedRoot, checkpoints.Finalized.Root)
+	t.Log("Checkpoints use the latest proposed block")
+}
+
+func TestFinalityStall(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+
+	// Stall finality after 3 epochs
+	for i := uint64(0); i < 3*config.SlotsPerEpoch; i++ {
+		d.CommitBlock(true)
+	}
+	d.SetFinalityStalled(true)
+	for i := uint64(0); i < 3*config.SlotsPerEpoch; i++ {
+		d.CommitBlock(true)
+	}
+	t.Log("Committed 3 epochs with finality stalled")
+
+	// The checkpoints shouldn't have moved
+	checkpoints := d.GetFinalityCheckpoints()
+	require.Equal(t, uint64(2), checkpoints.CurrentJustified.Epoch)
+	require.Equal(t, uint64(1), checkpoints.Finalized.Epoch)
+	t.Log("Checkpoints didn't advance")
+
+	// Force finalization while still stalled
+	err := d.ForceFinalization(5)
+	require.NoError(t, err)
+	checkpoints = d.GetFinalityCheckpoints()
+	require.Equal(t, uint64(2), checkpoints.PreviousJustified.Epoch)
+	require.Equal(t, uint64(5), checkpoints.CurrentJustified.Epoch)
+	require.Equal(t, uint64(5), checkpoints.Finalized.Epoch)
+	require.Equal(t, d.blockMap[5*config.SlotsPerEpoch].Root, checkpoints.Finalized.Root)
+	t.Log("Forced finalization")
+
+	// Make sure it can't go backwards or finalize an epoch that hasn't ended
+	require.Error(t, d.ForceFinalization(4))
+	require.Equal(t, uint64(6), d.GetCurrentSlot()/config.SlotsPerEpoch)
+	require.Error(t, d.ForceFinalization(6))
+	require.Error(t, d.ForceFinalization(7))
+
+	// Resume finality
+	d.SetFinalityStalled(false)
+	for i := uint64(0); i < 2*config.SlotsPerEpoch; i++ {
+		d.CommitBlock(true)
+	}
+	checkpoints = d.GetFinalityCheckpoints()
+	require.Equal(t, uint64(7), checkpoints.CurrentJustified.Epoch)
+	require.Equal(t, uint64(6), checkpoints.Finalized.Epoch)
+	t.Log("Finality resumed")
+}
@@ -0,0 +1,233 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rocket-pool/node-manager-core/beacon"
+)
+
+// Start the exit process for a validator. Returns an error if the validator isn't active or has already started exiting.
+func (db *Database) InitiateValidatorExit(index uint64) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if index >= uint64(len(db.validators)) {
+		return fmt.Errorf("validator with index %d does not exist", index)
+	}
+	validator := db.validators[index]
+	if validator.Status != beacon.ValidatorState_ActiveOngoing {
+		return fmt.Errorf("validator %d is not active (status: %s)", index, validator.Status)
+	}
+
+	db.initiateValidatorExit(validator, db.currentSlot/db.config.SlotsPerEpoch)
+	validator.Status = beacon.ValidatorState_ActiveExiting
+	return nil
+}
+
+// Handle activation eligibility, the activation queue, and status transitions for each validator at the start of a new epoch.
+// Like the spec's process_registry_updates, this runs as the previous epoch ends, so that's the current epoch that
+// activation and exit epochs are computed from.
+func (db *Database) processRegistryUpdates(epoch uint64) {
+	currentEpoch := epoch - 1
+
+	// Mark new validators as eligible for activation
+	for _, validator := range db.validators {
+		if validator.Status == beacon.ValidatorState_PendingInitialized &&
+			validator.ActivationEligibilityEpoch == FarFutureEpoch &&
+			validator.EffectiveBalance >= MinActivationBalance {
+			validator.ActivationEligibilityEpoch = currentEpoch + 1
+			validator.Status = beacon.ValidatorState_PendingQueued
+		}
+
+		// Validators that were queued manually became eligible in the epoch that just ended
+		if validator.Status == beacon.ValidatorState_PendingQueued && validator.ActivationEligibilityEpoch == FarFutureEpoch {
+			validator.ActivationEligibilityEpoch = currentEpoch
+		}
+	}
+
+	// Get the validators in the activation queue, which must have been eligible as of the finalized epoch
+	queue := []*Validator{}
+	for _, validator := range db.validators {
+		if validator.Status == beacon.ValidatorState_PendingQueued &&
+			validator.ActivationEpoch == FarFutureEpoch &&
+			validator.ActivationEligibilityEpoch <= db.finality.Finalized.Epoch {
+			queue = append(queue, validator)
+		}
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		if queue[i].ActivationEligibilityEpoch != queue[j].ActivationEligibilityEpoch {
+			return queue[i].ActivationEligibilityEpoch < queue[j].ActivationEligibilityEpoch
+		}
+		return queue[i].Index < queue[j].Index
+	})
+
+	// Dequeue as many as the churn limit allows; after Electra, churn is applied to deposits instead so all of them are dequeued
+	churnLimit := uint64(len(queue))
+	if !db.config.IsElectraActive(epoch) {
+		churnLimit = min(db.getValidatorChurnLimit(), MaxPerEpochActivationChurnLimit)
+	}
+	for i, validator := range queue {
+		if uint64(i) >= churnLimit {
+			break
+		}
+		validator.ActivationEpoch = currentEpoch + 1 + db.config.ActivationDelay
+	}
+
+	// Move each validator through the lifecycle
+	for _, validator := range db.validators {
+		db.updateValidatorStatus(validator, epoch)
+	}
+}
+
+// Move a validator through the lifecycle based on its epoch fields, at the start of the given epoch.
+// Validators that were put into a status manually will have their missing epoch fields filled in. Manual exits are
+// initiated in the epoch that just ended, the same as if InitiateValidatorExit had been called during it.
+func (db *Database) updateValidatorStatus(validator *Validator, epoch uint64) {
+	currentEpoch := epoch - 1
+
+	// Activation
+	if validator.Status == beacon.ValidatorState_PendingQueued && validator.ActivationEpoch <= epoch {
+		validator.Status = beacon.ValidatorState_ActiveOngoing
+	}
+
+	// Exit initiation
+	if validator.Status == beacon.ValidatorState_ActiveOngoing && validator.ExitEpoch != FarFutureEpoch {
+		validator.Status = beacon.ValidatorState_ActiveExiting
+	}
+	if validator.Status == beacon.ValidatorState_ActiveExiting || validator.Status == beacon.ValidatorState_ActiveSlashed {
+		if validator.ExitEpoch == FarFutureEpoch {
+			db.initiateValidatorExit(validator, currentEpoch)
+		}
+		if validator.Slashed {
+			validator.Status = beacon.ValidatorState_ActiveSlashed
+		}
+	}
+
+	// Exit
+	if (validator.Status == beacon.ValidatorState_ActiveExiting || validator.Status == beacon.ValidatorState_ActiveSlashed) && validator.ExitEpoch <= epoch {
+		if validator.Slashed {
+			validator.Status = beacon.ValidatorState_ExitedSlashed
+		} else {
+			validator.Status = beacon.ValidatorState_ExitedUnslashed
+		}
+	}
+
+	// Withdrawability
+	if validator.Status == beacon.ValidatorState_ExitedUnslashed || validator.Status == beacon.ValidatorState_ExitedSlashed {
+		if validator.ExitEpoch == FarFutureEpoch {
+			validator.ExitEpoch = epoch
+		}
+		if validator.WithdrawableEpoch == FarFutureEpoch {
+			validator.WithdrawableEpoch = validator.ExitEpoch + MinValidatorWithdrawabilityDelay
+		}
+		if validator.WithdrawableEpoch <= epoch {
+			validator.Status = beacon.ValidatorState_WithdrawalPossible
+		}
+	}
+	if validator.Status == beacon.ValidatorState_WithdrawalPossible && validator.Balance == 0 {
+		validator.Status = beacon.ValidatorState_WithdrawalDone
+	}
+}
+
+// Assign an exit epoch and withdrawable epoch to a validator, respecting the exit queue's churn limit.
+// The current epoch is the epoch the exit is being processed in.
+func (db *Database) initiateValidatorExit(validator *Validator, currentEpoch uint64) {
+	if validator.ExitEpoch != FarFutureEpoch {
+		return
+	}
+	if db.config.IsElectraActive(currentEpoch) {
+		exitQueueEpoch := db.computeExitEpochAndUpdateChurn(validator.EffectiveBalance, currentEpoch)
+		validator.ExitEpoch = exitQueueEpoch
+		validator.WithdrawableEpoch = exitQueueEpoch + MinValidatorWithdrawabilityDelay
+		return
+	}
+
+	// Find the earliest epoch in the exit queue
+	exitQueueEpoch := computeActivationExitEpoch(currentEpoch)
+	for _, other := range db.validators {
+		if other.ExitEpoch != FarFutureEpoch && other.ExitEpoch > exitQueueEpoch {
+			exitQueueEpoch = other.ExitEpoch
+		}
+	}
+
+	// Push it back an epoch if that one is full
+	exitQueueChurn := uint64(0)
+	for _, other := range db.validators {
+		if other.ExitEpoch == exitQueueEpoch {
+			exitQueueChurn++
+		}
+	}
+	if exitQueueChurn >= db.getValidatorChurnLimit() {
+		exitQueueEpoch++
+	}
+
+	validator.ExitEpoch = exitQueueEpoch
+	validator.WithdrawableEpoch = exitQueueEpoch + MinValidatorWithdrawabilityDelay
+}
+
+// Get the number of validators that can enter or exit the active set in a single epoch
+func (db *Database) getValidatorChurnLimit() uint64 {
+	activeCount := uint64(0)
+	for _, validator := range db.validators {
+		if validator.IsActive() {
+			activeCount++
+		}
+	}
+	return max(MinPerEpochChurnLimit, activeCount/ChurnLimitQuotient)
+}
+
+// Get the epoch a validator exiting with the given balance will leave in after Electra, consuming the balance from the
+// exit churn
+func (db *Database) computeExitEpochAndUpdateChurn(exitBalance uint64, currentEpoch uint64) uint64 {
+	earliestExitEpoch := max(db.earliestExitEpoch, computeActivationExitEpoch(currentEpoch))
+	perEpochChurn := db.getActivationExitChurnLimit()
+
+	// A new epoch gets the full churn
+	exitBalanceToConsume := db.exitBalanceToConsume
+	if db.earliestExitEpoch < earliestExitEpoch {
+		exitBalanceToConsume = perEpochChurn
+	}
+
+	// Spill over into later epochs if the balance doesn't fit
+	if exitBalance > exitBalanceToConsume {
+		balanceToProcess := exitBalance - exitBalanceToConsume
+		additionalEpochs := (balanceToProcess-1)/perEpochChurn + 1
+		earliestExitEpoch += additionalEpochs
+		exitBalanceToConsume += additionalEpochs * perEpochChurn
+	}
+
+	db.exitBalanceToConsume = exitBalanceToConsume - exitBalance
+	db.earliestExitEpoch = earliestExitEpoch
+	return earliestExitEpoch
+}
+
+// Get the balance, in gwei, that can enter or exit the active set in a single epoch after Electra
+func (db *Database) getActivationExitChurnLimit() uint64 {
+	return min(MaxPerEpochActivationExitChurnLimit, db.getBalanceChurnLimit())
+}
+
+// Get the balance churn limit after Electra, which scales with the total active balance
+func (db *Database) getBalanceChurnLimit() uint64 {
+	totalActiveBalance := uint64(0)
+	for _, validator := range db.validators {
+		if validator.IsActive() {
+			totalActiveBalance += validator.EffectiveBalance
+		}
+	}
+	churn := max(MinPerEpochChurnLimitElectra, totalActiveBalance/ChurnLimitQuotient)
+	return churn - churn%EffectiveBalanceIncrement
+}
+
+// Get the highest effective balance a validator can have at the given epoch
+func (db *Database) getMaxEffectiveBalance(validator *Validator, epoch uint64) uint64 {
+	if !db.config.IsElectraActive(epoch) {
+		return MaxEffectiveBalance
+	}
+	return validator.GetMaxEffectiveBalance()
+}
+
+// Get the earliest epoch that activations and exits initiated in the given epoch can take effect in
+func computeActivationExitEpoch(epoch uint64) uint64 {
+	return epoch + 1 + MaxSeedLookahead
+}
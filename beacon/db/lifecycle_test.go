@@ -0,0 +1,190 @@
+package db
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nodeset-org/osha/beacon/internal/test"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/node/validator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatorLifecycle(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	v := addTestValidator(t, d, test.Pubkey0String)
+
+	// The validator should be queued at the start of epoch 1
+	commitEpochs(d, 1)
+	require.Equal(t, beacon.ValidatorState_PendingQueued, v.Status)
+	require.Equal(t, uint64(1), v.ActivationEligibilityEpoch)
+	require.Equal(t, FarFutureEpoch, v.ActivationEpoch)
+	t.Log("Validator is queued")
+
+	// It should be dequeued once its eligibility epoch is finalized
+	commitEpochs(d, 2)
+	require.Equal(t, beacon.ValidatorState_PendingQueued, v.Status)
+	require.Equal(t, 3+config.ActivationDelay, v.ActivationEpoch)
+	t.Logf("Validator will activate at epoch %d", v.ActivationEpoch)
+
+	// Run to the activation epoch
+	commitEpochs(d, config.ActivationDelay)
+	require.Equal(t, beacon.ValidatorState_ActiveOngoing, v.Status)
+	t.Log("Validator is active")
+
+	// Exit it
+	err := d.InitiateValidatorExit(v.Index)
+	require.NoError(t, err)
+	currentEpoch := d.GetCurrentEpoch()
+	require.Equal(t, beacon.ValidatorState_ActiveExiting, v.Status)
+	require.Equal(t, currentEpoch+1+MaxSeedLookahead, v.ExitEpoch)
+	require.Equal(t, v.ExitEpoch+MinValidatorWithdrawabilityDelay, v.WithdrawableEpoch)
+	t.Logf("Validator is exiting at epoch %d", v.ExitEpoch)
+
+	// Run to the exit epoch
+	commitEpochs(d, v.ExitEpoch-currentEpoch)
+	require.Equal(t, beacon.ValidatorState_ExitedUnslashed, v.Status)
+	t.Log("Validator has exited")
+
+	// Run to the withdrawable epoch
+	commitEpochs(d, v.WithdrawableEpoch-v.ExitEpoch)
+	require.Equal(t, beacon.ValidatorState_WithdrawalPossible, v.Status)
+	t.Log("Validator is withdrawable")
+
+	// Drain the balance
+	v.SetBalance(0)
+	commitEpochs(d, 1)
+	require.Equal(t, beacon.ValidatorState_WithdrawalDone, v.Status)
+	t.Log("Validator is withdrawn")
+}
+
+func TestActivationChurn(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	validators := make([]*Validator, 6)
+	for i := range validators {
+		pubkey := beacon.ValidatorPubkey{0xbe, 0xac, byte(i)}
+		validators[i] = addTestValidator(t, d, pubkey.HexWithPrefix())
+	}
+
+	// Run until the first batch is dequeued
+	commitEpochs(d, 3)
+	for i, v := range validators {
+		if uint64(i) < MinPerEpochChurnLimit {
+			require.Equal(t, 3+config.ActivationDelay, v.ActivationEpoch)
+		} else {
+			require.Equal(t, FarFutureEpoch, v.ActivationEpoch)
+		}
+	}
+	t.Log("First batch was dequeued")
+
+	// The rest should be dequeued in the next epoch
+	commitEpochs(d, 1)
+	for i, v := range validators {
+		if uint64(i) >= MinPerEpochChurnLimit {
+			require.Equal(t, 4+config.ActivationDelay, v.ActivationEpoch)
+		}
+	}
+	t.Log("Second batch was dequeued")
+}
+
+func TestElectraChurn(t *testing.T) {
+	config := NewDefaultConfig()
+	config.ElectraForkEpoch = 0
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	validators := make([]*Validator, 6)
+	for i := range validators {
+		pubkey := beacon.ValidatorPubkey{0xbe, 0xac, byte(i)}
+		validators[i] = addTestValidator(t, d, pubkey.HexWithPrefix())
+	}
+
+	// Activation isn't limited by churn after Electra, so they should all be dequeued at once
+	commitEpochs(d, 3)
+	for _, v := range validators {
+		require.Equal(t, 3+config.ActivationDelay, v.ActivationEpoch)
+	}
+	t.Log("All validators were dequeued")
+
+	// Exits are limited by the balance churn, which fits 4 validators with 32 ETH each
+	commitEpochs(d, config.ActivationDelay)
+	currentEpoch := d.GetCurrentEpoch()
+	for _, v := range validators {
+		require.Equal(t, beacon.ValidatorState_ActiveOngoing, v.Status)
+		require.NoError(t, d.InitiateValidatorExit(v.Index))
+	}
+	for i, v := range validators {
+		expectedExitEpoch := currentEpoch + 1 + MaxSeedLookahead
+		if uint64(i) >= MinPerEpochChurnLimitElectra/MinActivationBalance {
+			expectedExitEpoch++
+		}
+		require.Equal(t, expectedExitEpoch, v.ExitEpoch)
+		require.Equal(t, expectedExitEpoch+MinValidatorWithdrawabilityDelay, v.WithdrawableEpoch)
+	}
+	t.Log("Exits spilled over into the next epoch")
+}
+
+func TestManualStatusLifecycle(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	v := addTestValidator(t, d, test.Pubkey0String)
+	other := addTestValidator(t, d, test.Pubkey1String)
+	other.Status = beacon.ValidatorState_ActiveOngoing
+
+	// Manually mark the validator as exiting, and exit another one normally in the same epoch
+	v.SetStatus(beacon.ValidatorState_ActiveExiting)
+	require.NoError(t, d.InitiateValidatorExit(other.Index))
+	commitEpochs(d, 1)
+	require.Equal(t, beacon.ValidatorState_ActiveExiting, v.Status)
+	require.Equal(t, 1+MaxSeedLookahead, v.ExitEpoch)
+	require.Equal(t, other.ExitEpoch, v.ExitEpoch)
+	require.Equal(t, v.ExitEpoch+MinValidatorWithdrawabilityDelay, v.WithdrawableEpoch)
+	t.Log("Exit epochs were assigned the same way as a normal exit")
+
+	// Run to the exit
+	commitEpochs(d, MaxSeedLookahead)
+	require.Equal(t, beacon.ValidatorState_ExitedUnslashed, v.Status)
+	t.Log("Validator has exited")
+}
+
+func TestManualQueuedStatus(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	v := addTestValidator(t, d, test.Pubkey0String)
+
+	// Manually queue the validator in epoch 0
+	v.SetStatus(beacon.ValidatorState_PendingQueued)
+	commitEpochs(d, 1)
+	require.Equal(t, beacon.ValidatorState_PendingQueued, v.Status)
+	require.Equal(t, uint64(0), v.ActivationEligibilityEpoch)
+	t.Log("Validator was made eligible in the epoch it was queued")
+
+	// It should be dequeued and activated like any other validator
+	commitEpochs(d, 1)
+	require.NotEqual(t, FarFutureEpoch, v.ActivationEpoch)
+	commitEpochs(d, v.ActivationEpoch-d.GetCurrentEpoch())
+	require.Equal(t, beacon.ValidatorState_ActiveOngoing, v.Status)
+	t.Log("Validator is active")
+}
+
+// Add a validator with 0x01 credentials to the database
+func addTestValidator(t *testing.T, d *Database, pubkeyString string) *Validator {
+	pubkey, err := beacon.HexToValidatorPubkey(pubkeyString)
+	if err != nil {
+		t.Fatalf("Error parsing pubkey: %v", err)
+	}
+	withdrawalCreds := validator.GetWithdrawalCredsFromAddress(common.HexToAddress(test.WithdrawalCredentialsString))
+	v, err := d.AddValidator(pubkey, withdrawalCreds)
+	if err != nil {
+		t.Fatalf("Error adding validator: %v", err)
+	}
+	return v
+}
+
+// Commit blocks for a number of full epochs
+func commitEpochs(d *Database, epochs uint64) {
+	for i := uint64(0); i < epochs*d.config.SlotsPerEpoch; i++ {
+		d.CommitBlock(true)
+	}
+}
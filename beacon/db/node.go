@@ -0,0 +1,151 @@
+package db
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/btcsuite/btcd/btcutil/base58"
+)
+
+const (
+	// The client version string the node reports by default
+	DefaultNodeVersion string = "OSHA/v1.0.0"
+
+	// The number of connected peers the node reports by default
+	DefaultPeerCount uint64 = 50
+
+	// The largest number of connected peers the node can report, since the peers route lists each one
+	MaxPeerCount uint64 = 10000
+)
+
+// The networking identity of the node
+type NodeIdentity struct {
+	// The node's libp2p peer ID
+	PeerID string
+
+	// The node's Ethereum Node Record
+	Enr string
+
+	// The multiaddrs the node listens on for libp2p connections
+	P2PAddresses []string
+
+	// The multiaddrs the node listens on for discovery
+	DiscoveryAddresses []string
+}
+
+// Create a copy of the identity
+func (i NodeIdentity) Clone() NodeIdentity {
+	i.P2PAddresses = slices.Clone(i.P2PAddresses)
+	i.DiscoveryAddresses = slices.Clone(i.DiscoveryAddresses)
+	return i
+}
+
+// Information the node reports about itself on the node routes
+type NodeInfo struct {
+	// The client version string
+	Version string
+
+	// The status code the health route responds with, or 0 to derive it from the sync status
+	HealthStatus int
+
+	// The number of connected peers
+	PeerCount uint64
+
+	// The node's networking identity
+	Identity NodeIdentity
+}
+
+// Get the default node info
+func newNodeInfo() NodeInfo {
+	return NodeInfo{
+		Version:   DefaultNodeVersion,
+		PeerCount: DefaultPeerCount,
+		Identity: NodeIdentity{
+			PeerID:             GetMockPeerID(0),
+			Enr:                getMockEnr(0),
+			P2PAddresses:       []string{"/ip4/127.0.0.1/tcp/9000"},
+			DiscoveryAddresses: []string{"/ip4/127.0.0.1/udp/9000"},
+		},
+	}
+}
+
+// Get the information the node reports about itself
+func (db *Database) GetNodeInfo() NodeInfo {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	info := db.nodeInfo
+	info.Identity = info.Identity.Clone()
+	return info
+}
+
+// Set the client version string the node reports
+func (db *Database) SetNodeVersion(version string) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.nodeInfo.Version = version
+}
+
+// Set the status code the health route responds with. Use 200 (ready), 206 (syncing), or 503 (not ready), or 0 to
+// derive it from the sync status again.
+func (db *Database) SetNodeHealth(status int) error {
+	switch status {
+	case 0, http.StatusOK, http.StatusPartialContent, http.StatusServiceUnavailable:
+	default:
+		return fmt.Errorf("invalid health status [%d]", status)
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.nodeInfo.HealthStatus = status
+	return nil
+}
+
+// Set the number of connected peers the node reports, up to MaxPeerCount
+func (db *Database) SetPeerCount(count uint64) error {
+	if count > MaxPeerCount {
+		return fmt.Errorf("peer count [%d] is higher than the maximum of %d", count, MaxPeerCount)
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.nodeInfo.PeerCount = count
+	return nil
+}
+
+// Set the networking identity the node reports
+func (db *Database) SetNodeIdentity(identity NodeIdentity) error {
+	if identity.PeerID == "" {
+		return fmt.Errorf("peer ID is required")
+	}
+	if identity.Enr == "" {
+		return fmt.Errorf("ENR is required")
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.nodeInfo.Identity = identity.Clone()
+	return nil
+}
+
+// Get a deterministic, fake libp2p peer ID for the given index. It's formatted as the identity multihash of a
+// secp256k1 public key, like the IDs real Beacon nodes use.
+func GetMockPeerID(index uint64) string {
+	key := sha256.Sum256([]byte(fmt.Sprintf("peer-%d", index)))
+	multihash := []byte{0x00, 0x25, 0x08, 0x02, 0x12, 0x21, 0x02}
+	multihash = append(multihash, key[:]...)
+	return base58.Encode(multihash)
+}
+
+// Get a deterministic, fake ENR for the given index
+func getMockEnr(index uint64) string {
+	record := sha256.Sum256([]byte(fmt.Sprintf("enr-%d", index)))
+	return "enr:-" + base64.RawURLEncoding.EncodeToString(record[:])
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeInfo(t *testing.T) {
+	d := NewDatabase(slog.Default(), 0)
+
+	// Check the defaults
+	info := d.GetNodeInfo()
+	require.Equal(t, DefaultNodeVersion, info.Version)
+	require.Equal(t, 0, info.HealthStatus)
+	require.Equal(t, DefaultPeerCount, info.PeerCount)
+	require.True(t, strings.HasPrefix(info.Identity.PeerID, "16Uiu2HA"))
+	require.NotEqual(t, info.Identity.PeerID, GetMockPeerID(1))
+	t.Logf("Default node info is correct, peer ID is %s", info.Identity.PeerID)
+
+	// Change everything
+	d.SetNodeVersion("Lighthouse/v5.3.0")
+	require.NoError(t, d.SetNodeHealth(http.StatusServiceUnavailable))
+	require.NoError(t, d.SetPeerCount(0))
+	require.Error(t, d.SetPeerCount(MaxPeerCount+1))
+	identity := NodeIdentity{
+		PeerID:       GetMockPeerID(10),
+		Enr:          "enr:-test",
+		P2PAddresses: []string{"/ip4/1.2.3.4/tcp/9000"},
+	}
+	require.NoError(t, d.SetNodeIdentity(identity))
+	identity.P2PAddresses[0] = "/ip4/5.6.7.8/tcp/9000"
+	t.Log("Updated the node info")
+
+	// Clones keep the changes without sharing the identity
+	clone := d.Clone()
+	info = clone.GetNodeInfo()
+	require.Equal(t, "Lighthouse/v5.3.0", info.Version)
+	require.Equal(t, http.StatusServiceUnavailable, info.HealthStatus)
+	require.Equal(t, uint64(0), info.PeerCount)
+	require.Equal(t, "enr:-test", info.Identity.Enr)
+	require.Equal(t, []string{"/ip4/1.2.3.4/tcp/9000"}, info.Identity.P2PAddresses)
+	t.Log("Clone kept the node info")
+
+	// Invalid values are rejected
+	require.Error(t, d.SetNodeHealth(http.StatusTeapot))
+	require.Error(t, d.SetNodeIdentity(NodeIdentity{Enr: "enr:-test"}))
+	require.Error(t, d.SetNodeIdentity(NodeIdentity{PeerID: GetMockPeerID(10)}))
+	t.Log("Invalid node info was rejected")
+}
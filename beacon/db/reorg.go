@@ -0,0 +1,96 @@
+package db
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Replace the blocks in the last depth slots with a new branch of the chain. Blocks are proposed in the first newBlocks
+// slots of the branch and the rest are missed, so the current slot doesn't change. The new blocks are linked to
+// Execution blocks starting from the first one linked to a dropped block.
+// Withdrawals paid out by the dropped blocks are returned to their validators, pending partial withdrawals they swept are
+// put back in the queue, and the new blocks process withdrawals as usual, starting from the withdrawal index and sweep
+// position of the first dropped block. Other state changes made during the dropped slots are kept.
+// The reorg can't cross an epoch transition, since the state changes made by the transition (such as validator status
+// changes) depend on the blocks before it and can't be unwound.
+// Returns the blocks that were dropped, in slot order.
+func (db *Database) Reorg(depth uint64, newBlocks uint64) ([]*Block, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	// Input validation
+	if depth == 0 {
+		return nil, fmt.Errorf("reorg depth must be at least 1")
+	}
+	if depth > db.currentSlot {
+		return nil, fmt.Errorf("reorg depth %d is past genesis (current slot: %d)", depth, db.currentSlot)
+	}
+	if newBlocks > depth {
+		return nil, fmt.Errorf("can't propose %d new blocks in %d slots", newBlocks, depth)
+	}
+	forkSlot := db.currentSlot - depth
+	finalizedSlot := db.finality.Finalized.Epoch * db.config.SlotsPerEpoch
+	if db.finality.Finalized.Root != (common.Hash{}) && forkSlot <= finalizedSlot {
+		return nil, fmt.Errorf("can't reorg slot %d since slot %d is finalized", forkSlot, finalizedSlot)
+	}
+	epochStartSlot := db.currentSlot / db.config.SlotsPerEpoch * db.config.SlotsPerEpoch
+	if forkSlot < epochStartSlot {
+		return nil, fmt.Errorf("can't reorg slot %d since it's before the current epoch's first slot (%d)", forkSlot, epochStartSlot)
+	}
+
+	// Drop the old blocks
+	dropped := []*Block{}
+	sweptPartialWithdrawals := []*PendingPartialWithdrawal{}
+	for slot := forkSlot; slot < db.currentSlot; slot++ {
+		block, exists := db.blockMap[slot]
+		if !exists {
+			continue
+		}
+		if len(dropped) == 0 {
+			cursor := db.withdrawalCursorMap[slot]
+			db.nextExecutionBlockIndex = db.executionBlockMap[slot]
+			db.nextWithdrawalIndex = cursor.withdrawalIndex
+			db.nextWithdrawalValidatorIndex = cursor.validatorIndex
+		}
+		for _, withdrawal := range block.Withdrawals {
+			db.validators[withdrawal.ValidatorIndex].Balance += withdrawal.Amount
+		}
+		for _, pending := range db.withdrawalCursorMap[slot].processedPartialWithdrawals {
+			sweptPartialWithdrawals = append(sweptPartialWithdrawals, &pending)
+		}
+		delete(db.blockMap, slot)
+		delete(db.executionBlockMap, slot)
+		delete(db.withdrawalCursorMap, slot)
+		dropped = append(dropped, block)
+	}
+	db.pendingPartialWithdrawals = append(sweptPartialWithdrawals, db.pendingPartialWithdrawals...)
+	db.headBlockRoot = common.Hash{}
+	parent := db.getLatestBlock(forkSlot)
+	if parent != nil {
+		db.headBlockRoot = parent.Root
+	}
+
+	// Forget the history of the dropped slots
+	for i, validatorHistory := range db.validatorHistories {
+		db.validatorHistories[i] = validatorHistory.before(forkSlot)
+	}
+	db.depositHistory = db.depositHistory.before(forkSlot)
+	db.partialWithdrawalHistory = db.partialWithdrawalHistory.before(forkSlot)
+	db.consolidationHistory = db.consolidationHistory.before(forkSlot)
+	db.finalityHistory = db.finalityHistory.before(forkSlot)
+	maps.DeleteFunc(db.stateRootMap, func(root common.Hash, slot uint64) bool {
+		return slot >= forkSlot
+	})
+
+	// Build the new branch
+	db.reorgCount++
+	for slot := forkSlot; slot < db.currentSlot; slot++ {
+		if slot-forkSlot < newBlocks {
+			db.addBlock(slot)
+		}
+		db.recordState(slot)
+	}
+	return dropped, nil
+}
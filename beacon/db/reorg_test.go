@@ -0,0 +1,180 @@
+package db
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/nodeset-org/osha/beacon/internal/test"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorg(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	v := addTestValidator(t, d, test.Pubkey0String)
+	v.Status = beacon.ValidatorState_ActiveOngoing
+	for i := 0; i < 5; i++ {
+		d.CommitBlock(true)
+	}
+	oldBlocks := []*Block{d.GetBlockBySlot(2), d.GetBlockBySlot(3), d.GetBlockBySlot(4)}
+
+	// Replace the last 3 slots with 2 blocks and a missed slot
+	dropped, err := d.Reorg(3, 2)
+	require.NoError(t, err)
+	require.Equal(t, oldBlocks, dropped)
+	require.Equal(t, uint64(5), d.GetCurrentSlot())
+	t.Log("Reorged the last 3 slots")
+
+	// Check the new branch
+	parent := d.GetBlockBySlot(1)
+	for i, slot := range []uint64{2, 3} {
+		block := d.GetBlockBySlot(slot)
+		require.NotEqual(t, oldBlocks[i].Root, block.Root)
+		require.Equal(t, parent.Root, block.ParentRoot)
+		require.Equal(t, oldBlocks[i].ExecutionBlockNumber, block.ExecutionBlockNumber)
+		require.Equal(t, block.StateRoot, d.GetState(slot).StateRoot)
+		require.Nil(t, d.GetBlockByRoot(oldBlocks[i].Root))
+		require.Nil(t, d.GetStateByRoot(oldBlocks[i].StateRoot))
+		parent = block
+	}
+	require.Nil(t, d.GetBlockBySlot(4))
+	require.Equal(t, parent, d.GetHeadBlock())
+	t.Log("New branch replaced the old blocks")
+
+	// The next block continues from the new branch
+	d.CommitBlock(true)
+	block := d.GetBlockBySlot(5)
+	require.Equal(t, parent.Root, block.ParentRoot)
+	require.Equal(t, config.FirstExecutionBlockIndex+4, block.ExecutionBlockNumber)
+	t.Log("Next block built on the new head")
+
+	// Blocks should follow the Execution head if the dropped Execution blocks were kept
+	d.SetLatestExecutionBlockNumber(oldBlocks[2].ExecutionBlockNumber + 1)
+	d.CommitBlock(true)
+	require.Equal(t, oldBlocks[2].ExecutionBlockNumber+2, d.GetHeadBlock().ExecutionBlockNumber)
+	t.Log("Next block followed the Execution head")
+}
+
+func TestReorgWithdrawals(t *testing.T) {
+	d := NewDatabase(slog.Default(), 0)
+	v := addTestValidator(t, d, test.Pubkey0String)
+	v.Status = beacon.ValidatorState_ActiveOngoing
+	v.Balance = StartingBalance + 1e9
+
+	// Sweep the excess balance
+	d.CommitBlock(true)
+	require.Len(t, d.GetBlockBySlot(0).Withdrawals, 1)
+	require.Equal(t, StartingBalance, v.Balance)
+
+	// Reorg the block out
+	_, err := d.Reorg(1, 0)
+	require.NoError(t, err)
+	require.Equal(t, StartingBalance+1e9, v.Balance)
+	require.Nil(t, d.GetHeadBlock())
+	t.Log("Dropped withdrawal was returned to the validator")
+
+	// Replace it with a new block that sweeps it again
+	_, err = d.Reorg(1, 1)
+	require.NoError(t, err)
+	require.Len(t, d.GetBlockBySlot(0).Withdrawals, 1)
+	require.Equal(t, uint64(0), d.GetBlockBySlot(0).Withdrawals[0].Index)
+	require.Equal(t, StartingBalance, v.Balance)
+	t.Log("New block swept the withdrawal with the same index")
+}
+
+func TestReorgPartialWithdrawals(t *testing.T) {
+	config := NewDefaultConfig()
+	config.ElectraForkEpoch = 0
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	v := addTestValidator(t, d, test.Pubkey0String)
+	v.Status = beacon.ValidatorState_ActiveOngoing
+	v.WithdrawalCredentials[0] = CompoundingWithdrawalPrefix
+	v.Balance = 40e9
+	pending, err := d.AddPendingPartialWithdrawal(v.Index, 5e9)
+	require.NoError(t, err)
+	pending.WithdrawableEpoch = 0
+
+	// Sweep the pending withdrawal
+	d.CommitBlock(true)
+	require.Len(t, d.GetBlockBySlot(0).Withdrawals, 1)
+	require.Empty(t, d.GetPendingPartialWithdrawals())
+
+	// Reorg the block out
+	_, err = d.Reorg(1, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(40e9), v.Balance)
+	require.Equal(t, []*PendingPartialWithdrawal{pending}, d.GetPendingPartialWithdrawals())
+	require.Len(t, d.GetState(0).PendingPartialWithdrawals, 1)
+	t.Log("Dropped partial withdrawal was put back in the queue")
+
+	// Replace it with a new block that sweeps it again
+	_, err = d.Reorg(1, 1)
+	require.NoError(t, err)
+	require.Len(t, d.GetBlockBySlot(0).Withdrawals, 1)
+	require.Equal(t, uint64(35e9), v.Balance)
+	require.Empty(t, d.GetPendingPartialWithdrawals())
+	t.Log("New block swept the partial withdrawal again")
+}
+
+func TestInvalidReorg(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	commitEpochs(d, 3)
+
+	_, err := d.Reorg(0, 0)
+	require.Error(t, err)
+	_, err = d.Reorg(d.GetCurrentSlot()+1, 0)
+	require.Error(t, err)
+	_, err = d.Reorg(2, 3)
+	require.Error(t, err)
+	t.Log("Invalid depths were rejected")
+
+	// Epoch 1 is finalized, so its first slot can't be reorged
+	require.Equal(t, uint64(1), d.GetFinalityCheckpoints().Finalized.Epoch)
+	_, err = d.Reorg(2*config.SlotsPerEpoch, 0)
+	require.Error(t, err)
+	t.Log("Finalized slots can't be reorged")
+
+	// Reorgs can't cross the transition into the current epoch
+	_, err = d.Reorg(1, 0)
+	require.Error(t, err)
+	d.CommitBlock(true)
+	_, err = d.Reorg(2, 0)
+	require.Error(t, err)
+	_, err = d.Reorg(1, 0)
+	require.NoError(t, err)
+	t.Log("Reorgs across an epoch transition were rejected")
+}
+
+func TestReorgFullWithdrawal(t *testing.T) {
+	d := NewDatabase(slog.Default(), 0)
+	v := addTestValidator(t, d, test.Pubkey0String)
+	v.Status = beacon.ValidatorState_WithdrawalPossible
+	v.ExitEpoch = 0
+	v.WithdrawableEpoch = 0
+
+	// Withdraw the whole balance and reorg the block out
+	d.CommitBlock(true)
+	require.Equal(t, uint64(0), v.Balance)
+	_, err := d.Reorg(1, 0)
+	require.NoError(t, err)
+	require.Equal(t, StartingBalance, v.Balance)
+	require.Equal(t, beacon.ValidatorState_WithdrawalPossible, v.Status)
+	require.Equal(t, StartingBalance, d.GetState(0).GetValidatorByIndex(0).Balance)
+	t.Log("Dropped full withdrawal was returned to the validator")
+
+	// Withdraw it again and let the epoch transition mark it as done
+	d.CommitBlock(true)
+	missSlots(d, d.config.SlotsPerEpoch-2)
+	require.Equal(t, uint64(0), v.Balance)
+	require.Equal(t, beacon.ValidatorState_WithdrawalDone, v.Status)
+	t.Log("Validator was fully withdrawn on the new branch")
+
+	// The withdrawal can't be reorged out anymore since the transition already marked it as done
+	_, err = d.Reorg(d.config.SlotsPerEpoch-1, 0)
+	require.Error(t, err)
+	require.Equal(t, uint64(0), v.Balance)
+	require.Equal(t, beacon.ValidatorState_WithdrawalDone, v.Status)
+	t.Log("Reorg across the transition was rejected")
+}
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// The number of seconds in a year, used to convert APRs into per-epoch rates
+	secondsPerYear float64 = 365.25 * 24 * 60 * 60
+)
+
+// Check if rewards and penalties are applied at each epoch transition
+func (db *Database) GetRewardsEnabled() bool {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.rewardsEnabled
+}
+
+// Enable or disable rewards and penalties at each epoch transition
+func (db *Database) SetRewardsEnabled(enabled bool) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.rewardsEnabled = enabled
+}
+
+// Get the annual percentage rate active validators earn with full participation
+func (db *Database) GetRewardsApr() float64 {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.rewardsApr
+}
+
+// Set the annual percentage rate active validators earn with full participation (e.g. 0.03 for 3%)
+func (db *Database) SetRewardsApr(apr float64) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if apr < 0 || math.IsNaN(apr) || math.IsInf(apr, 0) {
+		return fmt.Errorf("invalid APR %f", apr)
+	}
+	db.rewardsApr = apr
+	return nil
+}
+
+// Get a validator's attestation participation rate, from 0 (offline) to 1 (perfect)
+func (db *Database) GetParticipation(index uint64) float64 {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.getParticipation(index)
+}
+
+// Set a validator's attestation participation rate, from 0 (offline) to 1 (perfect)
+func (db *Database) SetParticipation(index uint64, participation float64) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if index >= uint64(len(db.validators)) {
+		return fmt.Errorf("validator with index %d does not exist", index)
+	}
+	if participation < 0 || participation > 1 || math.IsNaN(participation) {
+		return fmt.Errorf("invalid participation rate %f, must be between 0 and 1", participation)
+	}
+	if db.participation == nil {
+		db.participation = map[uint64]float64{}
+	}
+	db.participation[index] = participation
+	return nil
+}
+
+// Apply attestation rewards for the participating portion of each active validator's duties,
+// and penalties for the portion it missed. The lock must be held by the caller.
+func (db *Database) processRewardsAndPenalties() {
+	if !db.rewardsEnabled {
+		return
+	}
+
+	epochsPerYear := secondsPerYear / float64(db.config.SecondsPerSlot*db.config.SlotsPerEpoch)
+	for _, validator := range db.validators {
+		if !validator.IsActive() {
+			continue
+		}
+
+		// The base reward is what a perfectly participating validator earns each epoch
+		baseReward := float64(validator.EffectiveBalance) * db.rewardsApr / epochsPerYear
+		participation := db.getParticipation(validator.Index)
+		if validator.Slashed {
+			participation = 0
+		}
+		reward := uint64(baseReward * participation)
+		penalty := uint64(baseReward * (1 - participation))
+
+		validator.Balance += reward
+		if penalty > validator.Balance {
+			penalty = validator.Balance
+		}
+		validator.Balance -= penalty
+	}
+}
+
+// Get a validator's participation rate, defaulting to perfect participation. The lock must be held by the caller.
+func (db *Database) getParticipation(index uint64) float64 {
+	participation, exists := db.participation[index]
+	if !exists {
+		return 1
+	}
+	return participation
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/nodeset-org/osha/beacon/internal/test"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewardsAndPenalties(t *testing.T) {
+	config := NewDefaultConfig()
+	config.RewardsEnabled = true
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	online := addTestValidator(t, d, test.Pubkey0String)
+	online.Status = beacon.ValidatorState_ActiveOngoing
+	offline := addTestValidator(t, d, test.Pubkey1String)
+	offline.Status = beacon.ValidatorState_ActiveOngoing
+	partial := addTestValidator(t, d, test.Pubkey2String)
+	partial.Status = beacon.ValidatorState_ActiveOngoing
+	pending := addTestValidator(t, d, test.Pubkey3String)
+	require.NoError(t, d.SetParticipation(offline.Index, 0))
+	require.NoError(t, d.SetParticipation(partial.Index, 0.75))
+	require.Error(t, d.SetParticipation(partial.Index, 1.5))
+
+	// Run through an epoch of missed slots so no withdrawals are swept
+	missSlots(d, config.SlotsPerEpoch)
+	epochsPerYear := secondsPerYear / float64(config.SecondsPerSlot*config.SlotsPerEpoch)
+	baseReward := uint64(float64(StartingBalance) * config.RewardsApr / epochsPerYear)
+	require.Equal(t, StartingBalance+baseReward, online.Balance)
+	require.Equal(t, StartingBalance-baseReward, offline.Balance)
+	require.InDelta(t, StartingBalance+baseReward/2, partial.Balance, 1)
+	require.Equal(t, StartingBalance, pending.Balance)
+	t.Logf("Rewards and penalties applied - base reward: %d gwei", baseReward)
+
+	// Disable rewards
+	d.SetRewardsEnabled(false)
+	missSlots(d, config.SlotsPerEpoch)
+	require.Equal(t, StartingBalance+baseReward, online.Balance)
+	t.Log("Balances didn't change with rewards disabled")
+}
+
+// Advance the chain by a number of missed slots
+func missSlots(d *Database, slots uint64) {
+	for i := uint64(0); i < slots; i++ {
+		d.CommitBlock(false)
+	}
+}
@@ -3,4 +3,49 @@ package db
 const (
 	FarFutureEpoch  uint64 = 0xffffffffffffffff
 	StartingBalance uint64 = 32e9
+
+	// Spec values for validator balances and the lifecycle
+	MinActivationBalance             uint64 = 32e9
+	EffectiveBalanceIncrement        uint64 = 1e9
+	MaxSeedLookahead                 uint64 = 4
+	MinValidatorWithdrawabilityDelay uint64 = 256
+	ShardCommitteePeriod             uint64 = 256
+	MinPerEpochChurnLimit            uint64 = 4
+	MaxPerEpochActivationChurnLimit  uint64 = 8
+	ChurnLimitQuotient               uint64 = 65536
+
+	// Spec values for effective balance hysteresis
+	HysteresisQuotient           uint64 = 4
+	HysteresisDownwardMultiplier uint64 = 1
+	HysteresisUpwardMultiplier   uint64 = 5
+
+	// Spec values for validator balances and churn after Electra
+	MaxEffectiveBalance                 uint64 = 32e9
+	MaxEffectiveBalanceElectra          uint64 = 2048e9
+	MinPerEpochChurnLimitElectra        uint64 = 128e9
+	MaxPerEpochActivationExitChurnLimit uint64 = 256e9
+
+	// Spec values for slashing
+	EpochsPerSlashingsVector                uint64 = 8192
+	MinSlashingPenaltyQuotient              uint64 = 128
+	MinSlashingPenaltyQuotientAltair        uint64 = 64
+	MinSlashingPenaltyQuotientBellatrix     uint64 = 32
+	MinSlashingPenaltyQuotientElectra       uint64 = 4096
+	ProportionalSlashingMultiplier          uint64 = 1
+	ProportionalSlashingMultiplierAltair    uint64 = 2
+	ProportionalSlashingMultiplierBellatrix uint64 = 3
+	WhistleblowerRewardQuotient             uint64 = 512
+	WhistleblowerRewardQuotientElectra      uint64 = 4096
+
+	// Spec values for withdrawals
+	MaxWithdrawalsPerPayload         uint64 = 16
+	MaxValidatorsPerWithdrawalsSweep uint64 = 16384
+
+	// Spec values for withdrawals after Electra
+	MaxPendingPartialsPerWithdrawalsSweep uint64 = 8
+
+	// Withdrawal credential prefixes
+	BlsWithdrawalPrefix         byte = 0x00
+	Eth1AddressWithdrawalPrefix byte = 0x01
+	CompoundingWithdrawalPrefix byte = 0x02
 )
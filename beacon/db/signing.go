@@ -0,0 +1,51 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/beacon/ssz_types"
+	"github.com/rocket-pool/node-manager-core/node/validator"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// Compute the signature domain for the given domain type and fork version, using the chain's genesis validators root
+func (c *Config) ComputeDomain(domainType eth2types.DomainType, forkVersion []byte) ([]byte, error) {
+	domain, err := eth2types.ComputeDomain(domainType, forkVersion, c.GenesisValidatorsRoot)
+	if err != nil {
+		return nil, fmt.Errorf("error computing signature domain: %w", err)
+	}
+	return domain, nil
+}
+
+// Check that a signature over an object root was made by the given pubkey in the given domain
+func verifySignature(pubkey beacon.ValidatorPubkey, objectRoot [32]byte, domain []byte, signature beacon.ValidatorSignature) error {
+	err := validator.InitializeBls()
+	if err != nil {
+		return fmt.Errorf("error initializing BLS: %w", err)
+	}
+
+	// Get the signing root
+	signingRoot := ssz_types.SigningRoot{
+		ObjectRoot: objectRoot[:],
+		Domain:     domain,
+	}
+	signingRootHash, err := signingRoot.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("error computing signing root: %w", err)
+	}
+
+	// Verify the signature
+	blsPubkey, err := eth2types.BLSPublicKeyFromBytes(pubkey[:])
+	if err != nil {
+		return fmt.Errorf("invalid pubkey %s: %w", pubkey.HexWithPrefix(), err)
+	}
+	blsSignature, err := eth2types.BLSSignatureFromBytes(signature[:])
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	if !blsSignature.Verify(signingRootHash[:], blsPubkey) {
+		return fmt.Errorf("signature is not valid for pubkey %s", pubkey.HexWithPrefix())
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package db
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/beacon"
+)
+
+// A signed Beacon block header, used as evidence in a proposer slashing
+type SignedBlockHeader struct {
+	Slot          uint64
+	ProposerIndex uint64
+	ParentRoot    common.Hash
+	StateRoot     common.Hash
+	BodyRoot      common.Hash
+	Signature     beacon.ValidatorSignature
+}
+
+// Evidence that a validator proposed two different blocks for the same slot
+type ProposerSlashing struct {
+	Header1 SignedBlockHeader
+	Header2 SignedBlockHeader
+}
+
+// The data an attestation votes for
+type AttestationData struct {
+	Slot            uint64
+	CommitteeIndex  uint64
+	BeaconBlockRoot common.Hash
+	Source          Checkpoint
+	Target          Checkpoint
+}
+
+// An attestation with the indices of the validators that signed it, used as evidence in an attester slashing
+type IndexedAttestation struct {
+	AttestingIndices []uint64
+	Data             AttestationData
+	Signature        beacon.ValidatorSignature
+}
+
+// Evidence that a set of validators made a double vote or a surround vote
+type AttesterSlashing struct {
+	Attestation1 IndexedAttestation
+	Attestation2 IndexedAttestation
+}
+
+// Get the proposer slashings that have been accepted into the pool
+func (db *Database) GetProposerSlashings() []*ProposerSlashing {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	slashings := make([]*ProposerSlashing, len(db.proposerSlashings))
+	copy(slashings, db.proposerSlashings)
+	return slashings
+}
+
+// Get the attester slashings that have been accepted into the pool
+func (db *Database) GetAttesterSlashings() []*AttesterSlashing {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	slashings := make([]*AttesterSlashing, len(db.attesterSlashings))
+	copy(slashings, db.attesterSlashings)
+	return slashings
+}
+
+// Slash a validator as if it had been caught by the current slot's proposer
+func (db *Database) SlashValidator(index uint64) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	validator, err := db.getSlashableValidator(index)
+	if err != nil {
+		return err
+	}
+	db.slashValidator(validator, db.getInitialSlashingPenalty(validator))
+	db.rewardWhistleblower(validator)
+	return nil
+}
+
+// Slash a validator like SlashValidator, but with a custom penalty (in gwei) instead of the spec's initial penalty and
+// whistleblower reward, so its balance drops by exactly that amount and no other balances change
+func (db *Database) SlashValidatorWithPenalty(index uint64, penalty uint64) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	validator, err := db.getSlashableValidator(index)
+	if err != nil {
+		return err
+	}
+	db.slashValidator(validator, penalty)
+	return nil
+}
+
+// Validate a proposer slashing and, if it's valid, slash the proposer and add it to the pool.
+// The mock doesn't track signed blocks, so the header signatures aren't checked.
+func (db *Database) SubmitProposerSlashing(slashing *ProposerSlashing) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	// Check the headers
+	header1 := slashing.Header1
+	header2 := slashing.Header2
+	if header1.Slot != header2.Slot {
+		return fmt.Errorf("headers are for different slots (%d and %d)", header1.Slot, header2.Slot)
+	}
+	if header1.ProposerIndex != header2.ProposerIndex {
+		return fmt.Errorf("headers have different proposers (%d and %d)", header1.ProposerIndex, header2.ProposerIndex)
+	}
+	header1.Signature = beacon.ValidatorSignature{}
+	header2.Signature = beacon.ValidatorSignature{}
+	if header1 == header2 {
+		return fmt.Errorf("headers are identical")
+	}
+
+	// Check the proposer
+	if header1.ProposerIndex >= uint64(len(db.validators)) {
+		return fmt.Errorf("validator with index %d does not exist", header1.ProposerIndex)
+	}
+	validator := db.validators[header1.ProposerIndex]
+	if !db.isSlashable(validator) {
+		return fmt.Errorf("validator %d is not slashable (status: %s)", validator.Index, validator.Status)
+	}
+
+	db.slashValidator(validator, db.getInitialSlashingPenalty(validator))
+	db.rewardWhistleblower(validator)
+	db.proposerSlashings = append(db.proposerSlashings, slashing)
+	return nil
+}
+
+// Validate an attester slashing and, if it's valid, slash every slashable validator that signed both attestations and
+// add it to the pool. The mock doesn't track attestations, so the aggregate signatures aren't checked.
+func (db *Database) SubmitAttesterSlashing(slashing *AttesterSlashing) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	// Check the attestations
+	data1 := slashing.Attestation1.Data
+	data2 := slashing.Attestation2.Data
+	isDoubleVote := data1 != data2 && data1.Target.Epoch == data2.Target.Epoch
+	isSurroundVote := data1.Source.Epoch < data2.Source.Epoch && data2.Target.Epoch < data1.Target.Epoch
+	if !isDoubleVote && !isSurroundVote {
+		return fmt.Errorf("attestations are not a double vote or a surround vote")
+	}
+	for i, attestation := range []IndexedAttestation{slashing.Attestation1, slashing.Attestation2} {
+		indices := attestation.AttestingIndices
+		if len(indices) == 0 {
+			return fmt.Errorf("attestation %d has no attesting indices", i+1)
+		}
+		for j := 1; j < len(indices); j++ {
+			if indices[j] <= indices[j-1] {
+				return fmt.Errorf("attestation %d's attesting indices are not sorted and unique", i+1)
+			}
+		}
+	}
+
+	// Slash the validators in both attestations
+	slashedCount := 0
+	for _, index := range slashing.Attestation1.AttestingIndices {
+		if !slices.Contains(slashing.Attestation2.AttestingIndices, index) || index >= uint64(len(db.validators)) {
+			continue
+		}
+		validator := db.validators[index]
+		if db.isSlashable(validator) {
+			db.slashValidator(validator, db.getInitialSlashingPenalty(validator))
+			db.rewardWhistleblower(validator)
+			slashedCount++
+		}
+	}
+	if slashedCount == 0 {
+		return fmt.Errorf("no validators were slashed")
+	}
+	db.attesterSlashings = append(db.attesterSlashings, slashing)
+	return nil
+}
+
+// Get a validator by its index, returning an error if it doesn't exist or can't be slashed. The lock must be held by
+// the caller.
+func (db *Database) getSlashableValidator(index uint64) (*Validator, error) {
+	if index >= uint64(len(db.validators)) {
+		return nil, fmt.Errorf("validator with index %d does not exist", index)
+	}
+	validator := db.validators[index]
+	if !db.isSlashable(validator) {
+		return nil, fmt.Errorf("validator %d is not slashable (status: %s)", index, validator.Status)
+	}
+	return validator, nil
+}
+
+// Check if a validator can be slashed. The lock must be held by the caller.
+func (db *Database) isSlashable(validator *Validator) bool {
+	epoch := db.currentSlot / db.config.SlotsPerEpoch
+	return !validator.Slashed && validator.IsActive() && epoch < validator.WithdrawableEpoch
+}
+
+// Get the spec's initial penalty for slashing a validator in the current epoch. The lock must be held by the caller.
+func (db *Database) getInitialSlashingPenalty(validator *Validator) uint64 {
+	epoch := db.currentSlot / db.config.SlotsPerEpoch
+	penaltyQuotient := MinSlashingPenaltyQuotientBellatrix
+	switch {
+	case db.config.IsElectraActive(epoch):
+		penaltyQuotient = MinSlashingPenaltyQuotientElectra
+	case epoch < db.config.AltairForkEpoch:
+		penaltyQuotient = MinSlashingPenaltyQuotient
+	case epoch < db.config.BellatrixForkEpoch:
+		penaltyQuotient = MinSlashingPenaltyQuotientAltair
+	}
+	return validator.EffectiveBalance / penaltyQuotient
+}
+
+// Slash a validator, forcing it to exit and applying the given initial penalty. The lock must be held by the caller.
+func (db *Database) slashValidator(validator *Validator, penalty uint64) {
+	epoch := db.currentSlot / db.config.SlotsPerEpoch
+	db.initiateValidatorExit(validator, epoch)
+	validator.Slashed = true
+	validator.Status = beacon.ValidatorState_ActiveSlashed
+	validator.WithdrawableEpoch = max(validator.WithdrawableEpoch, epoch+EpochsPerSlashingsVector)
+	db.slashings[epoch%EpochsPerSlashingsVector] += validator.EffectiveBalance
+	validator.Balance -= min(validator.Balance, penalty)
+}
+
+// Reward the current slot's proposer for slashing a validator. It gets the whole whistleblower reward, since there's no
+// separate whistleblower. The lock must be held by the caller.
+func (db *Database) rewardWhistleblower(validator *Validator) {
+	epoch := db.currentSlot / db.config.SlotsPerEpoch
+	whistleblowerQuotient := WhistleblowerRewardQuotient
+	if db.config.IsElectraActive(epoch) {
+		whistleblowerQuotient = WhistleblowerRewardQuotientElectra
+	}
+	proposerIndex, exists := db.getProposerIndex(db.currentSlot, getActiveValidatorIndices(db.validators))
+	if exists {
+		db.validators[proposerIndex].Balance += validator.EffectiveBalance / whistleblowerQuotient
+	}
+}
+
+// Apply the correlation penalty to slashed validators halfway to their withdrawable epoch, based on the total balance
+// slashed in the surrounding epochs. The lock must be held by the caller.
+func (db *Database) processSlashings(epoch uint64) {
+	currentEpoch := epoch - 1
+	totalBalance := uint64(0)
+	for _, validator := range db.validators {
+		if validator.IsActive() {
+			totalBalance += validator.EffectiveBalance
+		}
+	}
+	totalBalance = max(totalBalance, EffectiveBalanceIncrement)
+
+	// Get the adjusted total slashed balance
+	multiplier := ProportionalSlashingMultiplierBellatrix
+	switch {
+	case currentEpoch < db.config.AltairForkEpoch:
+		multiplier = ProportionalSlashingMultiplier
+	case currentEpoch < db.config.BellatrixForkEpoch:
+		multiplier = ProportionalSlashingMultiplierAltair
+	}
+	totalSlashed := uint64(0)
+	for _, slashed := range db.slashings {
+		totalSlashed += slashed
+	}
+	adjustedTotalSlashingBalance := min(totalSlashed*multiplier, totalBalance)
+
+	// Apply the penalties
+	penaltyPerIncrement := adjustedTotalSlashingBalance / (totalBalance / EffectiveBalanceIncrement)
+	for _, validator := range db.validators {
+		if !validator.Slashed || currentEpoch+EpochsPerSlashingsVector/2 != validator.WithdrawableEpoch {
+			continue
+		}
+		increments := validator.EffectiveBalance / EffectiveBalanceIncrement
+		var penalty uint64
+		if db.config.IsElectraActive(currentEpoch) {
+			penalty = penaltyPerIncrement * increments
+		} else {
+			penalty = increments * adjustedTotalSlashingBalance / totalBalance * EffectiveBalanceIncrement
+		}
+		validator.Balance -= min(validator.Balance, penalty)
+	}
+
+	// Clear out the slot for the new epoch
+	db.slashings[epoch%EpochsPerSlashingsVector] = 0
+}
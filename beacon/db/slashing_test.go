@@ -0,0 +1,166 @@
+package db
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nodeset-org/osha/beacon/internal/test"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlashingPenalties(t *testing.T) {
+	d := NewDatabase(slog.Default(), 0)
+	validators := []*Validator{}
+	for _, pubkey := range []string{test.Pubkey0String, test.Pubkey1String, test.Pubkey2String, test.Pubkey3String} {
+		v := addTestValidator(t, d, pubkey)
+		v.Status = beacon.ValidatorState_ActiveOngoing
+		validators = append(validators, v)
+	}
+	slashed := validators[0]
+	proposer := validators[1]
+	require.NoError(t, d.SetProposer(0, proposer.Index))
+
+	// Slash the validator
+	require.NoError(t, d.SlashValidator(slashed.Index))
+	require.True(t, slashed.Slashed)
+	require.Equal(t, beacon.ValidatorState_ActiveSlashed, slashed.Status)
+	require.Equal(t, uint64(1+MaxSeedLookahead), slashed.ExitEpoch)
+	require.Equal(t, EpochsPerSlashingsVector, slashed.WithdrawableEpoch)
+	require.Equal(t, StartingBalance-StartingBalance/MinSlashingPenaltyQuotientBellatrix, slashed.Balance)
+	require.Equal(t, StartingBalance+StartingBalance/WhistleblowerRewardQuotient, proposer.Balance)
+	require.Error(t, d.SlashValidator(slashed.Index))
+	t.Logf("Initial penalty applied - exit epoch: %d, withdrawable epoch: %d", slashed.ExitEpoch, slashed.WithdrawableEpoch)
+
+	// Move the midpoint up so the correlation penalty is applied at the end of epoch 1, not epoch 0
+	slashed.WithdrawableEpoch = 1 + EpochsPerSlashingsVector/2
+	balance := slashed.Balance
+	missSlots(d, d.config.SlotsPerEpoch)
+	require.Equal(t, balance, slashed.Balance)
+	t.Log("No correlation penalty at the end of epoch 0")
+
+	// About a quarter of the active balance was slashed, so with the multiplier of 3 it loses about 3/4 of its effective
+	// balance, which dropped to its balance at the end of epoch 0
+	totalBalance := uint64(0)
+	for _, validator := range validators {
+		totalBalance += validator.EffectiveBalance
+	}
+	penalty := slashed.EffectiveBalance / EffectiveBalanceIncrement * (3 * StartingBalance) / totalBalance * EffectiveBalanceIncrement
+	missSlots(d, d.config.SlotsPerEpoch)
+	require.Equal(t, balance-penalty, slashed.Balance)
+	t.Logf("Correlation penalty applied at the end of epoch 1 - balance: %d", slashed.Balance)
+
+	// The penalty is only applied once
+	missSlots(d, d.config.SlotsPerEpoch)
+	require.Equal(t, balance-penalty, slashed.Balance)
+}
+
+func TestElectraSlashingPenalties(t *testing.T) {
+	config := NewDefaultConfig()
+	config.ElectraForkEpoch = 0
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	validators := []*Validator{}
+	for _, pubkey := range []string{test.Pubkey0String, test.Pubkey1String, test.Pubkey2String, test.Pubkey3String} {
+		v := addTestValidator(t, d, pubkey)
+		v.Status = beacon.ValidatorState_ActiveOngoing
+		validators = append(validators, v)
+	}
+	slashed := validators[0]
+	proposer := validators[1]
+	require.NoError(t, d.SetProposer(0, proposer.Index))
+
+	// Slash the validator
+	require.NoError(t, d.SlashValidator(slashed.Index))
+	require.Equal(t, StartingBalance-StartingBalance/MinSlashingPenaltyQuotientElectra, slashed.Balance)
+	require.Equal(t, StartingBalance+StartingBalance/WhistleblowerRewardQuotientElectra, proposer.Balance)
+	t.Log("Initial penalty applied")
+
+	// The penalty is calculated per effective balance increment
+	slashed.WithdrawableEpoch = EpochsPerSlashingsVector / 2
+	balance := slashed.Balance
+	missSlots(d, config.SlotsPerEpoch)
+	penaltyPerIncrement := 3 * StartingBalance / (4 * StartingBalance / EffectiveBalanceIncrement)
+	require.Equal(t, balance-penaltyPerIncrement*(StartingBalance/EffectiveBalanceIncrement), slashed.Balance)
+	t.Logf("Correlation penalty applied - balance: %d", slashed.Balance)
+}
+
+func TestProposerSlashing(t *testing.T) {
+	d := NewDatabase(slog.Default(), 0)
+	v := addTestValidator(t, d, test.Pubkey0String)
+	header := SignedBlockHeader{
+		Slot:          1,
+		ProposerIndex: v.Index,
+		BodyRoot:      common.HexToHash("0x01"),
+	}
+
+	// Pending validators can't be slashed
+	slashing := &ProposerSlashing{Header1: header, Header2: header}
+	slashing.Header2.BodyRoot = common.HexToHash("0x02")
+	require.Error(t, d.SubmitProposerSlashing(slashing))
+	v.Status = beacon.ValidatorState_ActiveOngoing
+
+	// Invalid headers
+	for _, modify := range []func(*SignedBlockHeader){
+		func(h *SignedBlockHeader) { h.BodyRoot = header.BodyRoot },
+		func(h *SignedBlockHeader) { h.Slot++ },
+		func(h *SignedBlockHeader) { h.ProposerIndex++ },
+	} {
+		invalid := &ProposerSlashing{Header1: header, Header2: slashing.Header2}
+		modify(&invalid.Header2)
+		require.Error(t, d.SubmitProposerSlashing(invalid))
+	}
+	invalid := &ProposerSlashing{Header1: header, Header2: header}
+	invalid.Header2.Signature[0] = 1
+	require.Error(t, d.SubmitProposerSlashing(invalid))
+	require.False(t, v.Slashed)
+	t.Log("Invalid proposer slashings were rejected")
+
+	// Submit a valid slashing
+	require.NoError(t, d.SubmitProposerSlashing(slashing))
+	require.True(t, v.Slashed)
+	require.Len(t, d.GetProposerSlashings(), 1)
+	t.Log("Proposer was slashed")
+}
+
+func TestAttesterSlashing(t *testing.T) {
+	d := NewDatabase(slog.Default(), 0)
+	validators := []*Validator{}
+	for _, pubkey := range []string{test.Pubkey0String, test.Pubkey1String, test.Pubkey2String} {
+		v := addTestValidator(t, d, pubkey)
+		v.Status = beacon.ValidatorState_ActiveOngoing
+		validators = append(validators, v)
+	}
+	attestation := func(indices []uint64, source uint64, target uint64) IndexedAttestation {
+		return IndexedAttestation{
+			AttestingIndices: indices,
+			Data: AttestationData{
+				Source: Checkpoint{Epoch: source},
+				Target: Checkpoint{Epoch: target},
+			},
+		}
+	}
+
+	// Identical, unrelated, empty and unsorted attestations are rejected
+	require.Error(t, d.SubmitAttesterSlashing(&AttesterSlashing{attestation([]uint64{0}, 1, 2), attestation([]uint64{0}, 1, 2)}))
+	require.Error(t, d.SubmitAttesterSlashing(&AttesterSlashing{attestation([]uint64{0}, 1, 2), attestation([]uint64{0}, 2, 3)}))
+	require.Error(t, d.SubmitAttesterSlashing(&AttesterSlashing{attestation([]uint64{}, 1, 3), attestation([]uint64{0}, 0, 3)}))
+	require.Error(t, d.SubmitAttesterSlashing(&AttesterSlashing{attestation([]uint64{1, 0}, 1, 3), attestation([]uint64{0}, 0, 3)}))
+
+	// Attestations with no validators in common are rejected
+	require.Error(t, d.SubmitAttesterSlashing(&AttesterSlashing{attestation([]uint64{0}, 1, 3), attestation([]uint64{1}, 0, 3)}))
+	t.Log("Invalid attester slashings were rejected")
+
+	// Double vote
+	require.NoError(t, d.SubmitAttesterSlashing(&AttesterSlashing{attestation([]uint64{0, 1}, 1, 3), attestation([]uint64{1, 2}, 0, 3)}))
+	require.False(t, validators[0].Slashed)
+	require.True(t, validators[1].Slashed)
+	require.False(t, validators[2].Slashed)
+	t.Log("Double vote was slashed")
+
+	// Surround vote, where the already slashed validator is skipped
+	require.NoError(t, d.SubmitAttesterSlashing(&AttesterSlashing{attestation([]uint64{0, 1}, 1, 4), attestation([]uint64{0, 1}, 2, 3)}))
+	require.True(t, validators[0].Slashed)
+	require.Len(t, d.GetAttesterSlashings(), 2)
+	t.Log("Surround vote was slashed")
+}
@@ -0,0 +1,257 @@
+package db
+
+import (
+	"encoding/binary"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/beacon"
+)
+
+// A read-only view of the Beacon chain state at a specific slot
+type State struct {
+	// The slot of the state
+	Slot uint64
+
+	// The root of the state
+	StateRoot common.Hash
+
+	// The validators registered as of the state's slot
+	Validators []*Validator
+
+	// The deposits that were pending as of the state's slot
+	PendingDeposits []*Deposit
+
+	// The partial withdrawals that were pending as of the state's slot
+	PendingPartialWithdrawals []*PendingPartialWithdrawal
+
+	// The consolidations that were pending as of the state's slot
+	PendingConsolidations []*PendingConsolidation
+
+	// The finality checkpoints as of the state's slot
+	Finality FinalityCheckpoints
+}
+
+// Get a validator in the state by its index. Returns nil if it doesn't exist.
+func (s *State) GetValidatorByIndex(index uint64) *Validator {
+	if index >= uint64(len(s.Validators)) {
+		return nil
+	}
+	return s.Validators[index]
+}
+
+// Get a validator in the state by its pubkey. Returns nil if it doesn't exist.
+func (s *State) GetValidatorByPubkey(pubkey beacon.ValidatorPubkey) *Validator {
+	for _, validator := range s.Validators {
+		if validator.Pubkey == pubkey {
+			return validator
+		}
+	}
+	return nil
+}
+
+// A value recorded at the slot it changed in
+type version[T any] struct {
+	slot  uint64
+	value T
+}
+
+// The history of a value, stored as the versions it changed in so unchanged slots don't take up any space
+type history[T any] []version[T]
+
+// Get the versions recorded before the given slot
+func (h history[T]) before(slot uint64) history[T] {
+	i := sort.Search(len(h), func(i int) bool {
+		return h[i].slot >= slot
+	})
+	return h[:i]
+}
+
+// Get the versions needed to get the value as of the given slot or any slot after it
+func (h history[T]) since(slot uint64) history[T] {
+	i := sort.Search(len(h), func(i int) bool {
+		return h[i].slot > slot
+	})
+	if i == 0 {
+		return h
+	}
+	return slices.Clone(h[i-1:])
+}
+
+// Get the value as of the given slot. Returns false if the value didn't exist yet.
+func (h history[T]) at(slot uint64) (T, bool) {
+	i := sort.Search(len(h), func(i int) bool {
+		return h[i].slot > slot
+	})
+	if i == 0 {
+		var empty T
+		return empty, false
+	}
+	return h[i-1].value, true
+}
+
+// Get the latest recorded value. Returns false if there isn't one.
+func (h history[T]) latest() (T, bool) {
+	if len(h) == 0 {
+		var empty T
+		return empty, false
+	}
+	return h[len(h)-1].value, true
+}
+
+// Get the state at the given slot. The head slot returns the live state; older slots are rebuilt from history.
+// Returns nil if the slot hasn't been reached yet or has been pruned.
+func (db *Database) GetState(slot uint64) *State {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.getState(slot)
+}
+
+// Get the state with the given state root. Returns nil if it doesn't exist.
+func (db *Database) GetStateByRoot(root common.Hash) *State {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if root == db.getStateRoot(db.currentSlot) {
+		return db.getState(db.currentSlot)
+	}
+	slot, exists := db.stateRootMap[root]
+	if !exists {
+		return nil
+	}
+	return db.getState(slot)
+}
+
+// Get the state at the given slot. The lock must be held by the caller.
+func (db *Database) getState(slot uint64) *State {
+	if slot > db.currentSlot || slot < db.oldestStateSlot {
+		return nil
+	}
+
+	// The head is the live state
+	if slot == db.currentSlot {
+		return &State{
+			Slot:                      slot,
+			StateRoot:                 db.getStateRoot(slot),
+			Validators:                slices.Clone(db.validators),
+			PendingDeposits:           slices.Clone(db.pendingDeposits),
+			PendingPartialWithdrawals: slices.Clone(db.pendingPartialWithdrawals),
+			PendingConsolidations:     slices.Clone(db.pendingConsolidations),
+			Finality:                  db.finality,
+		}
+	}
+
+	// Rebuild older states from history
+	state := &State{
+		Slot:                      slot,
+		StateRoot:                 db.getStateRoot(slot),
+		Validators:                []*Validator{},
+		PendingDeposits:           getQueueAt(db.depositHistory, slot),
+		PendingPartialWithdrawals: getQueueAt(db.partialWithdrawalHistory, slot),
+		PendingConsolidations:     getQueueAt(db.consolidationHistory, slot),
+	}
+	for _, validatorHistory := range db.validatorHistories {
+		validator, exists := validatorHistory.at(slot)
+		if !exists {
+			// Validators are added in index order, so none of the later ones exist yet either
+			break
+		}
+		state.Validators = append(state.Validators, &validator)
+	}
+	state.Finality, _ = db.finalityHistory.at(slot)
+	return state
+}
+
+// Record the parts of the state that changed during the given slot so it can be rebuilt later.
+// The lock must be held by the caller.
+func (db *Database) recordState(slot uint64) {
+	// Validators
+	for i, validator := range db.validators {
+		if i == len(db.validatorHistories) {
+			db.validatorHistories = append(db.validatorHistories, history[Validator]{})
+		}
+		last, exists := db.validatorHistories[i].latest()
+		if !exists || last != *validator {
+			db.validatorHistories[i] = append(db.validatorHistories[i], version[Validator]{slot: slot, value: *validator})
+		}
+	}
+
+	// Pending queues
+	db.depositHistory = recordQueue(db.depositHistory, db.pendingDeposits, slot)
+	db.partialWithdrawalHistory = recordQueue(db.partialWithdrawalHistory, db.pendingPartialWithdrawals, slot)
+	db.consolidationHistory = recordQueue(db.consolidationHistory, db.pendingConsolidations, slot)
+
+	// Finality
+	lastFinality, exists := db.finalityHistory.latest()
+	if !exists || lastFinality != db.finality {
+		db.finalityHistory = append(db.finalityHistory, version[FinalityCheckpoints]{slot: slot, value: db.finality})
+	}
+
+	db.stateRootMap[db.getStateRoot(slot)] = slot
+}
+
+// Forget the history of the states more than StateHistoryEpochs before the finalized checkpoint, so the history doesn't
+// grow forever. The lock must be held by the caller.
+func (db *Database) pruneStates() {
+	retentionSlots := db.config.StateHistoryEpochs * db.config.SlotsPerEpoch
+	finalizedSlot := db.finality.Finalized.Epoch * db.config.SlotsPerEpoch
+	if finalizedSlot <= db.oldestStateSlot+retentionSlots {
+		return
+	}
+
+	oldestSlot := finalizedSlot - retentionSlots
+	for i, validatorHistory := range db.validatorHistories {
+		db.validatorHistories[i] = validatorHistory.since(oldestSlot)
+	}
+	db.depositHistory = db.depositHistory.since(oldestSlot)
+	db.partialWithdrawalHistory = db.partialWithdrawalHistory.since(oldestSlot)
+	db.consolidationHistory = db.consolidationHistory.since(oldestSlot)
+	db.finalityHistory = db.finalityHistory.since(oldestSlot)
+	maps.DeleteFunc(db.stateRootMap, func(root common.Hash, slot uint64) bool {
+		return slot < oldestSlot
+	})
+	db.oldestStateSlot = oldestSlot
+}
+
+// Add a new version of a queue to its history if it changed
+func recordQueue[T comparable](queueHistory history[[]T], queue []*T, slot uint64) history[[]T] {
+	values := make([]T, len(queue))
+	for i, element := range queue {
+		values[i] = *element
+	}
+	last, exists := queueHistory.latest()
+	if exists && slices.Equal(last, values) {
+		return queueHistory
+	}
+	return append(queueHistory, version[[]T]{slot: slot, value: values})
+}
+
+// Rebuild a queue as of the given slot from its history
+func getQueueAt[T any](queueHistory history[[]T], slot uint64) []*T {
+	values, _ := queueHistory.at(slot)
+	queue := make([]*T, len(values))
+	for i := range values {
+		element := values[i]
+		queue[i] = &element
+	}
+	return queue
+}
+
+// Get the root of the state at the given slot. This is the state root of the block proposed in the slot,
+// or derived from the latest block and the slot number if the slot was missed. The lock must be held by the caller.
+func (db *Database) getStateRoot(slot uint64) common.Hash {
+	block, exists := db.blockMap[slot]
+	if exists {
+		return block.StateRoot
+	}
+
+	var parentRoot common.Hash
+	latestBlock := db.getLatestBlock(slot)
+	if latestBlock != nil {
+		parentRoot = latestBlock.Root
+	}
+	return deriveRoot(parentRoot, "state"+string(binary.LittleEndian.AppendUint64(nil, slot)))
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/nodeset-org/osha/beacon/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoricalStates(t *testing.T) {
+	d := NewDatabase(slog.Default(), 0)
+	v0 := addTestValidator(t, d, test.Pubkey0String)
+	d.CommitBlock(true)
+
+	// Change the balance in slot 1
+	v0.Balance = StartingBalance - 1e9
+	d.CommitBlock(true)
+
+	// Add a validator in slot 2 without a block
+	addTestValidator(t, d, test.Pubkey1String)
+	d.CommitBlock(false)
+	require.Equal(t, uint64(3), d.GetCurrentSlot())
+	t.Log("Committed 3 slots")
+
+	// Check the old states
+	state := d.GetState(0)
+	require.NotNil(t, state)
+	require.Len(t, state.Validators, 1)
+	require.Equal(t, StartingBalance, state.Validators[0].Balance)
+	state = d.GetState(1)
+	require.Len(t, state.Validators, 1)
+	require.Equal(t, StartingBalance-1e9, state.Validators[0].Balance)
+	state = d.GetState(2)
+	require.Len(t, state.Validators, 2)
+	require.Nil(t, state.GetValidatorByIndex(2))
+	t.Log("Historical states were rebuilt correctly")
+
+	// Old states are copies
+	state.Validators[0].Balance = 0
+	require.Equal(t, StartingBalance-1e9, v0.Balance)
+	t.Log("Historical states didn't modify the live validators")
+
+	// Check the head and future slots
+	state = d.GetState(3)
+	require.Same(t, v0, state.GetValidatorByIndex(0))
+	require.Nil(t, d.GetState(4))
+	t.Log("Head state is live and future states don't exist")
+
+	// Look up states by root
+	root := d.GetState(1).StateRoot
+	require.Equal(t, d.GetBlockBySlot(1).StateRoot, root)
+	require.Equal(t, uint64(1), d.GetStateByRoot(root).Slot)
+	require.Equal(t, uint64(2), d.GetStateByRoot(d.GetState(2).StateRoot).Slot)
+	require.Equal(t, uint64(3), d.GetStateByRoot(d.GetState(3).StateRoot).Slot)
+	require.Nil(t, d.GetStateByRoot([32]byte{}))
+	t.Log("States were found by root")
+
+	// Clones keep the history
+	clone := d.Clone()
+	require.Equal(t, StartingBalance, clone.GetState(0).Validators[0].Balance)
+	require.Equal(t, uint64(1), clone.GetStateByRoot(root).Slot)
+	t.Log("Clone kept the state history")
+}
+
+func TestStatePruning(t *testing.T) {
+	config := NewDefaultConfig()
+	config.StateHistoryEpochs = 1
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	v0 := addTestValidator(t, d, test.Pubkey0String)
+	commitEpochs(d, 2)
+	v0.Balance = StartingBalance - 1e9
+	prunedRoot := d.GetState(config.SlotsPerEpoch).StateRoot
+	commitEpochs(d, 3)
+	t.Log("Committed 5 epochs")
+
+	// Epoch 3 is finalized, so states before epoch 2 should be pruned
+	require.Equal(t, uint64(3), d.GetFinalityCheckpoints().Finalized.Epoch)
+	require.Nil(t, d.GetState(0))
+	require.Nil(t, d.GetState(2*config.SlotsPerEpoch-1))
+	require.Nil(t, d.GetStateByRoot(prunedRoot))
+	t.Log("Old states were pruned")
+
+	// Newer states should still be rebuilt correctly
+	state := d.GetState(2 * config.SlotsPerEpoch)
+	require.NotNil(t, state)
+	require.Equal(t, StartingBalance-1e9, state.Validators[0].Balance)
+	require.Equal(t, state, d.GetStateByRoot(state.StateRoot))
+	require.Equal(t, 2*config.SlotsPerEpoch, d.validatorHistories[0][0].slot)
+	t.Log("Retained states were rebuilt correctly")
+}
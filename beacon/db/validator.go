@@ -40,22 +40,19 @@ func NewValidator(pubkey beacon.ValidatorPubkey, withdrawalCredentials common.Ha
 	}
 }
 
+// Set the validator's balance. The effective balance follows it at the next epoch transition.
 func (v *Validator) SetBalance(balanceGwei uint64) {
 	v.Balance = balanceGwei
-
-	// Rules taken from the spec: https://github.com/ethereum/annotated-spec/blob/master/phase0/beacon-chain.md#misc
-	if balanceGwei < v.EffectiveBalance-25e7 {
-		v.EffectiveBalance--
-	}
-	if balanceGwei > v.EffectiveBalance+125e7 {
-		v.EffectiveBalance++
-	}
 }
 
 func (v *Validator) SetStatus(status beacon.ValidatorState) {
 	v.Status = status
 }
 
+// Mark the validator as slashed and subtract the penalty from its balance. This doesn't start its exit or apply any of
+// the spec's slashing penalties and rewards.
+//
+// Deprecated: use Database.SlashValidator, which slashes the validator the way the spec does.
 func (v *Validator) Slash(penaltyGwei uint64) error {
 	if v.Status != beacon.ValidatorState_ActiveOngoing && v.Status != beacon.ValidatorState_ActiveExiting {
 		return fmt.Errorf("validator with pubkey %s is not in a slashable state", v.Pubkey.HexWithPrefix())
@@ -66,6 +63,39 @@ func (v *Validator) Slash(penaltyGwei uint64) error {
 	return nil
 }
 
+// Check if the validator is currently active on the Beacon chain
+func (v *Validator) IsActive() bool {
+	switch v.Status {
+	case beacon.ValidatorState_ActiveOngoing, beacon.ValidatorState_ActiveExiting, beacon.ValidatorState_ActiveSlashed:
+		return true
+	}
+	return false
+}
+
+// Check if the validator has 0x01 or 0x02 credentials, so it can withdraw to an execution address
+func (v *Validator) HasExecutionWithdrawalCredentials() bool {
+	prefix := v.WithdrawalCredentials[0]
+	return prefix == Eth1AddressWithdrawalPrefix || prefix == CompoundingWithdrawalPrefix
+}
+
+// Get the execution address the validator withdraws to. Only valid if it has execution withdrawal credentials.
+func (v *Validator) GetWithdrawalAddress() common.Address {
+	return common.BytesToAddress(v.WithdrawalCredentials[12:])
+}
+
+// Check if the validator has 0x02 compounding credentials
+func (v *Validator) HasCompoundingWithdrawalCredentials() bool {
+	return v.WithdrawalCredentials[0] == CompoundingWithdrawalPrefix
+}
+
+// Get the highest effective balance the validator can have after Electra, based on its withdrawal credentials
+func (v *Validator) GetMaxEffectiveBalance() uint64 {
+	if v.HasCompoundingWithdrawalCredentials() {
+		return MaxEffectiveBalanceElectra
+	}
+	return MinActivationBalance
+}
+
 func (v *Validator) GetValidatorMeta() client.Validator {
 	validatorMeta := client.Validator{
 		Index:   strconv.FormatUint(v.Index, 10),
@@ -0,0 +1,194 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/node-manager-core/beacon"
+)
+
+// A withdrawal from the Beacon chain to an execution address
+type Withdrawal struct {
+	// The global index of the withdrawal
+	Index uint64
+
+	// The index of the validator being withdrawn from
+	ValidatorIndex uint64
+
+	// The execution address receiving the withdrawal
+	Address common.Address
+
+	// The amount withdrawn, in gwei
+	Amount uint64
+}
+
+// A partial withdrawal requested from the execution layer after Electra (EIP-7002), waiting to be swept
+type PendingPartialWithdrawal struct {
+	// The index of the validator being withdrawn from
+	ValidatorIndex uint64
+
+	// The amount requested, in gwei
+	Amount uint64
+
+	// The earliest epoch the withdrawal can be swept in
+	WithdrawableEpoch uint64
+}
+
+// The position of the withdrawal sweep, recorded with each block so it can be restored if the block is reorged out
+type withdrawalCursor struct {
+	// The index to assign to the next withdrawal
+	withdrawalIndex uint64
+
+	// The validator index to start the next sweep from
+	validatorIndex uint64
+
+	// The pending partial withdrawals the block took off the queue
+	processedPartialWithdrawals []PendingPartialWithdrawal
+}
+
+// Get the partial withdrawals waiting to be swept
+func (db *Database) GetPendingPartialWithdrawals() []*PendingPartialWithdrawal {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	withdrawals := make([]*PendingPartialWithdrawal, len(db.pendingPartialWithdrawals))
+	copy(withdrawals, db.pendingPartialWithdrawals)
+	return withdrawals
+}
+
+// Queue a partial withdrawal for a validator with compounding credentials, as if it had been requested from the
+// execution layer. The amount is capped to the validator's balance over 32 ETH, and the withdrawable epoch is
+// assigned from the exit churn.
+func (db *Database) AddPendingPartialWithdrawal(index uint64, amount uint64) (*PendingPartialWithdrawal, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	// Partial withdrawal requests only exist after Electra
+	epoch := db.currentSlot / db.config.SlotsPerEpoch
+	if !db.config.IsElectraActive(epoch) {
+		return nil, fmt.Errorf("partial withdrawal requests aren't supported before Electra (fork epoch %d)", db.config.ElectraForkEpoch)
+	}
+
+	// Check the validator
+	if index >= uint64(len(db.validators)) {
+		return nil, fmt.Errorf("validator with index %d does not exist", index)
+	}
+	validator := db.validators[index]
+	if validator.Status != beacon.ValidatorState_ActiveOngoing {
+		return nil, fmt.Errorf("validator %d is not active (status: %s)", index, validator.Status)
+	}
+	if !validator.HasCompoundingWithdrawalCredentials() {
+		return nil, fmt.Errorf("validator %d does not have compounding withdrawal credentials", index)
+	}
+	if amount == 0 {
+		return nil, fmt.Errorf("withdrawal amount must be greater than 0")
+	}
+
+	// Make sure there's an excess balance to withdraw
+	pendingBalance := db.getPendingBalanceToWithdraw(index)
+	if validator.EffectiveBalance < MinActivationBalance || validator.Balance <= MinActivationBalance+pendingBalance {
+		return nil, fmt.Errorf("validator %d has no excess balance to withdraw", index)
+	}
+
+	// Queue it
+	toWithdraw := min(validator.Balance-MinActivationBalance-pendingBalance, amount)
+	exitQueueEpoch := db.computeExitEpochAndUpdateChurn(toWithdraw, epoch)
+	withdrawal := &PendingPartialWithdrawal{
+		ValidatorIndex:    index,
+		Amount:            toWithdraw,
+		WithdrawableEpoch: exitQueueEpoch + MinValidatorWithdrawabilityDelay,
+	}
+	db.pendingPartialWithdrawals = append(db.pendingPartialWithdrawals, withdrawal)
+	return withdrawal, nil
+}
+
+// Sweep the pending partial withdrawals (after Electra) and then the validators for full and partial withdrawals,
+// debiting their balances and returning the withdrawals to include in the block's payload. The lock must be held by the caller.
+func (db *Database) processWithdrawals(epoch uint64) []Withdrawal {
+	withdrawals := []Withdrawal{}
+	validatorCount := uint64(len(db.validators))
+	if validatorCount == 0 {
+		return withdrawals
+	}
+
+	// Process the pending partial withdrawals that are ready, which only exist after Electra
+	processedCount := 0
+	for _, pending := range db.pendingPartialWithdrawals {
+		if !db.config.IsElectraActive(epoch) || pending.WithdrawableEpoch > epoch || uint64(len(withdrawals)) == MaxPendingPartialsPerWithdrawalsSweep {
+			break
+		}
+		validator := db.validators[pending.ValidatorIndex]
+		if validator.ExitEpoch == FarFutureEpoch && validator.EffectiveBalance >= MinActivationBalance && validator.Balance > MinActivationBalance {
+			withdrawals = append(withdrawals, db.withdraw(validator, min(validator.Balance-MinActivationBalance, pending.Amount)))
+		}
+		processedCount++
+	}
+	db.pendingPartialWithdrawals = db.pendingPartialWithdrawals[processedCount:]
+
+	sweepLimit := min(validatorCount, MaxValidatorsPerWithdrawalsSweep)
+	validatorIndex := db.nextWithdrawalValidatorIndex % validatorCount
+	for i := uint64(0); i < sweepLimit; i++ {
+		validator := db.validators[validatorIndex]
+		amount := db.getWithdrawableAmount(validator, epoch)
+		if amount > 0 {
+			withdrawals = append(withdrawals, db.withdraw(validator, amount))
+		}
+		if uint64(len(withdrawals)) == MaxWithdrawalsPerPayload {
+			break
+		}
+		validatorIndex = (validatorIndex + 1) % validatorCount
+	}
+
+	// Like the spec's update_next_withdrawal_validator_index, start the next sweep after the last withdrawal if the
+	// payload is full, or advance by the full sweep size otherwise
+	if uint64(len(withdrawals)) == MaxWithdrawalsPerPayload {
+		db.nextWithdrawalValidatorIndex = (withdrawals[len(withdrawals)-1].ValidatorIndex + 1) % validatorCount
+	} else {
+		db.nextWithdrawalValidatorIndex = (db.nextWithdrawalValidatorIndex + MaxValidatorsPerWithdrawalsSweep) % validatorCount
+	}
+	return withdrawals
+}
+
+// Get the amount that can be withdrawn from a validator, in gwei. The lock must be held by the caller.
+func (db *Database) getWithdrawableAmount(validator *Validator, epoch uint64) uint64 {
+	if !validator.HasExecutionWithdrawalCredentials() || validator.Balance == 0 {
+		return 0
+	}
+
+	// Full withdrawal
+	if validator.WithdrawableEpoch <= epoch {
+		return validator.Balance
+	}
+
+	// Partial withdrawal of the excess balance
+	maxEffectiveBalance := db.getMaxEffectiveBalance(validator, epoch)
+	if validator.EffectiveBalance == maxEffectiveBalance && validator.Balance > maxEffectiveBalance {
+		return validator.Balance - maxEffectiveBalance
+	}
+	return 0
+}
+
+// Debit a withdrawal from a validator's balance. The lock must be held by the caller.
+func (db *Database) withdraw(validator *Validator, amount uint64) Withdrawal {
+	withdrawal := Withdrawal{
+		Index:          db.nextWithdrawalIndex,
+		ValidatorIndex: validator.Index,
+		Address:        validator.GetWithdrawalAddress(),
+		Amount:         amount,
+	}
+	validator.Balance -= amount
+	db.nextWithdrawalIndex++
+	return withdrawal
+}
+
+// Get the total amount a validator has waiting in the pending partial withdrawals queue, in gwei.
+// The lock must be held by the caller.
+func (db *Database) getPendingBalanceToWithdraw(index uint64) uint64 {
+	total := uint64(0)
+	for _, withdrawal := range db.pendingPartialWithdrawals {
+		if withdrawal.ValidatorIndex == index {
+			total += withdrawal.Amount
+		}
+	}
+	return total
+}
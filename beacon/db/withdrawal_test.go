@@ -0,0 +1,202 @@
+package db
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nodeset-org/osha/beacon/internal/test"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithdrawalSweep(t *testing.T) {
+	config := NewDefaultConfig()
+	d := NewDatabaseWithConfig(slog.Default(), config)
+
+	// Validator with excess balance
+	partial := addTestValidator(t, d, test.Pubkey0String)
+	partial.Status = beacon.ValidatorState_ActiveOngoing
+	partial.Balance = 33e9
+
+	// Withdrawable validator
+	full := addTestValidator(t, d, test.Pubkey1String)
+	full.Status = beacon.ValidatorState_WithdrawalPossible
+	full.ExitEpoch = 0
+	full.WithdrawableEpoch = 0
+
+	// Validator with BLS credentials
+	bls := addTestValidator(t, d, test.Pubkey2String)
+	bls.Status = beacon.ValidatorState_ActiveOngoing
+	bls.WithdrawalCredentials = common.Hash{}
+	bls.Balance = 33e9
+
+	// Propose a block
+	d.CommitBlock(true)
+	block := d.GetBlockBySlot(0)
+	require.Len(t, block.Withdrawals, 2)
+	require.Equal(t, Withdrawal{
+		Index:          0,
+		ValidatorIndex: partial.Index,
+		Address:        common.HexToAddress(test.WithdrawalCredentialsString),
+		Amount:         1e9,
+	}, block.Withdrawals[0])
+	require.Equal(t, Withdrawal{
+		Index:          1,
+		ValidatorIndex: full.Index,
+		Address:        common.HexToAddress(test.WithdrawalCredentialsString),
+		Amount:         32e9,
+	}, block.Withdrawals[1])
+	require.Equal(t, uint64(32e9), partial.Balance)
+	require.Equal(t, uint64(0), full.Balance)
+	require.Equal(t, uint64(33e9), bls.Balance)
+	t.Log("Withdrawals were processed correctly")
+
+	// Nothing left to withdraw, and missed slots shouldn't withdraw anything
+	d.CommitBlock(true)
+	require.Empty(t, d.GetBlockBySlot(1).Withdrawals)
+	partial.Balance = 34e9
+	d.CommitBlock(false)
+	require.Equal(t, uint64(34e9), partial.Balance)
+	t.Log("No extra withdrawals were processed")
+}
+
+func TestFullWithdrawalPayload(t *testing.T) {
+	config := NewDefaultConfig()
+	config.ElectraForkEpoch = 0
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	validators := make([]*Validator, 20)
+	for i := range validators {
+		pubkey := beacon.ValidatorPubkey{0xbe, 0xac, byte(i)}
+		validators[i] = addTestValidator(t, d, pubkey.HexWithPrefix())
+		validators[i].Status = beacon.ValidatorState_ActiveOngoing
+		validators[i].Balance = 33e9
+	}
+
+	// Queue a partial withdrawal for the last validator, which is processed before the sweep
+	last := validators[len(validators)-1]
+	last.WithdrawalCredentials[0] = CompoundingWithdrawalPrefix
+	last.Balance = 40e9
+	pending, err := d.AddPendingPartialWithdrawal(last.Index, 5e9)
+	require.NoError(t, err)
+	pending.WithdrawableEpoch = 0
+
+	// The payload should fill partway through the sweep, and the next sweep should start after the last withdrawal
+	d.CommitBlock(true)
+	block := d.GetBlockBySlot(0)
+	require.Len(t, block.Withdrawals, int(MaxWithdrawalsPerPayload))
+	require.Equal(t, last.Index, block.Withdrawals[0].ValidatorIndex)
+	for i, withdrawal := range block.Withdrawals[1:] {
+		require.Equal(t, uint64(i), withdrawal.ValidatorIndex)
+	}
+	require.Equal(t, MaxWithdrawalsPerPayload-1, d.nextWithdrawalValidatorIndex)
+	t.Log("Sweep stopped when the payload was full")
+
+	// The rest of the validators should be swept next, and the cursor should advance by the full sweep size
+	d.CommitBlock(true)
+	block = d.GetBlockBySlot(1)
+	require.Len(t, block.Withdrawals, 4)
+	for i, withdrawal := range block.Withdrawals {
+		require.Equal(t, MaxWithdrawalsPerPayload-1+uint64(i), withdrawal.ValidatorIndex)
+	}
+	require.Equal(t, (MaxWithdrawalsPerPayload-1+MaxValidatorsPerWithdrawalsSweep)%uint64(len(validators)), d.nextWithdrawalValidatorIndex)
+	t.Log("Unvisited validators were swept in the next block")
+}
+
+func TestCompoundingWithdrawals(t *testing.T) {
+	config := NewDefaultConfig()
+	config.ElectraForkEpoch = 0
+	d := NewDatabaseWithConfig(slog.Default(), config)
+
+	// Compounding validator at its max effective balance
+	maxed := addTestValidator(t, d, test.Pubkey0String)
+	maxed.Status = beacon.ValidatorState_ActiveOngoing
+	maxed.WithdrawalCredentials[0] = CompoundingWithdrawalPrefix
+	maxed.EffectiveBalance = MaxEffectiveBalanceElectra
+	maxed.Balance = MaxEffectiveBalanceElectra + 1e9
+
+	// Compounding validator with a balance over 32 ETH, which it keeps
+	growing := addTestValidator(t, d, test.Pubkey1String)
+	growing.Status = beacon.ValidatorState_ActiveOngoing
+	growing.WithdrawalCredentials[0] = CompoundingWithdrawalPrefix
+	growing.Balance = 33e9
+
+	// Only the excess over 2048 ETH should be withdrawn
+	d.CommitBlock(true)
+	block := d.GetBlockBySlot(0)
+	require.Len(t, block.Withdrawals, 1)
+	require.Equal(t, maxed.Index, block.Withdrawals[0].ValidatorIndex)
+	require.Equal(t, uint64(1e9), block.Withdrawals[0].Amount)
+	require.Equal(t, MaxEffectiveBalanceElectra, maxed.Balance)
+	require.Equal(t, uint64(33e9), growing.Balance)
+	t.Log("Compounding withdrawals were processed correctly")
+}
+
+func TestPendingPartialWithdrawals(t *testing.T) {
+	config := NewDefaultConfig()
+	config.ElectraForkEpoch = 0
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	v := addTestValidator(t, d, test.Pubkey0String)
+	v.Status = beacon.ValidatorState_ActiveOngoing
+	v.WithdrawalCredentials[0] = CompoundingWithdrawalPrefix
+	v.Balance = 40e9
+
+	// Queue a withdrawal, then one that's capped by the remaining excess balance
+	first, err := d.AddPendingPartialWithdrawal(v.Index, 5e9)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5e9), first.Amount)
+	require.Equal(t, 1+MaxSeedLookahead+MinValidatorWithdrawabilityDelay, first.WithdrawableEpoch)
+	second, err := d.AddPendingPartialWithdrawal(v.Index, 10e9)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3e9), second.Amount)
+	_, err = d.AddPendingPartialWithdrawal(v.Index, 1e9)
+	require.Error(t, err)
+	t.Log("Partial withdrawals were queued")
+
+	// Validators with pending withdrawals can't exit
+	err = d.SubmitVoluntaryExit(&VoluntaryExit{ValidatorIndex: v.Index})
+	require.ErrorContains(t, err, "pending partial withdrawals")
+	t.Log("Voluntary exit was rejected")
+
+	// Nothing is swept until they're withdrawable
+	d.CommitBlock(true)
+	require.Empty(t, d.GetBlockBySlot(0).Withdrawals)
+	require.Len(t, d.GetPendingPartialWithdrawals(), 2)
+	first.WithdrawableEpoch = 0
+	second.WithdrawableEpoch = 0
+	d.CommitBlock(true)
+	block := d.GetBlockBySlot(1)
+	require.Len(t, block.Withdrawals, 2)
+	require.Equal(t, uint64(5e9), block.Withdrawals[0].Amount)
+	require.Equal(t, uint64(3e9), block.Withdrawals[1].Amount)
+	require.Equal(t, uint64(32e9), v.Balance)
+	require.Empty(t, d.GetPendingPartialWithdrawals())
+	t.Log("Partial withdrawals were swept")
+
+	// The queue is part of the state history
+	require.Len(t, d.GetState(0).PendingPartialWithdrawals, 2)
+	require.Empty(t, d.GetState(1).PendingPartialWithdrawals)
+	t.Log("Historical states have the correct queue")
+}
+
+func TestPendingPartialWithdrawalsBeforeElectra(t *testing.T) {
+	config := NewDefaultConfig()
+	config.ElectraForkEpoch = 1
+	d := NewDatabaseWithConfig(slog.Default(), config)
+	v := addTestValidator(t, d, test.Pubkey0String)
+	v.Status = beacon.ValidatorState_ActiveOngoing
+	v.WithdrawalCredentials[0] = CompoundingWithdrawalPrefix
+	v.Balance = 40e9
+
+	// Requests should be rejected until Electra
+	_, err := d.AddPendingPartialWithdrawal(v.Index, 5e9)
+	require.Error(t, err)
+	require.Empty(t, d.GetPendingPartialWithdrawals())
+	t.Log("Withdrawal was rejected before Electra")
+
+	commitEpochs(d, 1)
+	v.Balance = 40e9
+	_, err = d.AddPendingPartialWithdrawal(v.Index, 5e9)
+	require.NoError(t, err)
+	t.Log("Withdrawal was queued after Electra")
+}
@@ -7,12 +7,19 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/nodeset-org/osha/beacon/db"
 	"github.com/nodeset-org/osha/beacon/internal/test"
+	"github.com/nodeset-org/osha/keys"
 	"github.com/rocket-pool/node-manager-core/beacon"
 	"github.com/rocket-pool/node-manager-core/node/validator"
 	"github.com/stretchr/testify/require"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
 )
 
 func ProvisionDatabaseForTesting(t *testing.T, logger *slog.Logger) *db.Database {
+	return ProvisionDatabaseWithConfigForTesting(t, logger, db.NewDefaultConfig())
+}
+
+// Provision a database like ProvisionDatabaseForTesting, using the given config
+func ProvisionDatabaseWithConfigForTesting(t *testing.T, logger *slog.Logger, config *db.Config) *db.Database {
 	// Prep the pubkeys and creds
 	pubkey0, err := beacon.HexToValidatorPubkey(test.Pubkey0String)
 	if err != nil {
@@ -31,7 +38,7 @@ func ProvisionDatabaseForTesting(t *testing.T, logger *slog.Logger) *db.Database
 	t.Log("Prepped pubkeys and creds")
 
 	// Create a new database
-	d := db.NewDatabase(logger, 0)
+	d := db.NewDatabaseWithConfig(logger, config)
 	v0, err := d.AddValidator(pubkey0, withdrawalCreds)
 	if err != nil {
 		t.Fatalf("Error adding validator [%s]: %v", pubkey0.HexWithPrefix(), err)
@@ -52,3 +59,48 @@ func ProvisionDatabaseForTesting(t *testing.T, logger *slog.Logger) *db.Database
 	t.Log("Added validators to database")
 	return d
 }
+
+// Add a validator backed by a real BLS key, derived from the default test mnemonic at the given index, and make it active
+func AddActiveValidatorWithKeyForTesting(t *testing.T, d *db.Database, keyIndex uint) (*db.Validator, *eth2types.BLSPrivateKey) {
+	keygen, err := keys.NewKeyGeneratorWithDefaults()
+	if err != nil {
+		t.Fatalf("Error creating key generator: %v", err)
+	}
+	key, err := keygen.GetBlsPrivateKey(keyIndex)
+	if err != nil {
+		t.Fatalf("Error getting BLS key %d: %v", keyIndex, err)
+	}
+	pubkey := beacon.ValidatorPubkey(key.PublicKey().Marshal())
+	withdrawalCreds := validator.GetWithdrawalCredsFromAddress(common.HexToAddress(test.WithdrawalCredentialsString))
+	v, err := d.AddValidator(pubkey, withdrawalCreds)
+	if err != nil {
+		t.Fatalf("Error adding validator [%s]: %v", pubkey.HexWithPrefix(), err)
+	}
+	v.Status = beacon.ValidatorState_ActiveOngoing
+	v.ActivationEligibilityEpoch = 0
+	v.ActivationEpoch = 0
+	t.Logf("Added active validator %d with BLS key %d", v.Index, keyIndex)
+	return v, key
+}
+
+// Create a deposit for a BLS key derived from the default test mnemonic, signed for the given config
+func CreateDepositForTesting(t *testing.T, config *db.Config, keyIndex uint, withdrawalCreds common.Hash, amount uint64) *db.Deposit {
+	keygen, err := keys.NewKeyGeneratorWithDefaults()
+	if err != nil {
+		t.Fatalf("Error creating key generator: %v", err)
+	}
+	key, err := keygen.GetBlsPrivateKey(keyIndex)
+	if err != nil {
+		t.Fatalf("Error getting BLS key %d: %v", keyIndex, err)
+	}
+	data, err := validator.GetDepositData(key, withdrawalCreds, config.GenesisForkVersion, amount, "holesky")
+	if err != nil {
+		t.Fatalf("Error creating deposit data: %v", err)
+	}
+	return &db.Deposit{
+		Pubkey:                beacon.ValidatorPubkey(data.PublicKey),
+		WithdrawalCredentials: withdrawalCreds,
+		Amount:                amount,
+		Signature:             beacon.ValidatorSignature(data.Signature),
+	}
+}
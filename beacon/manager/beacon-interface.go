@@ -2,17 +2,61 @@ package manager
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/db"
+	"github.com/rocket-pool/node-manager-core/beacon"
 	"github.com/rocket-pool/node-manager-core/beacon/client"
 	"github.com/rocket-pool/node-manager-core/utils"
 )
 
-// Temp until finality is implemented
+func (m *BeaconMockManager) Beacon_Block(ctx context.Context, blockId string) (client.BeaconBlockResponse, bool, error) {
+	block, err := m.GetBlock(blockId)
+	if err != nil {
+		return client.BeaconBlockResponse{}, false, err
+	}
+	if block == nil {
+		return client.BeaconBlockResponse{}, false, nil
+	}
+
+	response := client.BeaconBlockResponse{}
+	err = convertResponse(m.GetBlockResponse(block), &response)
+	if err != nil {
+		return client.BeaconBlockResponse{}, false, err
+	}
+	return response, true, nil
+}
+
+func (m *BeaconMockManager) Beacon_Header(ctx context.Context, blockId string) (client.BeaconBlockHeaderResponse, bool, error) {
+	block, err := m.GetBlock(blockId)
+	if err != nil {
+		return client.BeaconBlockHeaderResponse{}, false, err
+	}
+	if block == nil {
+		return client.BeaconBlockHeaderResponse{}, false, nil
+	}
+
+	response := client.BeaconBlockHeaderResponse{}
+	err = convertResponse(m.GetBlockHeaderResponse(block), &response)
+	if err != nil {
+		return client.BeaconBlockHeaderResponse{}, false, err
+	}
+	return response, true, nil
+}
+
 func (m *BeaconMockManager) Beacon_FinalityCheckpoints(ctx context.Context, stateId string) (client.FinalityCheckpointsResponse, error) {
+	state, err := m.getExistingState(stateId)
+	if err != nil {
+		return client.FinalityCheckpointsResponse{}, err
+	}
 	response := client.FinalityCheckpointsResponse{}
-	response.Data.Finalized.Epoch = utils.Uinteger(m.database.GetCurrentSlot())
-	response.Data.CurrentJustified.Epoch = utils.Uinteger(m.database.GetCurrentSlot())
-	response.Data.PreviousJustified.Epoch = utils.Uinteger(m.database.GetCurrentSlot())
+	err = convertResponse(m.GetFinalityCheckpointsResponse(state), &response)
+	if err != nil {
+		return client.FinalityCheckpointsResponse{}, err
+	}
 	return response, nil
 }
 
@@ -25,38 +69,56 @@ func (m *BeaconMockManager) Beacon_Genesis(ctx context.Context) (client.GenesisR
 }
 
 func (m *BeaconMockManager) Beacon_Validators(ctx context.Context, stateId string, ids []string) (client.ValidatorsResponse, error) {
-	// Get the validators
-	validators, err := m.GetValidators(ids)
+	state, err := m.getExistingState(stateId)
 	if err != nil {
 		return client.ValidatorsResponse{}, err
 	}
-
-	// Write the response
-	validatorMetas := make([]client.Validator, len(validators))
-	for i, validator := range validators {
-		validatorMetas[i] = validator.GetValidatorMeta()
-	}
-	response := client.ValidatorsResponse{
-		Data: validatorMetas,
-	}
-	return response, nil
+	return m.GetValidatorsResponse(state, ids, nil)
 }
 
 func (m *BeaconMockManager) Beacon_PendingDeposits(ctx context.Context, stateID string) (client.PendingDepositsResponse, error) {
-	// Get the pending deposits
-	pendingDeposits := m.GetPendingDeposits()
+	state, err := m.getExistingState(stateID)
+	if err != nil {
+		return client.PendingDepositsResponse{}, err
+	}
+	return m.GetPendingDepositsResponse(state), nil
+}
 
-	// Convert the deposit data to the native format
-	nativeDeposits := make([]client.PendingDeposit, len(pendingDeposits))
-	for i, deposit := range pendingDeposits {
-		nativeDeposits[i] = deposit.ConvertToNativeFormat()
+func (m *BeaconMockManager) Beacon_BlsToExecutionChanges_Post(ctx context.Context, request client.BLSToExecutionChangeRequest) error {
+	index, err := strconv.ParseUint(request.Message.ValidatorIndex, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid validator index [%s]", request.Message.ValidatorIndex)
+	}
+	if len(request.Message.FromBLSPubkey) != beacon.ValidatorPubkeyLength {
+		return fmt.Errorf("invalid BLS pubkey length %d", len(request.Message.FromBLSPubkey))
+	}
+	if len(request.Message.ToExecutionAddress) != common.AddressLength {
+		return fmt.Errorf("invalid execution address length %d", len(request.Message.ToExecutionAddress))
 	}
+	if len(request.Signature) != beacon.ValidatorSignatureLength {
+		return fmt.Errorf("invalid signature length %d", len(request.Signature))
+	}
+	return m.SubmitBlsToExecutionChange(&db.BlsToExecutionChange{
+		ValidatorIndex:     index,
+		FromBlsPubkey:      beacon.ValidatorPubkey(request.Message.FromBLSPubkey),
+		ToExecutionAddress: common.Address(request.Message.ToExecutionAddress),
+		Signature:          beacon.ValidatorSignature(request.Signature),
+	})
+}
 
-	// Write the response
-	response := client.PendingDepositsResponse{
-		Data: nativeDeposits,
+func (m *BeaconMockManager) Beacon_VoluntaryExits_Post(ctx context.Context, request client.VoluntaryExitRequest) error {
+	index, err := strconv.ParseUint(request.Message.ValidatorIndex, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid validator index [%s]", request.Message.ValidatorIndex)
 	}
-	return response, nil
+	if len(request.Signature) != beacon.ValidatorSignatureLength {
+		return fmt.Errorf("invalid signature length %d", len(request.Signature))
+	}
+	return m.SubmitVoluntaryExit(&db.VoluntaryExit{
+		Epoch:          uint64(request.Message.Epoch),
+		ValidatorIndex: index,
+		Signature:      beacon.ValidatorSignature(request.Signature),
+	})
 }
 
 func (m *BeaconMockManager) Config_DepositContract(ctx context.Context) (client.Eth2DepositContractResponse, error) {
@@ -68,10 +130,10 @@ func (m *BeaconMockManager) Config_DepositContract(ctx context.Context) (client.
 
 func (m *BeaconMockManager) Config_Spec(ctx context.Context) (client.Eth2ConfigResponse, error) {
 	response := client.Eth2ConfigResponse{}
-	response.Data.SecondsPerSlot = utils.Uinteger(m.config.SecondsPerSlot)
-	response.Data.SlotsPerEpoch = utils.Uinteger(m.config.SlotsPerEpoch)
-	response.Data.EpochsPerSyncCommitteePeriod = utils.Uinteger(m.config.EpochsPerSyncCommitteePeriod)
-	response.Data.CapellaForkVersion = m.config.CapellaForkVersion
+	err := convertResponse(m.GetConfigSpecResponse(), &response)
+	if err != nil {
+		return client.Eth2ConfigResponse{}, err
+	}
 	return response, nil
 }
 
@@ -87,3 +149,35 @@ func (m *BeaconMockManager) Node_Syncing(ctx context.Context) (client.SyncStatus
 	response.Data.SyncDistance = utils.Uinteger(highestSlot - currentSlot)
 	return response, nil
 }
+
+func (m *BeaconMockManager) Validator_DutiesProposer(ctx context.Context, indices []string, epoch uint64) (client.ProposerDutiesResponse, error) {
+	response := client.ProposerDutiesResponse{}
+	err := convertResponse(m.GetProposerDutiesResponse(epoch), &response)
+	if err != nil {
+		return client.ProposerDutiesResponse{}, err
+	}
+	return response, nil
+}
+
+func (m *BeaconMockManager) Validator_DutiesSync_Post(ctx context.Context, indices []string, epoch uint64) (client.SyncDutiesResponse, error) {
+	duties, err := m.GetSyncDutiesResponse(indices, epoch)
+	if err != nil {
+		return client.SyncDutiesResponse{}, err
+	}
+	return client.SyncDutiesResponse{
+		Data: duties.Data,
+	}, nil
+}
+
+// Convert one of the mock's API responses into the equivalent client type by round-tripping it through JSON
+func convertResponse(source any, target any) error {
+	bytes, err := json.Marshal(source)
+	if err != nil {
+		return fmt.Errorf("error serializing response: %w", err)
+	}
+	err = json.Unmarshal(bytes, target)
+	if err != nil {
+		return fmt.Errorf("error converting response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package manager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/beacon/client"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+// Get a block by its ID, which can be "head", "genesis", "finalized", a slot number, or a 0x-prefixed block root.
+// Returns nil if the block doesn't exist.
+func (m *BeaconMockManager) GetBlock(id string) (*db.Block, error) {
+	switch id {
+	case "head":
+		return m.database.GetHeadBlock(), nil
+	case "genesis":
+		return m.database.GetBlockBySlot(0), nil
+	case "finalized":
+		root := m.database.GetFinalityCheckpoints().Finalized.Root
+		if root == (common.Hash{}) {
+			return m.database.GetBlockBySlot(0), nil
+		}
+		return m.database.GetBlockByRoot(root), nil
+	}
+
+	if strings.HasPrefix(id, "0x") {
+		if len(id) != common.HashLength*2+2 {
+			return nil, fmt.Errorf("invalid block root [%s]", id)
+		}
+		return m.database.GetBlockByRoot(common.HexToHash(id)), nil
+	}
+	slot, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block ID [%s]", id)
+	}
+	return m.database.GetBlockBySlot(slot), nil
+}
+
+// Get the block proposed in the given slot. Returns nil if the slot was missed or hasn't been reached yet.
+func (m *BeaconMockManager) GetBlockBySlot(slot uint64) *db.Block {
+	return m.database.GetBlockBySlot(slot)
+}
+
+// Set the details of the Execution block included in the Beacon block for the given slot
+func (m *BeaconMockManager) SetExecutionBlockDetails(slot uint64, number uint64, hash common.Hash, timestamp uint64, feeRecipient common.Address) error {
+	return m.database.SetExecutionBlockDetails(slot, number, hash, timestamp, feeRecipient)
+}
+
+// Set the number of the latest block on the Execution chain, so the next proposed block is linked to the one after it
+func (m *BeaconMockManager) SetLatestExecutionBlockNumber(number uint64) {
+	m.database.SetLatestExecutionBlockNumber(number)
+}
+
+// Create the API response for a block's header
+func (m *BeaconMockManager) GetBlockHeaderResponse(block *db.Block) api.BlockHeaderResponse {
+	response := api.BlockHeaderResponse{}
+	response.Finalized = m.isBlockFinalized(block)
+	response.Data.Root = block.Root
+	response.Data.Canonical = true
+	response.Data.Header.Message = api.BlockHeaderMessage{
+		Slot:          utils.Uinteger(block.Slot),
+		ProposerIndex: strconv.FormatUint(block.ProposerIndex, 10),
+		ParentRoot:    block.ParentRoot,
+		StateRoot:     block.StateRoot,
+		BodyRoot:      block.BodyRoot,
+	}
+	response.Data.Header.Signature = make(utils.ByteArray, beacon.ValidatorSignatureLength)
+	return response
+}
+
+// Create the API response for a full block
+func (m *BeaconMockManager) GetBlockResponse(block *db.Block) api.BlockResponse {
+	response := api.BlockResponse{}
+	response.Version = m.config.GetForkName(block.Slot / m.config.SlotsPerEpoch)
+	response.Finalized = m.isBlockFinalized(block)
+	response.Data.Message.Slot = utils.Uinteger(block.Slot)
+	response.Data.Message.ProposerIndex = strconv.FormatUint(block.ProposerIndex, 10)
+	response.Data.Message.ParentRoot = block.ParentRoot
+	response.Data.Message.StateRoot = block.StateRoot
+	response.Data.Message.Body.Eth1Data = api.Eth1Data{} // Blocks keep the genesis state's empty Eth1 data vote
+	response.Data.Message.Body.Attestations = []client.Attestation{}
+	response.Data.Message.Body.ExecutionPayload = api.ExecutionPayload{
+		FeeRecipient: block.FeeRecipient,
+		BlockNumber:  utils.Uinteger(block.ExecutionBlockNumber),
+		BlockHash:    block.ExecutionBlockHash,
+		Timestamp:    utils.Uinteger(m.getExecutionBlockTimestamp(block)),
+		Withdrawals:  make([]api.Withdrawal, len(block.Withdrawals)),
+	}
+	for i, withdrawal := range block.Withdrawals {
+		response.Data.Message.Body.ExecutionPayload.Withdrawals[i] = api.Withdrawal{
+			Index:          utils.Uinteger(withdrawal.Index),
+			ValidatorIndex: utils.Uinteger(withdrawal.ValidatorIndex),
+			Address:        withdrawal.Address,
+			Amount:         utils.Uinteger(withdrawal.Amount),
+		}
+	}
+	response.Data.Signature = make(utils.ByteArray, beacon.ValidatorSignatureLength)
+	return response
+}
+
+// Get the timestamp of the Execution block included in a block's payload. If it isn't known, the start time of the
+// block's slot is used instead.
+func (m *BeaconMockManager) getExecutionBlockTimestamp(block *db.Block) uint64 {
+	if block.ExecutionBlockTimestamp != 0 {
+		return block.ExecutionBlockTimestamp
+	}
+	return uint64(m.config.GenesisTime.Unix()) + block.Slot*m.config.SecondsPerSlot
+}
+
+// Check if a block is at or before the finalized checkpoint
+func (m *BeaconMockManager) isBlockFinalized(block *db.Block) bool {
+	finalizedSlot := m.database.GetFinalityCheckpoints().Finalized.Epoch * m.config.SlotsPerEpoch
+	return block.Slot <= finalizedSlot
+}
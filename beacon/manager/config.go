@@ -0,0 +1,152 @@
+package manager
+
+import (
+	"strconv"
+
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	"github.com/rocket-pool/node-manager-core/utils"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// Preset values from the mainnet spec that the mock doesn't model, but clients expect to find in the config spec
+var staticSpecValues = map[string]uint64{
+	"SECONDS_PER_ETH1_BLOCK":          14,
+	"ETH1_FOLLOW_DISTANCE":            2048,
+	"EPOCHS_PER_ETH1_VOTING_PERIOD":   64,
+	"SLOTS_PER_HISTORICAL_ROOT":       8192,
+	"MAX_COMMITTEES_PER_SLOT":         64,
+	"TARGET_COMMITTEE_SIZE":           128,
+	"MAX_VALIDATORS_PER_COMMITTEE":    2048,
+	"MIN_ATTESTATION_INCLUSION_DELAY": 1,
+	"MAX_PROPOSER_SLASHINGS":          16,
+	"MAX_ATTESTER_SLASHINGS":          2,
+	"MAX_DEPOSITS":                    16,
+	"MAX_VOLUNTARY_EXITS":             16,
+	"MAX_BLS_TO_EXECUTION_CHANGES":    16,
+	"MAX_BLOBS_PER_BLOCK":             6,
+}
+
+// Signature domains, as reported in the config spec
+var specDomains = map[string]eth2types.DomainType{
+	"DOMAIN_BEACON_PROPOSER":                eth2types.DomainBeaconProposer,
+	"DOMAIN_BEACON_ATTESTER":                eth2types.DomainBeaconAttester,
+	"DOMAIN_RANDAO":                         eth2types.DomainRANDAO,
+	"DOMAIN_DEPOSIT":                        eth2types.DomainDeposit,
+	"DOMAIN_VOLUNTARY_EXIT":                 eth2types.DomainVoluntaryExit,
+	"DOMAIN_SELECTION_PROOF":                eth2types.DomainSelectionProof,
+	"DOMAIN_AGGREGATE_AND_PROOF":            eth2types.DomainAggregateAndProof,
+	"DOMAIN_SYNC_COMMITTEE":                 eth2types.DomainSyncCommittee,
+	"DOMAIN_SYNC_COMMITTEE_SELECTION_PROOF": eth2types.DomainSyncCommitteeSelectionProof,
+	"DOMAIN_CONTRIBUTION_AND_PROOF":         eth2types.DomainContributionAndProof,
+	"DOMAIN_BLS_TO_EXECUTION_CHANGE":        eth2types.DomainBlsToExecutionChange,
+}
+
+// Get the config spec response, with every value formatted as a string the way real clients report them
+func (m *BeaconMockManager) GetConfigSpecResponse() api.ConfigSpecResponse {
+	spec := map[string]string{
+		// Config
+		"PRESET_BASE":                               "mainnet",
+		"CONFIG_NAME":                               "osha",
+		"MIN_GENESIS_TIME":                          formatSpecUint(uint64(m.config.GenesisTime.Unix())),
+		"GENESIS_DELAY":                             "0",
+		"GENESIS_FORK_VERSION":                      utils.EncodeHexWithPrefix(m.config.GenesisForkVersion),
+		"ALTAIR_FORK_VERSION":                       utils.EncodeHexWithPrefix(m.config.AltairForkVersion),
+		"ALTAIR_FORK_EPOCH":                         formatSpecUint(m.config.AltairForkEpoch),
+		"BELLATRIX_FORK_VERSION":                    utils.EncodeHexWithPrefix(m.config.BellatrixForkVersion),
+		"BELLATRIX_FORK_EPOCH":                      formatSpecUint(m.config.BellatrixForkEpoch),
+		"CAPELLA_FORK_VERSION":                      utils.EncodeHexWithPrefix(m.config.CapellaForkVersion),
+		"CAPELLA_FORK_EPOCH":                        formatSpecUint(m.config.CapellaForkEpoch),
+		"DENEB_FORK_VERSION":                        utils.EncodeHexWithPrefix(m.config.DenebForkVersion),
+		"DENEB_FORK_EPOCH":                          formatSpecUint(m.config.DenebForkEpoch),
+		"ELECTRA_FORK_VERSION":                      utils.EncodeHexWithPrefix(m.config.ElectraForkVersion),
+		"ELECTRA_FORK_EPOCH":                        formatSpecUint(m.config.ElectraForkEpoch),
+		"SECONDS_PER_SLOT":                          formatSpecUint(m.config.SecondsPerSlot),
+		"MIN_VALIDATOR_WITHDRAWABILITY_DELAY":       formatSpecUint(db.MinValidatorWithdrawabilityDelay),
+		"SHARD_COMMITTEE_PERIOD":                    formatSpecUint(m.config.ShardCommitteePeriod),
+		"CHURN_LIMIT_QUOTIENT":                      formatSpecUint(db.ChurnLimitQuotient),
+		"MIN_PER_EPOCH_CHURN_LIMIT":                 formatSpecUint(db.MinPerEpochChurnLimit),
+		"MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT":      formatSpecUint(db.MaxPerEpochActivationChurnLimit),
+		"MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA":         formatSpecUint(db.MinPerEpochChurnLimitElectra),
+		"MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT": formatSpecUint(db.MaxPerEpochActivationExitChurnLimit),
+		"DEPOSIT_CHAIN_ID":                          formatSpecUint(m.config.ChainID),
+		"DEPOSIT_NETWORK_ID":                        formatSpecUint(m.config.ChainID),
+		"DEPOSIT_CONTRACT_ADDRESS":                  m.config.DepositContract.Hex(),
+
+		// Preset
+		"SLOTS_PER_EPOCH":                            formatSpecUint(m.config.SlotsPerEpoch),
+		"EPOCHS_PER_SYNC_COMMITTEE_PERIOD":           formatSpecUint(m.config.EpochsPerSyncCommitteePeriod),
+		"SYNC_COMMITTEE_SIZE":                        formatSpecUint(m.config.SyncCommitteeSize),
+		"MAX_SEED_LOOKAHEAD":                         formatSpecUint(db.MaxSeedLookahead),
+		"HYSTERESIS_QUOTIENT":                        formatSpecUint(db.HysteresisQuotient),
+		"HYSTERESIS_DOWNWARD_MULTIPLIER":             formatSpecUint(db.HysteresisDownwardMultiplier),
+		"HYSTERESIS_UPWARD_MULTIPLIER":               formatSpecUint(db.HysteresisUpwardMultiplier),
+		"MIN_ACTIVATION_BALANCE":                     formatSpecUint(db.MinActivationBalance),
+		"MAX_EFFECTIVE_BALANCE":                      formatSpecUint(db.MaxEffectiveBalance),
+		"MAX_EFFECTIVE_BALANCE_ELECTRA":              formatSpecUint(db.MaxEffectiveBalanceElectra),
+		"EFFECTIVE_BALANCE_INCREMENT":                formatSpecUint(db.EffectiveBalanceIncrement),
+		"MAX_WITHDRAWALS_PER_PAYLOAD":                formatSpecUint(db.MaxWithdrawalsPerPayload),
+		"MAX_VALIDATORS_PER_WITHDRAWALS_SWEEP":       formatSpecUint(db.MaxValidatorsPerWithdrawalsSweep),
+		"MAX_PENDING_PARTIALS_PER_WITHDRAWALS_SWEEP": formatSpecUint(db.MaxPendingPartialsPerWithdrawalsSweep),
+		"MAX_PENDING_DEPOSITS_PER_EPOCH":             formatSpecUint(m.config.MaxPendingDepositsPerEpoch),
+		"EPOCHS_PER_SLASHINGS_VECTOR":                formatSpecUint(db.EpochsPerSlashingsVector),
+		"MIN_SLASHING_PENALTY_QUOTIENT":              formatSpecUint(db.MinSlashingPenaltyQuotient),
+		"MIN_SLASHING_PENALTY_QUOTIENT_ALTAIR":       formatSpecUint(db.MinSlashingPenaltyQuotientAltair),
+		"MIN_SLASHING_PENALTY_QUOTIENT_BELLATRIX":    formatSpecUint(db.MinSlashingPenaltyQuotientBellatrix),
+		"MIN_SLASHING_PENALTY_QUOTIENT_ELECTRA":      formatSpecUint(db.MinSlashingPenaltyQuotientElectra),
+		"PROPORTIONAL_SLASHING_MULTIPLIER":           formatSpecUint(db.ProportionalSlashingMultiplier),
+		"PROPORTIONAL_SLASHING_MULTIPLIER_ALTAIR":    formatSpecUint(db.ProportionalSlashingMultiplierAltair),
+		"PROPORTIONAL_SLASHING_MULTIPLIER_BELLATRIX": formatSpecUint(db.ProportionalSlashingMultiplierBellatrix),
+		"WHISTLEBLOWER_REWARD_QUOTIENT":              formatSpecUint(db.WhistleblowerRewardQuotient),
+		"WHISTLEBLOWER_REWARD_QUOTIENT_ELECTRA":      formatSpecUint(db.WhistleblowerRewardQuotientElectra),
+
+		// Constants
+		"FAR_FUTURE_EPOCH":               formatSpecUint(db.FarFutureEpoch),
+		"BLS_WITHDRAWAL_PREFIX":          utils.EncodeHexWithPrefix([]byte{db.BlsWithdrawalPrefix}),
+		"ETH1_ADDRESS_WITHDRAWAL_PREFIX": utils.EncodeHexWithPrefix([]byte{db.Eth1AddressWithdrawalPrefix}),
+		"COMPOUNDING_WITHDRAWAL_PREFIX":  utils.EncodeHexWithPrefix([]byte{db.CompoundingWithdrawalPrefix}),
+	}
+	for key, value := range staticSpecValues {
+		spec[key] = formatSpecUint(value)
+	}
+	for key, domain := range specDomains {
+		spec[key] = utils.EncodeHexWithPrefix(domain[:])
+	}
+	return api.ConfigSpecResponse{
+		Data: spec,
+	}
+}
+
+// Get the fork schedule response
+func (m *BeaconMockManager) GetForkScheduleResponse() api.ForkScheduleResponse {
+	schedule := m.config.GetForkSchedule()
+	response := api.ForkScheduleResponse{
+		Data: make([]api.Fork, len(schedule)),
+	}
+	for i, fork := range schedule {
+		response.Data[i] = getForkData(fork)
+	}
+	return response
+}
+
+// Get the fork response for a state
+func (m *BeaconMockManager) GetStateForkResponse(state *db.State) api.StateForkResponse {
+	return api.StateForkResponse{
+		Finalized: m.isStateFinalized(state),
+		Data:      getForkData(m.config.GetFork(state.Slot / m.config.SlotsPerEpoch)),
+	}
+}
+
+// Convert a fork into its API format
+func getForkData(fork db.Fork) api.Fork {
+	return api.Fork{
+		PreviousVersion: fork.PreviousVersion,
+		CurrentVersion:  fork.CurrentVersion,
+		Epoch:           utils.Uinteger(fork.Epoch),
+	}
+}
+
+// Format an unsigned integer for the config spec
+func formatSpecUint(value uint64) string {
+	return strconv.FormatUint(value, 10)
+}
@@ -0,0 +1,94 @@
+package manager
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	"github.com/rocket-pool/node-manager-core/beacon/client"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+// Force a validator to be the proposer for the given slot
+func (m *BeaconMockManager) SetProposer(slot uint64, index uint64) error {
+	return m.database.SetProposer(slot, index)
+}
+
+// Force a validator into the sync committee for the current period
+func (m *BeaconMockManager) AddToSyncCommittee(index uint64) error {
+	return m.database.AddToSyncCommittee(index)
+}
+
+// Create the API response for the proposer duties of every slot in an epoch
+func (m *BeaconMockManager) GetProposerDutiesResponse(epoch uint64) api.ProposerDutiesResponse {
+	duties := m.database.GetProposerDuties(epoch)
+	response := api.ProposerDutiesResponse{
+		DependentRoot: m.getDependentRoot(epoch),
+		Data:          make([]api.ProposerDuty, len(duties)),
+	}
+	for i, proposerDuty := range duties {
+		duty := api.ProposerDuty{
+			ValidatorIndex: strconv.FormatUint(proposerDuty.ValidatorIndex, 10),
+			Slot:           utils.Uinteger(proposerDuty.Slot),
+		}
+		validator := m.database.GetValidatorByIndex(uint(proposerDuty.ValidatorIndex))
+		if validator != nil {
+			duty.Pubkey = validator.Pubkey[:]
+		}
+		response.Data[i] = duty
+	}
+	return response
+}
+
+// Create the API response for the sync committee duties of the given validators in an epoch
+func (m *BeaconMockManager) GetSyncDutiesResponse(ids []string, epoch uint64) (api.SyncDutiesResponse, error) {
+	// Get the positions of each validator in the committee
+	committee := m.database.GetSyncCommittee(epoch)
+	positions := map[uint64][]utils.Uinteger{}
+	for position, index := range committee {
+		positions[index] = append(positions[index], utils.Uinteger(position))
+	}
+
+	response := api.SyncDutiesResponse{
+		Data: []client.SyncDuty{},
+	}
+	for _, id := range ids {
+		index, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return api.SyncDutiesResponse{}, fmt.Errorf("invalid validator index [%s]", id)
+		}
+		validatorPositions, exists := positions[index]
+		if !exists {
+			continue
+		}
+		validator := m.database.GetValidatorByIndex(uint(index))
+		if validator == nil {
+			continue
+		}
+		response.Data = append(response.Data, client.SyncDuty{
+			Pubkey:               validator.Pubkey[:],
+			ValidatorIndex:       id,
+			SyncCommitteeIndices: validatorPositions,
+		})
+	}
+	return response, nil
+}
+
+// Get the root of the block the duties for an epoch depend on, which is the latest block at or before the last slot of
+// the previous epoch. The first epoch, and any epochs before a block was proposed, depend on the genesis block.
+// Returns an empty hash if there isn't a genesis block either.
+func (m *BeaconMockManager) getDependentRoot(epoch uint64) common.Hash {
+	var block *db.Block
+	if epoch > 0 {
+		block = m.database.GetLatestBlock(epoch*m.config.SlotsPerEpoch - 1)
+	}
+	if block == nil {
+		block = m.database.GetBlockBySlot(0)
+	}
+	if block == nil {
+		return common.Hash{}
+	}
+	return block.Root
+}
@@ -0,0 +1,225 @@
+package manager
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	"github.com/rocket-pool/node-manager-core/beacon/client"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	// The number of events that can be queued for a subscriber before it's considered too slow and disconnected
+	eventBufferSize int = 64
+)
+
+// The topics that can be subscribed to
+var eventTopics = map[string]bool{
+	api.HeadTopic:                true,
+	api.BlockTopic:               true,
+	api.FinalizedCheckpointTopic: true,
+	api.ChainReorgTopic:          true,
+	api.VoluntaryExitTopic:       true,
+}
+
+// An event published to subscribers of its topic
+type Event struct {
+	Topic string
+	Data  any
+}
+
+// A subscription to one or more event topics
+type EventSubscription struct {
+	hub    *eventHub
+	topics map[string]bool
+	events chan Event
+}
+
+// Get the channel events are delivered on. It's closed when the subscription ends, either because Close was called,
+// the subscriber fell too far behind, or the manager closed all subscriptions.
+func (s *EventSubscription) Events() <-chan Event {
+	return s.events
+}
+
+// Stop receiving events
+func (s *EventSubscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// Fans events out to every subscriber of their topic
+type eventHub struct {
+	subscriptions map[*EventSubscription]struct{}
+	logger        *slog.Logger
+	lock          sync.Mutex
+}
+
+// Create a new event hub
+func newEventHub(logger *slog.Logger) *eventHub {
+	return &eventHub{
+		subscriptions: map[*EventSubscription]struct{}{},
+		logger:        logger,
+	}
+}
+
+// Add a subscription for the given topics
+func (h *eventHub) subscribe(topics []string) *EventSubscription {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	subscription := &EventSubscription{
+		hub:    h,
+		topics: map[string]bool{},
+		events: make(chan Event, eventBufferSize),
+	}
+	for _, topic := range topics {
+		subscription.topics[topic] = true
+	}
+	h.subscriptions[subscription] = struct{}{}
+	return subscription
+}
+
+// Remove a subscription and close its channel. Does nothing if it was already removed.
+func (h *eventHub) unsubscribe(subscription *EventSubscription) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.remove(subscription)
+}
+
+// Send an event to every subscriber of its topic. Subscribers that can't keep up are disconnected instead of blocking
+// the publisher.
+func (h *eventHub) publish(topic string, data any) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	event := Event{
+		Topic: topic,
+		Data:  data,
+	}
+	for subscription := range h.subscriptions {
+		if !subscription.topics[topic] {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			h.logger.Warn("Disconnecting slow event subscriber", "topic", topic)
+			h.remove(subscription)
+		}
+	}
+}
+
+// Remove every subscription
+func (h *eventHub) closeAll() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for subscription := range h.subscriptions {
+		h.remove(subscription)
+	}
+}
+
+// Remove a subscription and close its channel. The lock must be held by the caller.
+func (h *eventHub) remove(subscription *EventSubscription) {
+	_, exists := h.subscriptions[subscription]
+	if !exists {
+		return
+	}
+	delete(h.subscriptions, subscription)
+	close(subscription.events)
+}
+
+// Subscribe to events on the given topics
+func (m *BeaconMockManager) SubscribeEvents(topics []string) (*EventSubscription, error) {
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("no topics provided")
+	}
+	for _, topic := range topics {
+		if !eventTopics[topic] {
+			return nil, fmt.Errorf("invalid topic [%s]", topic)
+		}
+	}
+	return m.events.subscribe(topics), nil
+}
+
+// Close every event subscription, ending their streams
+func (m *BeaconMockManager) CloseEventSubscriptions() {
+	m.events.closeAll()
+}
+
+// Publish the head and block events for a newly proposed block
+func (m *BeaconMockManager) publishBlockEvents(block *db.Block) {
+	m.events.publish(api.BlockTopic, api.BlockEvent{
+		Slot:  utils.Uinteger(block.Slot),
+		Block: block.Root,
+	})
+
+	epoch := block.Slot / m.config.SlotsPerEpoch
+	// The first epoch has no previous epoch, so both of its dependent roots are the genesis block
+	m.events.publish(api.HeadTopic, api.HeadEvent{
+		Slot:                      utils.Uinteger(block.Slot),
+		Block:                     block.Root,
+		State:                     block.StateRoot,
+		EpochTransition:           block.Slot%m.config.SlotsPerEpoch == 0,
+		PreviousDutyDependentRoot: m.getDependentRoot(max(epoch, 1) - 1),
+		CurrentDutyDependentRoot:  m.getDependentRoot(epoch),
+	})
+}
+
+// Publish a finalized checkpoint event if the finalized checkpoint has moved
+func (m *BeaconMockManager) publishFinalityEvent(previous db.Checkpoint) {
+	finalized := m.database.GetFinalityCheckpoints().Finalized
+	if finalized == previous {
+		return
+	}
+
+	event := api.FinalizedCheckpointEvent{
+		Block: finalized.Root,
+		Epoch: utils.Uinteger(finalized.Epoch),
+	}
+	if finalized.Root != (common.Hash{}) {
+		block := m.database.GetBlockByRoot(finalized.Root)
+		if block != nil {
+			event.State = block.StateRoot
+		}
+	}
+	m.events.publish(api.FinalizedCheckpointTopic, event)
+}
+
+// Publish a chain reorg event, comparing the old head with the new one
+func (m *BeaconMockManager) publishReorgEvent(oldHead *db.Block, depth uint64) {
+	slot := m.database.GetCurrentSlot() - 1
+	event := api.ChainReorgEvent{
+		Slot:  utils.Uinteger(slot),
+		Depth: utils.Uinteger(depth),
+		Epoch: utils.Uinteger(slot / m.config.SlotsPerEpoch),
+	}
+	if oldHead != nil {
+		event.OldHeadBlock = oldHead.Root
+		event.OldHeadState = oldHead.StateRoot
+	}
+	newHead := m.database.GetHeadBlock()
+	if newHead != nil {
+		event.Slot = utils.Uinteger(newHead.Slot)
+		event.Epoch = utils.Uinteger(newHead.Slot / m.config.SlotsPerEpoch)
+		event.NewHeadBlock = newHead.Root
+		event.NewHeadState = newHead.StateRoot
+	}
+	m.events.publish(api.ChainReorgTopic, event)
+}
+
+// Publish a voluntary exit event for an accepted exit
+func (m *BeaconMockManager) publishVoluntaryExitEvent(exit *db.VoluntaryExit) {
+	m.events.publish(api.VoluntaryExitTopic, client.VoluntaryExitRequest{
+		Message: client.VoluntaryExitMessage{
+			Epoch:          utils.Uinteger(exit.Epoch),
+			ValidatorIndex: strconv.FormatUint(exit.ValidatorIndex, 10),
+		},
+		Signature: exit.Signature[:],
+	})
+}
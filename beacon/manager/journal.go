@@ -0,0 +1,231 @@
+package manager
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+)
+
+const (
+	// The number of requests the journal holds by default before dropping the oldest ones
+	DefaultJournalCapacity int = 1000
+
+	// The longest request body the journal records, in bytes; longer bodies are truncated
+	MaxRecordedBodySize int = 64 * 1024
+)
+
+// A predicate that selects recorded requests
+type RequestMatcher func(request api.RecordedRequest) bool
+
+// Match requests with the given HTTP method
+func MatchMethod(method string) RequestMatcher {
+	return func(request api.RecordedRequest) bool {
+		return request.Method == method
+	}
+}
+
+// Match requests to the given route, using its pattern from the api package (such as api.ValidatorsRoute)
+func MatchRoute(route string) RequestMatcher {
+	return func(request api.RecordedRequest) bool {
+		return request.Route == route
+	}
+}
+
+// Match requests with the given path variable, such as api.StateID
+func MatchPathVar(name string, value string) RequestMatcher {
+	return func(request api.RecordedRequest) bool {
+		actual, exists := request.PathVars[name]
+		return exists && actual == value
+	}
+}
+
+// Match requests with the given query parameter
+func MatchQuery(name string, value string) RequestMatcher {
+	return func(request api.RecordedRequest) bool {
+		return slices.Contains(request.Query[name], value)
+	}
+}
+
+// Match requests for exactly the given set of validator IDs, in any order. The IDs are read from the "id" query
+// parameter, or from the body for POST requests that send them as a JSON array or in an object's "ids" field.
+func MatchValidatorIDs(ids ...string) RequestMatcher {
+	expected := slices.Clone(ids)
+	slices.Sort(expected)
+	expected = slices.Compact(expected)
+	return func(request api.RecordedRequest) bool {
+		actual := GetRequestValidatorIDs(request)
+		slices.Sort(actual)
+		actual = slices.Compact(actual)
+		return slices.Equal(expected, actual)
+	}
+}
+
+// Get the validator IDs a recorded request asked for. Returns an empty list if it didn't include any.
+func GetRequestValidatorIDs(request api.RecordedRequest) []string {
+	values := request.Query["id"]
+	if request.Body != "" {
+		var idArray []string
+		var idObject api.ValidatorsRequest
+		if json.Unmarshal([]byte(request.Body), &idArray) == nil {
+			values = append(values, idArray...)
+		} else if json.Unmarshal([]byte(request.Body), &idObject) == nil {
+			values = append(values, idObject.IDs...)
+		}
+	}
+
+	ids := []string{}
+	for _, value := range values {
+		for _, id := range strings.Split(value, ",") {
+			id = strings.TrimSpace(id)
+			if id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// An in-memory journal of the requests the server received. It's a ring buffer that drops the oldest requests once it's
+// full.
+type requestJournal struct {
+	requests []api.RecordedRequest
+	start    int
+	capacity int
+	lock     sync.Mutex
+}
+
+// Create a new journal that holds up to the given number of requests
+func newRequestJournal(capacity int) *requestJournal {
+	return &requestJournal{
+		requests: []api.RecordedRequest{},
+		capacity: capacity,
+	}
+}
+
+// Add a request to the journal, replacing the oldest one if it's full. Bodies longer than MaxRecordedBodySize are
+// truncated.
+func (j *requestJournal) add(request api.RecordedRequest) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if len(request.Body) > MaxRecordedBodySize {
+		request.Body = request.Body[:MaxRecordedBodySize]
+		request.BodyTruncated = true
+	}
+	if j.capacity == 0 {
+		return
+	}
+	if len(j.requests) < j.capacity {
+		j.requests = append(j.requests, request)
+		return
+	}
+	j.requests[j.start] = request
+	j.start = (j.start + 1) % j.capacity
+}
+
+// Get a copy of the requests in the journal, oldest first
+func (j *requestJournal) get() []api.RecordedRequest {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	return j.getOrdered()
+}
+
+// Replace the requests in the journal, keeping the newest ones if there are more than it can hold
+func (j *requestJournal) set(requests []api.RecordedRequest) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.setOrdered(requests)
+}
+
+// Change the number of requests the journal can hold, dropping the oldest ones if it shrinks
+func (j *requestJournal) setCapacity(capacity int) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	requests := j.getOrdered()
+	j.capacity = capacity
+	j.setOrdered(requests)
+}
+
+// Get a copy of the requests in the journal, oldest first. The lock must be held by the caller.
+func (j *requestJournal) getOrdered() []api.RecordedRequest {
+	return append(slices.Clone(j.requests[j.start:]), j.requests[:j.start]...)
+}
+
+// Replace the requests in the journal, keeping the newest ones if there are more than it can hold.
+// The lock must be held by the caller.
+func (j *requestJournal) setOrdered(requests []api.RecordedRequest) {
+	if len(requests) > j.capacity {
+		requests = requests[len(requests)-j.capacity:]
+	}
+	j.requests = slices.Clone(requests)
+	if j.requests == nil {
+		j.requests = []api.RecordedRequest{}
+	}
+	j.start = 0
+}
+
+// Record a request in the journal
+func (m *BeaconMockManager) RecordRequest(request api.RecordedRequest) {
+	m.journal.add(request)
+}
+
+// Get every recorded request, in the order their responses were sent
+func (m *BeaconMockManager) GetRecordedRequests() []api.RecordedRequest {
+	return m.journal.get()
+}
+
+// Get the recorded requests that match all of the given matchers, in the order their responses were sent
+func (m *BeaconMockManager) FindRecordedRequests(matchers ...RequestMatcher) []api.RecordedRequest {
+	matches := []api.RecordedRequest{}
+	for _, request := range m.journal.get() {
+		if matchesAll(request, matchers) {
+			matches = append(matches, request)
+		}
+	}
+	return matches
+}
+
+// Check that exactly count recorded requests match all of the given matchers, returning an error describing the
+// mismatch if not. For example, ExpectRecordedRequests(1, MatchMethod(http.MethodPost), MatchRoute(api.ValidatorsRoute),
+// MatchValidatorIDs("1", "2")) expects exactly one POST to the validators route for validators 1 and 2.
+func (m *BeaconMockManager) ExpectRecordedRequests(count int, matchers ...RequestMatcher) error {
+	matches := m.FindRecordedRequests(matchers...)
+	if len(matches) == count {
+		return nil
+	}
+
+	requests := m.journal.get()
+	lines := make([]string, len(requests))
+	for i, request := range requests {
+		lines[i] = fmt.Sprintf("%s %s (%d)", request.Method, request.Path, request.StatusCode)
+	}
+	return fmt.Errorf("expected %d matching requests but found %d; recorded requests: [%s]", count, len(matches), strings.Join(lines, ", "))
+}
+
+// Remove every recorded request from the journal
+func (m *BeaconMockManager) ClearRecordedRequests() {
+	m.journal.set(nil)
+}
+
+// Set the number of requests the journal holds before dropping the oldest ones, or 0 to stop recording requests
+func (m *BeaconMockManager) SetRecordedRequestsCapacity(capacity int) {
+	m.journal.setCapacity(capacity)
+	m.logger.Info("Set recorded requests capacity", "capacity", capacity)
+}
+
+// Check if a request matches every matcher
+func matchesAll(request api.RecordedRequest, matchers []RequestMatcher) bool {
+	for _, matcher := range matchers {
+		if !matcher(request) {
+			return false
+		}
+	}
+	return true
+}
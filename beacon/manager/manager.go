@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/nodeset-org/osha/beacon/api"
 	"github.com/nodeset-org/osha/beacon/db"
 	"github.com/rocket-pool/node-manager-core/beacon"
 	"github.com/rocket-pool/node-manager-core/beacon/client"
@@ -20,16 +22,26 @@ type BeaconMockManager struct {
 	config   *db.Config
 
 	// Internal fields
-	snapshots map[string]*db.Database
+	snapshots map[string]*snapshot
+	events    *eventHub
+	journal   *requestJournal
 	logger    *slog.Logger
 }
 
+// A snapshot of the manager's state
+type snapshot struct {
+	database *db.Database
+	requests []api.RecordedRequest
+}
+
 // Create a new beacon mock manager instance
 func NewBeaconMockManager(logger *slog.Logger, config *db.Config) *BeaconMockManager {
 	return &BeaconMockManager{
-		database:  db.NewDatabase(logger, config.FirstExecutionBlockIndex),
+		database:  db.NewDatabaseWithConfig(logger, config),
 		config:    config,
-		snapshots: map[string]*db.Database{},
+		snapshots: map[string]*snapshot{},
+		events:    newEventHub(logger),
+		journal:   newRequestJournal(DefaultJournalCapacity),
 		logger:    logger,
 	}
 }
@@ -39,19 +51,23 @@ func (m *BeaconMockManager) SetDatabase(db *db.Database) {
 	m.database = db
 }
 
-// Take a snapshot of the current database state
+// Take a snapshot of the current database state and request journal
 func (m *BeaconMockManager) TakeSnapshot(name string) {
-	m.snapshots[name] = m.database.Clone()
+	m.snapshots[name] = &snapshot{
+		database: m.database.Clone(),
+		requests: m.journal.get(),
+	}
 	m.logger.Info("Took DB snapshot", "name", name)
 }
 
-// Revert to a snapshot of the database state
+// Revert to a snapshot of the database state and request journal
 func (m *BeaconMockManager) RevertToSnapshot(name string) error {
 	snapshot, exists := m.snapshots[name]
 	if !exists {
 		return fmt.Errorf("snapshot with name [%s] does not exist", name)
 	}
-	m.database = snapshot.Clone()
+	m.database = snapshot.database.Clone()
+	m.journal.set(snapshot.requests)
 	m.logger.Info("Reverted to DB snapshot", "name", name)
 	return nil
 }
@@ -65,7 +81,35 @@ func (m *BeaconMockManager) GetConfig() *db.Config {
 // Set slotValidated to true to "propose a block" for the current slot, linking it to the next Execution block's index.
 // Set it to false to "miss" the slot, so there was not block proposed for it.
 func (m *BeaconMockManager) CommitBlock(slotValidated bool) {
+	slot := m.database.GetCurrentSlot()
+	finalized := m.database.GetFinalityCheckpoints().Finalized
 	m.database.CommitBlock(slotValidated)
+	if slotValidated {
+		m.publishBlockEvents(m.database.GetBlockBySlot(slot))
+	}
+	m.publishFinalityEvent(finalized)
+}
+
+// Replaces the blocks in the last depth slots with a new branch, proposing blocks in the first newBlocks slots of it and
+// missing the rest. Returns the blocks that were dropped.
+func (m *BeaconMockManager) Reorg(depth uint64, newBlocks uint64) ([]*db.Block, error) {
+	oldHead := m.database.GetHeadBlock()
+	dropped, err := m.database.Reorg(depth, newBlocks)
+	if err != nil {
+		return nil, err
+	}
+	m.logger.Info("Reorged the chain", "depth", depth, "dropped", len(dropped), "newBlocks", newBlocks)
+
+	// Publish the reorg, followed by the new blocks
+	m.publishReorgEvent(oldHead, depth)
+	currentSlot := m.database.GetCurrentSlot()
+	for slot := currentSlot - depth; slot < currentSlot; slot++ {
+		block := m.database.GetBlockBySlot(slot)
+		if block != nil {
+			m.publishBlockEvents(block)
+		}
+	}
+	return dropped, nil
 }
 
 // Returns the current Beacon chain slot
@@ -83,11 +127,77 @@ func (m *BeaconMockManager) SetHighestSlot(slot uint64) {
 	m.database.SetHighestSlot(slot)
 }
 
+// Returns the current finality checkpoints
+func (m *BeaconMockManager) GetFinalityCheckpoints() db.FinalityCheckpoints {
+	return m.database.GetFinalityCheckpoints()
+}
+
+// Stalls or resumes finality - while stalled, the justified and finalized checkpoints won't advance
+func (m *BeaconMockManager) SetFinalityStalled(stalled bool) {
+	m.database.SetFinalityStalled(stalled)
+}
+
+// Immediately justifies and finalizes the given epoch
+func (m *BeaconMockManager) ForceFinalization(epoch uint64) error {
+	finalized := m.database.GetFinalityCheckpoints().Finalized
+	err := m.database.ForceFinalization(epoch)
+	if err != nil {
+		return err
+	}
+	m.publishFinalityEvent(finalized)
+	return nil
+}
+
+// Enables or disables rewards and penalties at each epoch transition
+func (m *BeaconMockManager) SetRewardsEnabled(enabled bool) {
+	m.database.SetRewardsEnabled(enabled)
+}
+
+// Sets the annual percentage rate active validators earn with full participation (e.g. 0.03 for 3%)
+func (m *BeaconMockManager) SetRewardsApr(apr float64) error {
+	return m.database.SetRewardsApr(apr)
+}
+
+// Sets a validator's attestation participation rate, from 0 (offline) to 1 (perfect)
+func (m *BeaconMockManager) SetParticipation(index uint64, participation float64) error {
+	return m.database.SetParticipation(index, participation)
+}
+
 // Add a validator to the Beacon chain
 func (m *BeaconMockManager) AddValidator(pubkey beacon.ValidatorPubkey, withdrawalCredentials common.Hash) (*db.Validator, error) {
 	return m.database.AddValidator(pubkey, withdrawalCredentials)
 }
 
+// Starts the exit process for an active validator
+func (m *BeaconMockManager) InitiateValidatorExit(index uint64) error {
+	return m.database.InitiateValidatorExit(index)
+}
+
+// Validate a signed voluntary exit and start the validator's exit if it's valid
+func (m *BeaconMockManager) SubmitVoluntaryExit(exit *db.VoluntaryExit) error {
+	err := m.database.SubmitVoluntaryExit(exit)
+	if err != nil {
+		return err
+	}
+	m.publishVoluntaryExitEvent(exit)
+	return nil
+}
+
+// Get the voluntary exits that have been accepted into the pool
+func (m *BeaconMockManager) GetVoluntaryExits() []*db.VoluntaryExit {
+	return m.database.GetVoluntaryExits()
+}
+
+// Validate a signed BLS-to-execution change and add it to the pool if it's valid
+func (m *BeaconMockManager) SubmitBlsToExecutionChange(change *db.BlsToExecutionChange) error {
+	return m.database.SubmitBlsToExecutionChange(change)
+}
+
+// Get the BLS-to-execution changes in the pool that haven't been applied yet
+func (m *BeaconMockManager) GetBlsToExecutionChanges() []*db.BlsToExecutionChange {
+	return m.database.GetBlsToExecutionChanges()
+}
+
 // Gets a validator by its index or pubkey
 func (m *BeaconMockManager) GetValidator(id string) (*db.Validator, error) {
 	if len(id) == beacon.ValidatorPubkeyLength*2 || strings.HasPrefix(id, "0x") {
@@ -134,7 +244,67 @@ func (m *BeaconMockManager) AddPendingDeposit(deposit *db.Deposit) {
 	m.database.AddPendingDeposit(deposit)
 }
 
+// Decode DepositEvent logs from the deposit contract and add them to the Beacon chain as pending deposits made in the given slot.
+// Logs that can't be decoded are logged and skipped, so they don't block the deposits after them.
+func (m *BeaconMockManager) AddPendingDepositsFromEventLogs(logs []types.Log, slot uint64) {
+	for _, log := range logs {
+		if log.Address != m.config.DepositContract {
+			continue
+		}
+		deposit, err := db.NewDepositFromEventLog(log, slot)
+		if err != nil {
+			m.logger.Warn("Skipped invalid deposit event", "tx", log.TxHash.Hex(), "index", log.Index, "error", err)
+			continue
+		}
+		m.database.AddPendingDeposit(deposit)
+		m.logger.Info("Added pending deposit from deposit contract", "pubkey", deposit.Pubkey.HexWithPrefix(), "amount", deposit.Amount, "slot", slot)
+	}
+}
+
+// Import the entries of a deposit data file, such as one generated by staking-deposit-cli, after validating their
+// signatures and roots. Entries become pending deposits made in the current slot, or if preActivate is set, validators
+// that are already active. Returns the result of each entry; an error is only returned if the file couldn't be loaded.
+func (m *BeaconMockManager) ImportDepositDataFile(path string, preActivate bool) ([]db.DepositDataResult, error) {
+	entries, err := db.LoadDepositDataFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return m.ImportDepositData(entries, preActivate), nil
+}
+
+// Import deposit data entries after validating their signatures and roots. Entries become pending deposits made in the
+// current slot, or if preActivate is set, validators that are already active. Returns the result of each entry.
+func (m *BeaconMockManager) ImportDepositData(entries []db.DepositData, preActivate bool) []db.DepositDataResult {
+	results := m.database.ImportDepositData(entries, preActivate)
+	for _, result := range results {
+		if result.Error != nil {
+			m.logger.Warn("Skipped invalid deposit data entry", "index", result.Index, "error", result.Error)
+			continue
+		}
+		m.logger.Info("Imported deposit data entry", "index", result.Index, "pubkey", result.Pubkey.HexWithPrefix(), "preActivated", preActivate)
+	}
+	return results
+}
+
 // Remove a pending deposit from the Beacon chain
 func (m *BeaconMockManager) RemovePendingDeposit(deposit *db.Deposit) {
 	m.database.RemovePendingDeposit(deposit)
 }
+
+// Remove the pending deposits made in the given slot or later, such as ones from Execution blocks that were reverted
+func (m *BeaconMockManager) RemovePendingDepositsSince(slot uint64) {
+	removed := m.database.RemovePendingDepositsSince(slot)
+	if len(removed) > 0 {
+		m.logger.Info("Removed pending deposits", "count", len(removed), "since", slot)
+	}
+}
+
+// Queue a partial withdrawal for a validator, as if it had been requested from the execution layer
+func (m *BeaconMockManager) AddPendingPartialWithdrawal(index uint64, amount uint64) (*db.PendingPartialWithdrawal, error) {
+	return m.database.AddPendingPartialWithdrawal(index, amount)
+}
+
+// Queue a consolidation of one validator into another, as if it had been requested from the execution layer
+func (m *BeaconMockManager) AddPendingConsolidation(sourceIndex uint64, targetIndex uint64) (*db.PendingConsolidation, error) {
+	return m.database.AddPendingConsolidation(sourceIndex, targetIndex)
+}
@@ -0,0 +1,141 @@
+package manager
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+const (
+	// Peer connection states
+	peerStateConnected string = "connected"
+
+	// Peer connection directions
+	peerDirectionInbound  string = "inbound"
+	peerDirectionOutbound string = "outbound"
+)
+
+// The connection states peers can be filtered by
+var peerStates = []string{"disconnected", "connecting", peerStateConnected, "disconnecting"}
+
+// The connection directions peers can be filtered by
+var peerDirections = []string{peerDirectionInbound, peerDirectionOutbound}
+
+// Set the client version string the node reports
+func (m *BeaconMockManager) SetNodeVersion(version string) {
+	m.database.SetNodeVersion(version)
+}
+
+// Set the status code the health route responds with. Use 200 (ready), 206 (syncing), or 503 (not ready), or 0 to
+// derive it from the sync status again.
+func (m *BeaconMockManager) SetNodeHealth(status int) error {
+	return m.database.SetNodeHealth(status)
+}
+
+// Set the number of connected peers the node reports, up to db.MaxPeerCount
+func (m *BeaconMockManager) SetPeerCount(count uint64) error {
+	return m.database.SetPeerCount(count)
+}
+
+// Set the networking identity the node reports
+func (m *BeaconMockManager) SetNodeIdentity(identity db.NodeIdentity) error {
+	return m.database.SetNodeIdentity(identity)
+}
+
+// Get the node version response
+func (m *BeaconMockManager) GetNodeVersionResponse() api.NodeVersionResponse {
+	response := api.NodeVersionResponse{}
+	response.Data.Version = m.database.GetNodeInfo().Version
+	return response
+}
+
+// Get the status code for the health route. If the node is syncing and syncingStatus isn't 0, it's returned instead of
+// 206.
+func (m *BeaconMockManager) GetNodeHealthStatus(syncingStatus int) int {
+	status := m.database.GetNodeInfo().HealthStatus
+	if status == 0 {
+		status = http.StatusOK
+		if m.GetCurrentSlot() < m.GetHighestSlot() {
+			status = http.StatusPartialContent
+		}
+	}
+	if status == http.StatusPartialContent && syncingStatus != 0 {
+		return syncingStatus
+	}
+	return status
+}
+
+// Get the peers response, optionally filtered by connection state and direction. Every peer is connected, and they
+// alternate between inbound and outbound connections.
+func (m *BeaconMockManager) GetNodePeersResponse(states []string, directions []string) (api.PeersResponse, error) {
+	for _, state := range states {
+		if !slices.Contains(peerStates, state) {
+			return api.PeersResponse{}, fmt.Errorf("invalid peer state [%s]", state)
+		}
+	}
+	for _, direction := range directions {
+		if !slices.Contains(peerDirections, direction) {
+			return api.PeersResponse{}, fmt.Errorf("invalid peer direction [%s]", direction)
+		}
+	}
+
+	response := api.PeersResponse{
+		Data: []api.Peer{},
+	}
+	count := m.database.GetNodeInfo().PeerCount
+	for i := uint64(0); i < count; i++ {
+		peer := getMockPeer(i)
+		if len(states) > 0 && !slices.Contains(states, peer.State) {
+			continue
+		}
+		if len(directions) > 0 && !slices.Contains(directions, peer.Direction) {
+			continue
+		}
+		response.Data = append(response.Data, peer)
+	}
+	response.Meta.Count = utils.Uinteger(len(response.Data))
+	return response, nil
+}
+
+// Get the peer count response
+func (m *BeaconMockManager) GetNodePeerCountResponse() api.PeerCountResponse {
+	response := api.PeerCountResponse{}
+	response.Data.Connected = utils.Uinteger(m.database.GetNodeInfo().PeerCount)
+	return response
+}
+
+// Get the node identity response
+func (m *BeaconMockManager) GetNodeIdentityResponse() api.NodeIdentityResponse {
+	identity := m.database.GetNodeInfo().Identity
+	return api.NodeIdentityResponse{
+		Data: api.NodeIdentity{
+			PeerID:             identity.PeerID,
+			Enr:                identity.Enr,
+			P2PAddresses:       identity.P2PAddresses,
+			DiscoveryAddresses: identity.DiscoveryAddresses,
+			Metadata: api.NodeIdentityMetadata{
+				Attnets:  make([]byte, 8),
+				Syncnets: make([]byte, 1),
+			},
+		},
+	}
+}
+
+// Create a fake connected peer for the given index. Peer indices start at 1 so they don't collide with the node's own
+// default identity.
+func getMockPeer(index uint64) api.Peer {
+	direction := peerDirectionInbound
+	if index%2 == 1 {
+		direction = peerDirectionOutbound
+	}
+	return api.Peer{
+		PeerID:             db.GetMockPeerID(index + 1),
+		LastSeenP2PAddress: fmt.Sprintf("/ip4/10.0.%d.%d/tcp/9000", (index/256)%256, index%256),
+		State:              peerStateConnected,
+		Direction:          direction,
+	}
+}
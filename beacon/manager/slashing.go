@@ -0,0 +1,169 @@
+package manager
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+// Slash a validator as if it had been caught by the current slot's proposer
+func (m *BeaconMockManager) SlashValidator(index uint64) error {
+	return m.database.SlashValidator(index)
+}
+
+// Slash a validator like SlashValidator, but with a custom penalty (in gwei) instead of the spec's penalties and rewards
+func (m *BeaconMockManager) SlashValidatorWithPenalty(index uint64, penalty uint64) error {
+	return m.database.SlashValidatorWithPenalty(index, penalty)
+}
+
+// Validate a proposer slashing and slash the proposer if it's valid
+func (m *BeaconMockManager) SubmitProposerSlashing(request api.ProposerSlashing) error {
+	header1, err := getSignedBlockHeader(request.SignedHeader1)
+	if err != nil {
+		return fmt.Errorf("invalid first header: %w", err)
+	}
+	header2, err := getSignedBlockHeader(request.SignedHeader2)
+	if err != nil {
+		return fmt.Errorf("invalid second header: %w", err)
+	}
+	return m.database.SubmitProposerSlashing(&db.ProposerSlashing{
+		Header1: header1,
+		Header2: header2,
+	})
+}
+
+// Validate an attester slashing and slash the validators that signed both attestations if it's valid
+func (m *BeaconMockManager) SubmitAttesterSlashing(request api.AttesterSlashing) error {
+	attestation1, err := getIndexedAttestation(request.Attestation1)
+	if err != nil {
+		return fmt.Errorf("invalid first attestation: %w", err)
+	}
+	attestation2, err := getIndexedAttestation(request.Attestation2)
+	if err != nil {
+		return fmt.Errorf("invalid second attestation: %w", err)
+	}
+	return m.database.SubmitAttesterSlashing(&db.AttesterSlashing{
+		Attestation1: attestation1,
+		Attestation2: attestation2,
+	})
+}
+
+// Get the proposer slashings in the pool
+func (m *BeaconMockManager) GetProposerSlashingsResponse() api.ProposerSlashingsResponse {
+	slashings := m.database.GetProposerSlashings()
+	response := api.ProposerSlashingsResponse{
+		Data: make([]api.ProposerSlashing, len(slashings)),
+	}
+	for i, slashing := range slashings {
+		response.Data[i] = api.ProposerSlashing{
+			SignedHeader1: getSignedBeaconBlockHeaderData(slashing.Header1),
+			SignedHeader2: getSignedBeaconBlockHeaderData(slashing.Header2),
+		}
+	}
+	return response
+}
+
+// Get the attester slashings in the pool
+func (m *BeaconMockManager) GetAttesterSlashingsResponse() api.AttesterSlashingsResponse {
+	slashings := m.database.GetAttesterSlashings()
+	response := api.AttesterSlashingsResponse{
+		Data: make([]api.AttesterSlashing, len(slashings)),
+	}
+	for i, slashing := range slashings {
+		response.Data[i] = api.AttesterSlashing{
+			Attestation1: getIndexedAttestationData(slashing.Attestation1),
+			Attestation2: getIndexedAttestationData(slashing.Attestation2),
+		}
+	}
+	return response
+}
+
+// Convert a signed block header from its API format
+func getSignedBlockHeader(header api.SignedBeaconBlockHeader) (db.SignedBlockHeader, error) {
+	proposerIndex, err := strconv.ParseUint(header.Message.ProposerIndex, 10, 64)
+	if err != nil {
+		return db.SignedBlockHeader{}, fmt.Errorf("invalid proposer index [%s]", header.Message.ProposerIndex)
+	}
+	if len(header.Signature) != beacon.ValidatorSignatureLength {
+		return db.SignedBlockHeader{}, fmt.Errorf("invalid signature length %d", len(header.Signature))
+	}
+	return db.SignedBlockHeader{
+		Slot:          uint64(header.Message.Slot),
+		ProposerIndex: proposerIndex,
+		ParentRoot:    header.Message.ParentRoot,
+		StateRoot:     header.Message.StateRoot,
+		BodyRoot:      header.Message.BodyRoot,
+		Signature:     beacon.ValidatorSignature(header.Signature),
+	}, nil
+}
+
+// Convert a signed block header into its API format
+func getSignedBeaconBlockHeaderData(header db.SignedBlockHeader) api.SignedBeaconBlockHeader {
+	return api.SignedBeaconBlockHeader{
+		Message: api.BlockHeaderMessage{
+			Slot:          utils.Uinteger(header.Slot),
+			ProposerIndex: strconv.FormatUint(header.ProposerIndex, 10),
+			ParentRoot:    header.ParentRoot,
+			StateRoot:     header.StateRoot,
+			BodyRoot:      header.BodyRoot,
+		},
+		Signature: header.Signature[:],
+	}
+}
+
+// Convert an indexed attestation from its API format
+func getIndexedAttestation(attestation api.IndexedAttestation) (db.IndexedAttestation, error) {
+	if len(attestation.Signature) != beacon.ValidatorSignatureLength {
+		return db.IndexedAttestation{}, fmt.Errorf("invalid signature length %d", len(attestation.Signature))
+	}
+	indices := make([]uint64, len(attestation.AttestingIndices))
+	for i, index := range attestation.AttestingIndices {
+		indices[i] = uint64(index)
+	}
+	return db.IndexedAttestation{
+		AttestingIndices: indices,
+		Data: db.AttestationData{
+			Slot:            uint64(attestation.Data.Slot),
+			CommitteeIndex:  uint64(attestation.Data.Index),
+			BeaconBlockRoot: attestation.Data.BeaconBlockRoot,
+			Source: db.Checkpoint{
+				Epoch: uint64(attestation.Data.Source.Epoch),
+				Root:  attestation.Data.Source.Root,
+			},
+			Target: db.Checkpoint{
+				Epoch: uint64(attestation.Data.Target.Epoch),
+				Root:  attestation.Data.Target.Root,
+			},
+		},
+		Signature: beacon.ValidatorSignature(attestation.Signature),
+	}, nil
+}
+
+// Convert an indexed attestation into its API format
+func getIndexedAttestationData(attestation db.IndexedAttestation) api.IndexedAttestation {
+	indices := make([]utils.Uinteger, len(attestation.AttestingIndices))
+	for i, index := range attestation.AttestingIndices {
+		indices[i] = utils.Uinteger(index)
+	}
+	return api.IndexedAttestation{
+		AttestingIndices: indices,
+		Data: api.AttestationData{
+			Slot:            utils.Uinteger(attestation.Data.Slot),
+			Index:           utils.Uinteger(attestation.Data.CommitteeIndex),
+			BeaconBlockRoot: attestation.Data.BeaconBlockRoot,
+			Source: api.Checkpoint{
+				Epoch: utils.Uinteger(attestation.Data.Source.Epoch),
+				Root:  attestation.Data.Source.Root,
+			},
+			Target: api.Checkpoint{
+				Epoch: utils.Uinteger(attestation.Data.Target.Epoch),
+				Root:  attestation.Data.Target.Root,
+			},
+		},
+		Signature: attestation.Signature[:],
+	}
+}
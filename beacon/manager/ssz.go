@@ -0,0 +1,424 @@
+package manager
+
+import (
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nodeset-org/osha/beacon/db"
+	"github.com/nodeset-org/osha/beacon/ssz"
+	"github.com/rocket-pool/node-manager-core/beacon"
+)
+
+const (
+	// Sizes of the SSZ types used by the Beacon API, in bytes
+	rootSize          int = 32
+	versionSize       int = 4
+	logsBloomSize     int = 256
+	uint256Size       int = 32
+	syncCommitteeSize int = 512
+	pubkeySize        int = 48
+
+	// Lengths of the vectors in the spec's BeaconState
+	slotsPerHistoricalRoot    int = 8192
+	epochsPerHistoricalVector int = 65536
+)
+
+// Get the name of the fork that's active at the given slot, for the Eth-Consensus-Version header
+func (m *BeaconMockManager) GetForkName(slot uint64) string {
+	return m.config.GetForkName(slot / m.config.SlotsPerEpoch)
+}
+
+// Get the SSZ encoding of the validators in a state, optionally filtered by their statuses. There's no standard SSZ
+// type for this route, so each validator is encoded as a container of its index (uint64), balance (uint64), status
+// (List[uint8, 32]), and spec Validator.
+func (m *BeaconMockManager) GetValidatorsSsz(state *db.State, ids []string, statuses []string) ([]byte, error) {
+	validators, err := m.getFilteredValidators(state, ids, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := make([][]byte, len(validators))
+	for i, validator := range validators {
+		elements[i] = ssz.NewContainer().
+			Uint64(validator.Index).
+			Uint64(validator.Balance).
+			Variable([]byte(validator.Status)).
+			Fixed(encodeValidator(validator)).
+			Bytes()
+	}
+	return ssz.VariableList(elements), nil
+}
+
+// Get the SSZ encoding of a block as the spec's SignedBeaconBlock for the fork it was proposed in. Fields the mock
+// doesn't track are zeroed, and the operation lists are empty.
+func (m *BeaconMockManager) GetBlockSsz(block *db.Block) []byte {
+	message := ssz.NewContainer().
+		Uint64(block.Slot).
+		Uint64(block.ProposerIndex).
+		Fixed(block.ParentRoot[:]).
+		Fixed(block.StateRoot[:]).
+		Variable(m.encodeBlockBody(block)).
+		Bytes()
+	return ssz.NewContainer().
+		Variable(message).
+		Fixed(ssz.Zero(beacon.ValidatorSignatureLength)).
+		Bytes()
+}
+
+// Get the SSZ encoding of the chain's genesis details
+func (m *BeaconMockManager) GetBeaconGenesisSsz() []byte {
+	return ssz.NewContainer().
+		Uint64(uint64(m.config.GenesisTime.Unix())).
+		Fixed(toFixedBytes(m.config.GenesisValidatorsRoot, rootSize)).
+		Fixed(toFixedBytes(m.config.GenesisForkVersion, versionSize)).
+		Bytes()
+}
+
+// Get the SSZ encoding of the fork that's active in a state
+func (m *BeaconMockManager) GetStateForkSsz(state *db.State) []byte {
+	fork := m.config.GetFork(state.Slot / m.config.SlotsPerEpoch)
+	return ssz.NewContainer().
+		Fixed(toFixedBytes(fork.PreviousVersion, versionSize)).
+		Fixed(toFixedBytes(fork.CurrentVersion, versionSize)).
+		Uint64(fork.Epoch).
+		Bytes()
+}
+
+// Get the SSZ encoding of the pending deposits in a state, as a list of the spec's PendingDeposit
+func (m *BeaconMockManager) GetPendingDepositsSsz(state *db.State) []byte {
+	elements := make([][]byte, len(state.PendingDeposits))
+	for i, deposit := range state.PendingDeposits {
+		elements[i] = ssz.NewContainer().
+			Fixed(deposit.Pubkey[:]).
+			Fixed(deposit.WithdrawalCredentials[:]).
+			Uint64(deposit.Amount).
+			Fixed(deposit.Signature[:]).
+			Uint64(deposit.Slot).
+			Bytes()
+	}
+	return ssz.FixedList(elements)
+}
+
+// Get the SSZ encoding of the pending partial withdrawals in a state, as a list of the spec's PendingPartialWithdrawal
+func (m *BeaconMockManager) GetPendingPartialWithdrawalsSsz(state *db.State) []byte {
+	elements := make([][]byte, len(state.PendingPartialWithdrawals))
+	for i, withdrawal := range state.PendingPartialWithdrawals {
+		elements[i] = ssz.NewContainer().
+			Uint64(withdrawal.ValidatorIndex).
+			Uint64(withdrawal.Amount).
+			Uint64(withdrawal.WithdrawableEpoch).
+			Bytes()
+	}
+	return ssz.FixedList(elements)
+}
+
+// Get the SSZ encoding of the pending consolidations in a state, as a list of the spec's PendingConsolidation
+func (m *BeaconMockManager) GetPendingConsolidationsSsz(state *db.State) []byte {
+	elements := make([][]byte, len(state.PendingConsolidations))
+	for i, consolidation := range state.PendingConsolidations {
+		elements[i] = ssz.NewContainer().
+			Uint64(consolidation.SourceIndex).
+			Uint64(consolidation.TargetIndex).
+			Bytes()
+	}
+	return ssz.FixedList(elements)
+}
+
+// Get the SSZ encoding of a state as the spec's BeaconState for the fork that's active in it. Fields the mock doesn't
+// track, such as the historical roots and RANDAO mixes, are zeroed.
+func (m *BeaconMockManager) GetStateSsz(state *db.State) []byte {
+	epoch := state.Slot / m.config.SlotsPerEpoch
+	validators := make([][]byte, len(state.Validators))
+	balances := make([]uint64, len(state.Validators))
+	for i, validator := range state.Validators {
+		validators[i] = encodeValidator(validator)
+		balances[i] = validator.Balance
+	}
+
+	// Get the header of the latest block, which doesn't have its state root until the next slot is processed
+	header := db.Block{}
+	block := m.database.GetLatestBlock(state.Slot)
+	if block != nil {
+		header = *block
+		if block.Slot == state.Slot {
+			header.StateRoot = common.Hash{}
+		}
+	}
+
+	// Participation flags replaced the pending attestations in Altair
+	var participation []byte
+	if epoch >= m.config.AltairForkEpoch {
+		participation = make([]byte, len(state.Validators))
+	}
+
+	// Phase 0 fields
+	latestBlockHeader := ssz.NewContainer().
+		Uint64(header.Slot).
+		Uint64(header.ProposerIndex).
+		Fixed(header.ParentRoot[:]).
+		Fixed(header.StateRoot[:]).
+		Fixed(header.BodyRoot[:]).
+		Bytes()
+	eth1Data := ssz.NewContainer().
+		Fixed(ssz.Zero(rootSize)).
+		Uint64(0).
+		Fixed(ssz.Zero(rootSize)).
+		Bytes()
+	body := ssz.NewContainer().
+		Uint64(uint64(m.config.GenesisTime.Unix())).
+		Fixed(toFixedBytes(m.config.GenesisValidatorsRoot, rootSize)).
+		Uint64(state.Slot).
+		Fixed(m.GetStateForkSsz(state)).
+		Fixed(latestBlockHeader).
+		Fixed(ssz.Zero(slotsPerHistoricalRoot * rootSize)). // Block roots
+		Fixed(ssz.Zero(slotsPerHistoricalRoot * rootSize)). // State roots
+		Variable(nil).                                      // Historical roots
+		Fixed(eth1Data).
+		Variable(nil). // Eth1 data votes
+		Uint64(0).     // Eth1 deposit index
+		Variable(ssz.FixedList(validators)).
+		Variable(ssz.Uint64List(balances)).
+		Fixed(ssz.Zero(epochsPerHistoricalVector * rootSize)). // RANDAO mixes
+		Fixed(ssz.Zero(int(db.EpochsPerSlashingsVector) * 8)). // Slashings
+		Variable(participation).                               // Previous epoch attestations or participation
+		Variable(participation).                               // Current epoch attestations or participation
+		Fixed(ssz.Zero(1)).                                    // Justification bits
+		Fixed(encodeCheckpoint(state.Finality.PreviousJustified)).
+		Fixed(encodeCheckpoint(state.Finality.CurrentJustified)).
+		Fixed(encodeCheckpoint(state.Finality.Finalized))
+	if epoch < m.config.AltairForkEpoch {
+		return body.Bytes()
+	}
+
+	// Altair adds the inactivity scores and sync committees
+	syncCommittee := ssz.Zero((syncCommitteeSize + 1) * pubkeySize)
+	body.
+		Variable(ssz.Uint64List(make([]uint64, len(state.Validators)))).
+		Fixed(syncCommittee).
+		Fixed(syncCommittee)
+	if epoch < m.config.BellatrixForkEpoch {
+		return body.Bytes()
+	}
+
+	// Bellatrix adds the latest execution payload header
+	body.Variable(m.encodeExecutionPayloadHeader(block, epoch))
+	if epoch < m.config.CapellaForkEpoch {
+		return body.Bytes()
+	}
+
+	// Capella adds the withdrawal sweep and historical summaries
+	body.
+		Uint64(0).    // Next withdrawal index
+		Uint64(0).    // Next withdrawal validator index
+		Variable(nil) // Historical summaries
+	if epoch < m.config.ElectraForkEpoch {
+		return body.Bytes()
+	}
+
+	// Electra adds the churn trackers and pending queues
+	return body.
+		Uint64(0). // Deposit requests start index
+		Uint64(0). // Deposit balance to consume
+		Uint64(0). // Exit balance to consume
+		Uint64(0). // Earliest exit epoch
+		Uint64(0). // Consolidation balance to consume
+		Uint64(0). // Earliest consolidation epoch
+		Variable(m.GetPendingDepositsSsz(state)).
+		Variable(m.GetPendingPartialWithdrawalsSsz(state)).
+		Variable(m.GetPendingConsolidationsSsz(state)).
+		Bytes()
+}
+
+// Get the SSZ encoding of the config spec. There's no standard SSZ type for this route, so it's encoded as a list of
+// containers holding each key and value (both List[uint8]), sorted by key.
+func (m *BeaconMockManager) GetConfigSpecSsz() []byte {
+	spec := m.GetConfigSpecResponse().Data
+	keys := make([]string, 0, len(spec))
+	for key := range spec {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	elements := make([][]byte, len(keys))
+	for i, key := range keys {
+		elements[i] = ssz.NewContainer().
+			Variable([]byte(key)).
+			Variable([]byte(spec[key])).
+			Bytes()
+	}
+	return ssz.VariableList(elements)
+}
+
+// Encode the body of a block for the fork it was proposed in
+func (m *BeaconMockManager) encodeBlockBody(block *db.Block) []byte {
+	epoch := block.Slot / m.config.SlotsPerEpoch
+	eth1Data := ssz.NewContainer().
+		Fixed(ssz.Zero(rootSize)).
+		Uint64(0).
+		Fixed(ssz.Zero(rootSize)).
+		Bytes()
+
+	// Phase 0 fields
+	body := ssz.NewContainer().
+		Fixed(ssz.Zero(beacon.ValidatorSignatureLength)). // RANDAO reveal
+		Fixed(eth1Data).
+		Fixed(ssz.Zero(rootSize)). // Graffiti
+		Variable(nil).             // Proposer slashings
+		Variable(nil).             // Attester slashings
+		Variable(nil).             // Attestations
+		Variable(nil).             // Deposits
+		Variable(nil)              // Voluntary exits
+	if epoch < m.config.AltairForkEpoch {
+		return body.Bytes()
+	}
+
+	// Altair adds the sync aggregate
+	body.Fixed(ssz.Zero(syncCommitteeSize/8 + beacon.ValidatorSignatureLength))
+	if epoch < m.config.BellatrixForkEpoch {
+		return body.Bytes()
+	}
+
+	// Bellatrix adds the execution payload
+	body.Variable(m.encodeExecutionPayload(block))
+	if epoch < m.config.CapellaForkEpoch {
+		return body.Bytes()
+	}
+
+	// Capella adds BLS to execution changes
+	body.Variable(nil)
+	if epoch < m.config.DenebForkEpoch {
+		return body.Bytes()
+	}
+
+	// Deneb adds blob KZG commitments
+	body.Variable(nil)
+	if epoch < m.config.ElectraForkEpoch {
+		return body.Bytes()
+	}
+
+	// Electra adds the execution requests, which are 3 empty lists
+	executionRequests := ssz.NewContainer().
+		Variable(nil).
+		Variable(nil).
+		Variable(nil).
+		Bytes()
+	return body.Variable(executionRequests).Bytes()
+}
+
+// Encode the execution payload of a block for the fork it was proposed in
+func (m *BeaconMockManager) encodeExecutionPayload(block *db.Block) []byte {
+	epoch := block.Slot / m.config.SlotsPerEpoch
+	payload := ssz.NewContainer().
+		Fixed(ssz.Zero(rootSize)). // Parent hash
+		Fixed(block.FeeRecipient[:]).
+		Fixed(ssz.Zero(rootSize)).      // State root
+		Fixed(ssz.Zero(rootSize)).      // Receipts root
+		Fixed(ssz.Zero(logsBloomSize)). // Logs bloom
+		Fixed(ssz.Zero(rootSize)).      // Prev RANDAO
+		Uint64(block.ExecutionBlockNumber).
+		Uint64(0). // Gas limit
+		Uint64(0). // Gas used
+		Uint64(m.getExecutionBlockTimestamp(block)).
+		Variable(nil).                // Extra data
+		Fixed(ssz.Zero(uint256Size)). // Base fee per gas
+		Fixed(block.ExecutionBlockHash[:]).
+		Variable(nil) // Transactions
+	if epoch < m.config.CapellaForkEpoch {
+		return payload.Bytes()
+	}
+
+	// Capella adds withdrawals
+	withdrawals := make([][]byte, len(block.Withdrawals))
+	for i, withdrawal := range block.Withdrawals {
+		withdrawals[i] = ssz.NewContainer().
+			Uint64(withdrawal.Index).
+			Uint64(withdrawal.ValidatorIndex).
+			Fixed(withdrawal.Address[:]).
+			Uint64(withdrawal.Amount).
+			Bytes()
+	}
+	payload.Variable(ssz.FixedList(withdrawals))
+	if epoch < m.config.DenebForkEpoch {
+		return payload.Bytes()
+	}
+
+	// Deneb adds the blob gas fields
+	return payload.
+		Uint64(0). // Blob gas used
+		Uint64(0). // Excess blob gas
+		Bytes()
+}
+
+// Encode the header of a block's execution payload for the fork that's active in the given epoch. The payload is
+// zeroed if there isn't a block.
+func (m *BeaconMockManager) encodeExecutionPayloadHeader(block *db.Block, epoch uint64) []byte {
+	var number, timestamp uint64
+	var feeRecipient common.Address
+	var blockHash common.Hash
+	if block != nil {
+		number = block.ExecutionBlockNumber
+		timestamp = m.getExecutionBlockTimestamp(block)
+		feeRecipient = block.FeeRecipient
+		blockHash = block.ExecutionBlockHash
+	}
+
+	header := ssz.NewContainer().
+		Fixed(ssz.Zero(rootSize)). // Parent hash
+		Fixed(feeRecipient[:]).
+		Fixed(ssz.Zero(rootSize)).      // State root
+		Fixed(ssz.Zero(rootSize)).      // Receipts root
+		Fixed(ssz.Zero(logsBloomSize)). // Logs bloom
+		Fixed(ssz.Zero(rootSize)).      // Prev RANDAO
+		Uint64(number).
+		Uint64(0). // Gas limit
+		Uint64(0). // Gas used
+		Uint64(timestamp).
+		Variable(nil).                // Extra data
+		Fixed(ssz.Zero(uint256Size)). // Base fee per gas
+		Fixed(blockHash[:]).
+		Fixed(ssz.Zero(rootSize)) // Transactions root
+	if epoch < m.config.CapellaForkEpoch {
+		return header.Bytes()
+	}
+
+	// Capella adds the withdrawals root
+	header.Fixed(ssz.Zero(rootSize))
+	if epoch < m.config.DenebForkEpoch {
+		return header.Bytes()
+	}
+
+	// Deneb adds the blob gas fields
+	return header.
+		Uint64(0). // Blob gas used
+		Uint64(0). // Excess blob gas
+		Bytes()
+}
+
+// Encode a checkpoint as the spec's Checkpoint container
+func encodeCheckpoint(checkpoint db.Checkpoint) []byte {
+	return ssz.NewContainer().
+		Uint64(checkpoint.Epoch).
+		Fixed(checkpoint.Root[:]).
+		Bytes()
+}
+
+// Encode a validator as the spec's Validator container
+func encodeValidator(validator *db.Validator) []byte {
+	return ssz.NewContainer().
+		Fixed(validator.Pubkey[:]).
+		Fixed(validator.WithdrawalCredentials[:]).
+		Uint64(validator.EffectiveBalance).
+		Bool(validator.Slashed).
+		Uint64(validator.ActivationEligibilityEpoch).
+		Uint64(validator.ActivationEpoch).
+		Uint64(validator.ExitEpoch).
+		Uint64(validator.WithdrawableEpoch).
+		Bytes()
+}
+
+// Copy bytes into a vector of the given size, zero-padding or truncating them as needed
+func toFixedBytes(data []byte, size int) []byte {
+	fixed := make([]byte, size)
+	copy(fixed, data)
+	return fixed
+}
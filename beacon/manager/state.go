@@ -0,0 +1,274 @@
+package manager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/beacon/client"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+// The aggregate statuses validators can be filtered by, and the individual states they include
+var validatorStatusGroups = map[string][]beacon.ValidatorState{
+	"pending": {
+		beacon.ValidatorState_PendingInitialized,
+		beacon.ValidatorState_PendingQueued,
+	},
+	"active": {
+		beacon.ValidatorState_ActiveOngoing,
+		beacon.ValidatorState_ActiveExiting,
+		beacon.ValidatorState_ActiveSlashed,
+	},
+	"exited": {
+		beacon.ValidatorState_ExitedUnslashed,
+		beacon.ValidatorState_ExitedSlashed,
+	},
+	"withdrawal": {
+		beacon.ValidatorState_WithdrawalPossible,
+		beacon.ValidatorState_WithdrawalDone,
+	},
+}
+
+// Get the Beacon chain state by its ID, which can be "head", "genesis", "finalized", "justified", a slot number,
+// or a 0x-prefixed state root. Returns nil if the state doesn't exist.
+func (m *BeaconMockManager) GetState(id string) (*db.State, error) {
+	switch id {
+	case "head":
+		return m.database.GetState(m.database.GetCurrentSlot()), nil
+	case "genesis":
+		return m.database.GetState(0), nil
+	case "finalized":
+		epoch := m.database.GetFinalityCheckpoints().Finalized.Epoch
+		return m.database.GetState(epoch * m.config.SlotsPerEpoch), nil
+	case "justified":
+		epoch := m.database.GetFinalityCheckpoints().CurrentJustified.Epoch
+		return m.database.GetState(epoch * m.config.SlotsPerEpoch), nil
+	}
+
+	if strings.HasPrefix(id, "0x") {
+		if len(id) != common.HashLength*2+2 {
+			return nil, fmt.Errorf("invalid state root [%s]", id)
+		}
+		return m.database.GetStateByRoot(common.HexToHash(id)), nil
+	}
+	slot, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state ID [%s]", id)
+	}
+	return m.database.GetState(slot), nil
+}
+
+// Gets a validator from a state by its index or pubkey
+func (m *BeaconMockManager) GetStateValidator(state *db.State, id string) (*db.Validator, error) {
+	if len(id) == beacon.ValidatorPubkeyLength*2 || strings.HasPrefix(id, "0x") {
+		pubkey, err := beacon.HexToValidatorPubkey(id)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing pubkey [%s]: %v", id, err)
+		}
+		return state.GetValidatorByPubkey(pubkey), nil
+	}
+	index, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing index [%s]: %v", id, err)
+	}
+	return state.GetValidatorByIndex(index), nil
+}
+
+// Gets multiple validators from a state by their indices or pubkeys
+func (m *BeaconMockManager) GetStateValidators(state *db.State, ids []string) ([]*db.Validator, error) {
+	if len(ids) == 0 {
+		return state.Validators, nil
+	}
+
+	validators := []*db.Validator{}
+	for _, id := range ids {
+		validator, err := m.GetStateValidator(state, id)
+		if err != nil {
+			return nil, err
+		}
+		if validator == nil {
+			continue
+		}
+		validators = append(validators, validator)
+	}
+	return validators, nil
+}
+
+// Create the API response for validators in a state, optionally filtered by their statuses
+func (m *BeaconMockManager) GetValidatorsResponse(state *db.State, ids []string, statuses []string) (client.ValidatorsResponse, error) {
+	// Get the validators
+	validators, err := m.getFilteredValidators(state, ids, statuses)
+	if err != nil {
+		return client.ValidatorsResponse{}, err
+	}
+
+	// Write the response
+	validatorMetas := make([]client.Validator, len(validators))
+	for i, validator := range validators {
+		validatorMetas[i] = validator.GetValidatorMeta()
+	}
+	response := client.ValidatorsResponse{
+		Data: validatorMetas,
+	}
+	return response, nil
+}
+
+// Create the API response for the balances of validators in a state
+func (m *BeaconMockManager) GetValidatorBalancesResponse(state *db.State, ids []string) (api.ValidatorBalancesResponse, error) {
+	// Get the validators
+	validators, err := m.GetStateValidators(state, ids)
+	if err != nil {
+		return api.ValidatorBalancesResponse{}, err
+	}
+
+	// Write the response
+	response := api.ValidatorBalancesResponse{
+		Finalized: m.isStateFinalized(state),
+		Data:      make([]api.ValidatorBalance, len(validators)),
+	}
+	for i, validator := range validators {
+		response.Data[i] = api.ValidatorBalance{
+			Index:   strconv.FormatUint(validator.Index, 10),
+			Balance: utils.Uinteger(validator.Balance),
+		}
+	}
+	return response, nil
+}
+
+// Create the API response for the pending deposits in a state
+func (m *BeaconMockManager) GetPendingDepositsResponse(state *db.State) client.PendingDepositsResponse {
+	// Convert the deposit data to the native format
+	nativeDeposits := make([]client.PendingDeposit, len(state.PendingDeposits))
+	for i, deposit := range state.PendingDeposits {
+		nativeDeposits[i] = deposit.ConvertToNativeFormat()
+	}
+
+	// Write the response
+	response := client.PendingDepositsResponse{
+		Data: nativeDeposits,
+	}
+	return response
+}
+
+// Get the pending partial withdrawals response for a state
+func (m *BeaconMockManager) GetPendingPartialWithdrawalsResponse(state *db.State) api.PendingPartialWithdrawalsResponse {
+	response := api.PendingPartialWithdrawalsResponse{
+		Data: make([]api.PendingPartialWithdrawal, len(state.PendingPartialWithdrawals)),
+	}
+	for i, withdrawal := range state.PendingPartialWithdrawals {
+		response.Data[i] = api.PendingPartialWithdrawal{
+			ValidatorIndex:    strconv.FormatUint(withdrawal.ValidatorIndex, 10),
+			Amount:            utils.Uinteger(withdrawal.Amount),
+			WithdrawableEpoch: utils.Uinteger(withdrawal.WithdrawableEpoch),
+		}
+	}
+	return response
+}
+
+// Get the pending consolidations response for a state
+func (m *BeaconMockManager) GetPendingConsolidationsResponse(state *db.State) api.PendingConsolidationsResponse {
+	response := api.PendingConsolidationsResponse{
+		Data: make([]api.PendingConsolidation, len(state.PendingConsolidations)),
+	}
+	for i, consolidation := range state.PendingConsolidations {
+		response.Data[i] = api.PendingConsolidation{
+			SourceIndex: strconv.FormatUint(consolidation.SourceIndex, 10),
+			TargetIndex: strconv.FormatUint(consolidation.TargetIndex, 10),
+		}
+	}
+	return response
+}
+
+// Get the finality checkpoints response for a state
+func (m *BeaconMockManager) GetFinalityCheckpointsResponse(state *db.State) api.FinalityCheckpointsResponse {
+	response := api.FinalityCheckpointsResponse{}
+	response.Data.PreviousJustified = getCheckpointResponse(state.Finality.PreviousJustified)
+	response.Data.CurrentJustified = getCheckpointResponse(state.Finality.CurrentJustified)
+	response.Data.Finalized = getCheckpointResponse(state.Finality.Finalized)
+	return response
+}
+
+// Get a state by its ID, returning an error if it doesn't exist
+func (m *BeaconMockManager) getExistingState(id string) (*db.State, error) {
+	state, err := m.GetState(id)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, fmt.Errorf("state [%s] not found", id)
+	}
+	return state, nil
+}
+
+// Get the validators in a state by their indices or pubkeys, filtered by their statuses
+func (m *BeaconMockManager) getFilteredValidators(state *db.State, ids []string, statuses []string) ([]*db.Validator, error) {
+	validators, err := m.GetStateValidators(state, ids)
+	if err != nil {
+		return nil, err
+	}
+	return filterValidatorsByStatus(validators, statuses)
+}
+
+// Check if a state is at or before the finalized checkpoint
+func (m *BeaconMockManager) isStateFinalized(state *db.State) bool {
+	finalizedSlot := m.database.GetFinalityCheckpoints().Finalized.Epoch * m.config.SlotsPerEpoch
+	return state.Slot <= finalizedSlot
+}
+
+// Filter validators down to the ones with one of the given statuses. Statuses can be individual validator states or
+// the "active", "pending", "exited", and "withdrawal" groups. An empty list keeps every validator.
+func filterValidatorsByStatus(validators []*db.Validator, statuses []string) ([]*db.Validator, error) {
+	if len(statuses) == 0 {
+		return validators, nil
+	}
+
+	// Expand the groups into the individual states
+	allowed := map[beacon.ValidatorState]bool{}
+	for _, status := range statuses {
+		group, exists := validatorStatusGroups[status]
+		if exists {
+			for _, state := range group {
+				allowed[state] = true
+			}
+			continue
+		}
+		state := beacon.ValidatorState(status)
+		if !isValidatorState(state) {
+			return nil, fmt.Errorf("invalid validator status [%s]", status)
+		}
+		allowed[state] = true
+	}
+
+	filtered := []*db.Validator{}
+	for _, validator := range validators {
+		if allowed[validator.Status] {
+			filtered = append(filtered, validator)
+		}
+	}
+	return filtered, nil
+}
+
+// Check if a state is one of the individual validator states
+func isValidatorState(state beacon.ValidatorState) bool {
+	for _, group := range validatorStatusGroups {
+		for _, member := range group {
+			if member == state {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Convert a checkpoint into its API form
+func getCheckpointResponse(checkpoint db.Checkpoint) api.Checkpoint {
+	return api.Checkpoint{
+		Epoch: utils.Uinteger(checkpoint.Epoch),
+		Root:  checkpoint.Root,
+	}
+}
@@ -52,10 +52,22 @@ func main() {
 		Usage:   "An optional configuration file to load. If not specified, defaults will be used",
 	}
 
+	depositDataFlag := &cli.StringSliceFlag{
+		Name:    "deposit-data",
+		Aliases: []string{"d"},
+		Usage:   "A deposit data file, such as a deposit_data-*.json file from staking-deposit-cli, to import on startup. Can be specified multiple times",
+	}
+	preActivateFlag := &cli.BoolFlag{
+		Name:  "pre-activate",
+		Usage: "Import deposit data as active validators instead of pending deposits",
+	}
+
 	app.Flags = []cli.Flag{
 		ipFlag,
 		portFlag,
 		configFlag,
+		depositDataFlag,
+		preActivateFlag,
 	}
 	app.Action = func(c *cli.Context) error {
 		logger := slog.Default()
@@ -84,6 +96,25 @@ func main() {
 			os.Exit(1)
 		}
 
+		// Import any deposit data files, reporting each invalid entry
+		preActivate := c.Bool(preActivateFlag.Name)
+		for _, path := range c.StringSlice(depositDataFlag.Name) {
+			results, err := server.GetManager().ImportDepositDataFile(path, preActivate)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error importing deposit data: %v", err)
+				os.Exit(1)
+			}
+			imported := 0
+			for _, result := range results {
+				if result.Error != nil {
+					fmt.Fprintf(os.Stderr, "Skipped invalid deposit data in file [%s]: %v\n", path, result.Error)
+					continue
+				}
+				imported++
+			}
+			fmt.Printf("Imported %d of %d entries from deposit data file [%s]\n", imported, len(results), path)
+		}
+
 		// Start it
 		wg := &sync.WaitGroup{}
 		err = server.Start(wg)
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func (s *BeaconMockServer) addFault(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	route, exists := args["route"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing route"))
+		return
+	}
+	faultType, exists := args["type"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing type"))
+		return
+	}
+
+	// Input validation
+	fault := Fault{
+		Type: FaultType(faultType[0]),
+	}
+	var err error
+	if latencyString, exists := args["latency"]; exists {
+		fault.Latency, err = time.ParseDuration(latencyString[0])
+		if err != nil {
+			handleInputError(s.logger, w, fmt.Errorf("invalid latency [%s]: %w", latencyString[0], err))
+			return
+		}
+	}
+	if statusString, exists := args["status"]; exists {
+		fault.StatusCode, err = strconv.Atoi(statusString[0])
+		if err != nil {
+			handleInputError(s.logger, w, fmt.Errorf("invalid status [%s]: %w", statusString[0], err))
+			return
+		}
+	}
+	if countString, exists := args["count"]; exists {
+		fault.Count, err = strconv.ParseUint(countString[0], 10, 64)
+		if err != nil {
+			handleInputError(s.logger, w, fmt.Errorf("invalid count [%s]: %w", countString[0], err))
+			return
+		}
+	}
+	if probabilityString, exists := args["probability"]; exists {
+		fault.Probability, err = strconv.ParseFloat(probabilityString[0], 64)
+		if err != nil {
+			handleInputError(s.logger, w, fmt.Errorf("invalid probability [%s]: %w", probabilityString[0], err))
+			return
+		}
+	}
+
+	// Add the fault
+	err = s.AddFault(route[0], fault)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	handleSuccess(s.logger, w, nil)
+}
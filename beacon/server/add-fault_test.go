@@ -0,0 +1,247 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/rocket-pool/node-manager-core/beacon/client"
+	"github.com/stretchr/testify/require"
+)
+
+// Test injecting status code faults into the next N requests
+func TestStatusFault(t *testing.T) {
+	defer server.ClearFaults()
+
+	// Fail the next 2 sync status requests
+	sendAddFaultRequest(t, api.SyncingRoute, map[string]string{
+		"type":   string(FaultType_Status),
+		"status": "503",
+		"count":  "2",
+	}, http.StatusOK)
+	require.Equal(t, http.StatusServiceUnavailable, getFaultTestStatusCode(t, api.SyncingRoute))
+	require.Equal(t, http.StatusOK, getFaultTestStatusCode(t, api.BeaconGenesisRoute))
+	require.Equal(t, http.StatusServiceUnavailable, getFaultTestStatusCode(t, api.SyncingRoute))
+	t.Log("Injected the fault into the next 2 requests for the route only")
+
+	// The fault is used up now
+	require.Equal(t, http.StatusOK, getFaultTestStatusCode(t, api.SyncingRoute))
+	t.Log("Fault was removed after it was used up")
+}
+
+// Test injecting latency into requests on routes with path parameters
+func TestLatencyFault(t *testing.T) {
+	defer server.ClearFaults()
+
+	latency := 200 * time.Millisecond
+	sendAddFaultRequest(t, api.ValidatorsRoute, map[string]string{
+		"type":    string(FaultType_Latency),
+		"latency": latency.String(),
+		"count":   "1",
+	}, http.StatusOK)
+
+	// The first request is delayed but still succeeds
+	start := time.Now()
+	require.Equal(t, http.StatusOK, getFaultTestStatusCode(t, fmt.Sprintf(api.ValidatorsRouteTemplate, "head")))
+	require.GreaterOrEqual(t, time.Since(start), latency)
+	t.Logf("Request was delayed by %s", time.Since(start))
+
+	// The second isn't
+	start = time.Now()
+	require.Equal(t, http.StatusOK, getFaultTestStatusCode(t, fmt.Sprintf(api.ValidatorsRouteTemplate, "head")))
+	require.Less(t, time.Since(start), latency)
+	t.Log("Second request wasn't delayed")
+}
+
+// Test responding with malformed JSON
+func TestMalformedJsonFault(t *testing.T) {
+	defer server.ClearFaults()
+
+	sendAddFaultRequest(t, api.SyncingRoute, map[string]string{
+		"type":  string(FaultType_MalformedJson),
+		"count": "1",
+	}, http.StatusOK)
+
+	// Get the sync status
+	response, err := http.Get(fmt.Sprintf("http://localhost:%d/eth/%s", port, api.SyncingRoute))
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+
+	// Make sure it can't be parsed
+	var parsedResponse client.SyncStatusResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	require.Error(t, err)
+	t.Logf("Response couldn't be parsed: %v", err)
+}
+
+// Test dropping connections and timing out requests
+func TestDropAndTimeoutFaults(t *testing.T) {
+	defer server.ClearFaults()
+
+	// Use a client that doesn't reuse connections, so dropped requests aren't retried
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			DisableKeepAlives: true,
+		},
+		Timeout: 500 * time.Millisecond,
+	}
+	url := fmt.Sprintf("http://localhost:%d/eth/%s", port, api.SyncingRoute)
+
+	// Drop the connection
+	sendAddFaultRequest(t, api.SyncingRoute, map[string]string{
+		"type":  string(FaultType_Drop),
+		"count": "1",
+	}, http.StatusOK)
+	start := time.Now()
+	_, err := httpClient.Get(url)
+	require.Error(t, err)
+	require.Less(t, time.Since(start), httpClient.Timeout)
+	t.Logf("Connection was dropped: %v", err)
+
+	// Time out
+	sendAddFaultRequest(t, api.SyncingRoute, map[string]string{
+		"type":  string(FaultType_Timeout),
+		"count": "1",
+	}, http.StatusOK)
+	start = time.Now()
+	_, err = httpClient.Get(url)
+	require.Error(t, err)
+	require.GreaterOrEqual(t, time.Since(start), httpClient.Timeout)
+	t.Logf("Request timed out: %v", err)
+
+	// Both faults are used up
+	response, err := httpClient.Get(url)
+	require.NoError(t, err)
+	response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Log("Request succeeded after the faults were used up")
+}
+
+// Test injecting faults into a fraction of the requests on every route
+func TestProbabilisticFault(t *testing.T) {
+	defer server.ClearFaults()
+
+	sendAddFaultRequest(t, AllRoutes, map[string]string{
+		"type":        string(FaultType_Status),
+		"status":      "500",
+		"probability": "0.5",
+	}, http.StatusOK)
+
+	// Roughly half of the requests should fail
+	failures := 0
+	for i := 0; i < 100; i++ {
+		route := api.SyncingRoute
+		if i%2 == 1 {
+			route = api.BeaconGenesisRoute
+		}
+		if getFaultTestStatusCode(t, route) == http.StatusInternalServerError {
+			failures++
+		}
+	}
+	require.Greater(t, failures, 30)
+	require.Less(t, failures, 70)
+	t.Logf("%d of 100 requests failed", failures)
+
+	// Admin routes aren't affected by faults on every route, so they can still clear them
+	sendAddFaultRequest(t, AllRoutes, map[string]string{
+		"type":   string(FaultType_Status),
+		"status": "500",
+	}, http.StatusOK)
+	require.Equal(t, http.StatusInternalServerError, getFaultTestStatusCode(t, api.SyncingRoute))
+	sendClearFaultsRequest(t, api.SyncingRoute)
+	require.Equal(t, http.StatusInternalServerError, getFaultTestStatusCode(t, api.SyncingRoute))
+	sendClearFaultsRequest(t, "")
+	require.Equal(t, http.StatusOK, getFaultTestStatusCode(t, api.SyncingRoute))
+	t.Log("Faults for every route were cleared")
+}
+
+// Test adding invalid faults
+func TestInvalidFaults(t *testing.T) {
+	defer server.ClearFaults()
+
+	sendAddFaultRequest(t, api.SyncingRoute, map[string]string{"type": "explode"}, http.StatusBadRequest)
+	sendAddFaultRequest(t, api.SyncingRoute, map[string]string{"type": string(FaultType_Status), "status": "42"}, http.StatusBadRequest)
+	sendAddFaultRequest(t, api.SyncingRoute, map[string]string{"type": string(FaultType_Latency)}, http.StatusBadRequest)
+	sendAddFaultRequest(t, api.SyncingRoute, map[string]string{"type": string(FaultType_Drop), "probability": "1.5"}, http.StatusBadRequest)
+	sendAddFaultRequest(t, api.SyncingRoute, map[string]string{"type": string(FaultType_Latency), "latency": "soon"}, http.StatusBadRequest)
+	sendAddFaultRequest(t, "v1/node/synching", map[string]string{"type": string(FaultType_Drop)}, http.StatusBadRequest)
+	sendAddFaultRequest(t, fmt.Sprintf(api.ValidatorsRouteTemplate, "head"), map[string]string{"type": string(FaultType_Drop)}, http.StatusBadRequest)
+	sendAddFaultRequest(t, api.AddFaultRoute, map[string]string{"type": string(FaultType_Drop)}, http.StatusBadRequest)
+	require.Equal(t, http.StatusOK, getFaultTestStatusCode(t, api.SyncingRoute))
+	t.Log("Invalid faults were rejected")
+}
+
+// Get the status code of a GET request to an API route
+func getFaultTestStatusCode(t *testing.T, route string) int {
+	response, err := http.Get(fmt.Sprintf("http://localhost:%d/eth/%s", port, route))
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	defer response.Body.Close()
+	t.Logf("Received %d status code for route [%s]", response.StatusCode, route)
+	return response.StatusCode
+}
+
+// Send a request to add a fault to a route
+func sendAddFaultRequest(t *testing.T, route string, args map[string]string, expectedStatus int) {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.AddFaultRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	query.Add("route", route)
+	for key, value := range args {
+		query.Add(key, value)
+	}
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, expectedStatus, response.StatusCode)
+	t.Logf("Received %d status code", response.StatusCode)
+}
+
+// Send a request to clear the faults from a route, or from every route if it's empty
+func sendClearFaultsRequest(t *testing.T, route string) {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.ClearFaultsRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	if route != "" {
+		query := request.URL.Query()
+		query.Add("route", route)
+		request.URL.RawQuery = query.Encode()
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+}
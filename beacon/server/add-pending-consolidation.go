@@ -0,0 +1,49 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func (s *BeaconMockServer) addPendingConsolidation(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	sourceID, exists := args["source"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing source validator ID"))
+		return
+	}
+	targetID, exists := args["target"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing target validator ID"))
+		return
+	}
+
+	// Get the validators
+	source, err := s.manager.GetValidator(sourceID[0])
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	if source == nil {
+		handleInputError(s.logger, w, fmt.Errorf("validator [%s] not found", sourceID[0]))
+		return
+	}
+	target, err := s.manager.GetValidator(targetID[0])
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	if target == nil {
+		handleInputError(s.logger, w, fmt.Errorf("validator [%s] not found", targetID[0]))
+		return
+	}
+
+	// Queue the consolidation
+	_, err = s.manager.AddPendingConsolidation(source.Index, target.Index)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	handleSuccess(s.logger, w, nil)
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+func (s *BeaconMockServer) addPendingPartialWithdrawal(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	id, exists := args["id"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing validator ID"))
+		return
+	}
+	amountString, exists := args["amount"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing amount"))
+		return
+	}
+
+	// Input validation
+	amount, err := strconv.ParseUint(amountString[0], 10, 64)
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("invalid amount [%s]: %w", amountString[0], err))
+		return
+	}
+
+	// Get the validator
+	validator, err := s.manager.GetValidator(id[0])
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	if validator == nil {
+		handleInputError(s.logger, w, fmt.Errorf("validator [%s] not found", id[0]))
+		return
+	}
+
+	// Queue the withdrawal
+	_, err = s.manager.AddPendingPartialWithdrawal(validator.Index, amount)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	handleSuccess(s.logger, w, nil)
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func (s *BeaconMockServer) addToSyncCommittee(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	id, exists := args["id"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing validator ID"))
+		return
+	}
+
+	// Get the validator
+	validator, err := s.manager.GetValidator(id[0])
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	if validator == nil {
+		handleInputError(s.logger, w, fmt.Errorf("validator [%s] not found", id[0]))
+		return
+	}
+
+	// Add it to the committee
+	err = s.manager.AddToSyncCommittee(validator.Index)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	handleSuccess(s.logger, w, nil)
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/goccy/go-json"
+)
+
+// Handle the JSON POST variant of an admin route. The body's fields are converted into the query parameters the GET
+// variant uses, so both variants share the same handler.
+func (s *BeaconMockServer) handleJsonAdminRequest(w http.ResponseWriter, r *http.Request, handler http.HandlerFunc) {
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("error reading request body: %w", err))
+		return
+	}
+
+	args, err := getJsonArgs(bodyBytes)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	query := r.URL.Query()
+	for key, values := range args {
+		query[key] = values
+	}
+	r.URL.RawQuery = query.Encode()
+	handler(w, r)
+}
+
+// Convert a JSON object into query parameters. Fields can be strings, numbers, booleans, or arrays of them; null fields
+// are skipped.
+func getJsonArgs(body []byte) (url.Values, error) {
+	args := url.Values{}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return args, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var fields map[string]any
+	err := decoder.Decode(&fields)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing request body: %w", err)
+	}
+
+	for key, field := range fields {
+		elements, isArray := field.([]any)
+		if !isArray {
+			elements = []any{field}
+		}
+		for _, element := range elements {
+			var value string
+			switch element := element.(type) {
+			case nil:
+				continue
+			case string:
+				value = element
+			case json.Number:
+				value = element.String()
+			case bool:
+				value = strconv.FormatBool(element)
+			default:
+				return nil, fmt.Errorf("invalid value for field [%s]", key)
+			}
+			args.Add(key, value)
+		}
+	}
+	return args, nil
+}
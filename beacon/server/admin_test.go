@@ -0,0 +1,113 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test the JSON POST variants of the admin routes
+func TestJsonAdminRequests(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Set the highest slot and a validator's balance
+	code, _ := sendJsonAdminRequest(t, http.MethodPost, api.SetHighestSlotRoute, `{"slot": 14}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, uint64(14), server.manager.GetHighestSlot())
+	code, _ = sendJsonAdminRequest(t, http.MethodPost, api.SetBalanceRoute, `{"id": "1", "balance": 33000000000}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, uint64(33e9), d.GetValidatorByIndex(1).Balance)
+	t.Log("Set the highest slot and balance with JSON bodies")
+
+	// Errors should have a code and message
+	code, errorResponse := sendJsonAdminRequest(t, http.MethodPost, api.SetHighestSlotRoute, `{}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Equal(t, http.StatusBadRequest, errorResponse.Code)
+	require.Equal(t, "missing slot", errorResponse.Message)
+	code, errorResponse = sendJsonAdminRequest(t, http.MethodPost, api.SetHighestSlotRoute, `{"slot": "a"}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Contains(t, errorResponse.Message, "invalid slot [a]")
+	code, errorResponse = sendJsonAdminRequest(t, http.MethodPost, api.SetHighestSlotRoute, `{"slot": `)
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Contains(t, errorResponse.Message, "error deserializing request body")
+	t.Log("Received structured errors for invalid bodies")
+
+	// Other methods still aren't allowed
+	code, _ = sendJsonAdminRequest(t, http.MethodPut, api.SetHighestSlotRoute, `{"slot": 14}`)
+	require.Equal(t, http.StatusMethodNotAllowed, code)
+	t.Log("PUT wasn't allowed")
+}
+
+// Test converting JSON bodies into query parameters
+func TestJsonArgs(t *testing.T) {
+	args, err := getJsonArgs([]byte(`{"id": ["1", 2], "apr": 0.05, "enabled": false, "route": null}`))
+	require.NoError(t, err)
+	require.Equal(t, url.Values{
+		"id":      []string{"1", "2"},
+		"apr":     []string{"0.05"},
+		"enabled": []string{"false"},
+	}, args)
+
+	args, err = getJsonArgs([]byte(" "))
+	require.NoError(t, err)
+	require.Empty(t, args)
+
+	_, err = getJsonArgs([]byte(`{"identity": {"peer_id": "a"}}`))
+	require.Error(t, err)
+	_, err = getJsonArgs([]byte(`[1, 2]`))
+	require.Error(t, err)
+	t.Log("Converted JSON bodies correctly")
+}
+
+// Send a JSON request to an admin route, returning the status code and the error response if it failed
+func sendJsonAdminRequest(t *testing.T, method string, route string, body string) (int, api.ErrorResponse) {
+	// Create the request
+	request, err := http.NewRequest(method, fmt.Sprintf("http://localhost:%d/admin/%s", port, route), strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	request.Header.Set("Content-Type", api.JsonContentType)
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	defer response.Body.Close()
+	t.Logf("Sent request")
+
+	// Read the error if there was one
+	var errorResponse api.ErrorResponse
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusMethodNotAllowed {
+		bytes, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Fatalf("error reading the response body: %v", err)
+		}
+		err = json.Unmarshal(bytes, &errorResponse)
+		if err != nil {
+			t.Fatalf("error deserializing error response: %v", err)
+		}
+	}
+	t.Logf("Received %d status code", response.StatusCode)
+	return response.StatusCode, errorResponse
+}
@@ -0,0 +1,19 @@
+package server
+
+import (
+	"net/http"
+)
+
+func (s *BeaconMockServer) clearFaults(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+
+	// Remove the faults from the route if one was provided, or from every route otherwise
+	route, exists := args["route"]
+	if exists {
+		s.RemoveFaults(route[0])
+	} else {
+		s.ClearFaults()
+	}
+	handleSuccess(s.logger, w, nil)
+}
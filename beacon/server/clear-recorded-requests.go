@@ -0,0 +1,14 @@
+package server
+
+import (
+	"net/http"
+)
+
+func (s *BeaconMockServer) clearRecordedRequests(w http.ResponseWriter, r *http.Request) {
+	// Log the request
+	s.processApiRequest(w, r, nil)
+
+	// Clear the journal
+	s.manager.ClearRecordedRequests()
+	handleSuccess(s.logger, w, nil)
+}
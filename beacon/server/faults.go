@@ -0,0 +1,239 @@
+package server
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// The kinds of faults that can be injected into API responses
+type FaultType string
+
+const (
+	// Wait for the fault's latency before handling the request normally
+	FaultType_Latency FaultType = "latency"
+
+	// Respond with the fault's status code and an error body instead of handling the request
+	FaultType_Status FaultType = "status"
+
+	// Respond with a 200 status code and a truncated JSON body
+	FaultType_MalformedJson FaultType = "malformed_json"
+
+	// Close the connection without responding
+	FaultType_Drop FaultType = "drop"
+
+	// Never respond, holding the request open until the client gives up or the server stops
+	FaultType_Timeout FaultType = "timeout"
+)
+
+// The route that matches every API route when used to scope a fault
+const AllRoutes string = "*"
+
+// A fault to inject into the responses of an API route
+type Fault struct {
+	// The kind of fault
+	Type FaultType
+
+	// How long to delay the request for latency faults
+	Latency time.Duration
+
+	// The status code to respond with for status faults
+	StatusCode int
+
+	// The number of requests the fault will be injected into before it's removed, or 0 for no limit
+	Count uint64
+
+	// The chance of injecting the fault into each request, from 0 to 1. 0 is treated as 1, so the fault is always
+	// injected.
+	Probability float64
+}
+
+// A fault that's been added to a route, along with how many more requests it can be injected into
+type activeFault struct {
+	Fault
+	remaining uint64
+}
+
+// Injects faults into the responses of API routes
+type faultInjector struct {
+	faults map[string][]*activeFault
+	random *rand.Rand
+	stop   chan struct{}
+	lock   sync.Mutex
+}
+
+// Create a new fault injector. Its random source uses a fixed seed so probabilistic faults are reproducible between
+// runs.
+func newFaultInjector() *faultInjector {
+	return &faultInjector{
+		faults: map[string][]*activeFault{},
+		random: rand.New(rand.NewPCG(1, 1)),
+		stop:   make(chan struct{}),
+	}
+}
+
+// Add a fault to an API route, using its pattern from the api package (such as api.ValidatorsRoute) or AllRoutes.
+// Routes the server doesn't serve are rejected. Faults are checked in the order they were added, and at most one is
+// injected into each request.
+func (s *BeaconMockServer) AddFault(route string, fault Fault) error {
+	if route == "" {
+		return fmt.Errorf("missing route")
+	}
+	if route != AllRoutes && !s.isApiRoute(route) {
+		return fmt.Errorf("unknown route [%s]", route)
+	}
+	switch fault.Type {
+	case FaultType_Latency:
+		if fault.Latency <= 0 {
+			return fmt.Errorf("latency faults require a positive latency")
+		}
+	case FaultType_Status:
+		if fault.StatusCode < 100 || fault.StatusCode > 599 {
+			return fmt.Errorf("invalid status code [%d]", fault.StatusCode)
+		}
+	case FaultType_MalformedJson, FaultType_Drop, FaultType_Timeout:
+	default:
+		return fmt.Errorf("invalid fault type [%s]", fault.Type)
+	}
+	if fault.Probability < 0 || fault.Probability > 1 {
+		return fmt.Errorf("invalid probability [%f]", fault.Probability)
+	}
+
+	s.faults.lock.Lock()
+	defer s.faults.lock.Unlock()
+
+	s.faults.faults[route] = append(s.faults.faults[route], &activeFault{
+		Fault:     fault,
+		remaining: fault.Count,
+	})
+	s.logger.Info("Added fault", "route", route, "type", fault.Type, "count", fault.Count, "probability", fault.Probability)
+	return nil
+}
+
+// Remove the faults from an API route. Faults added with AllRoutes are only removed by using AllRoutes here.
+func (s *BeaconMockServer) RemoveFaults(route string) {
+	s.faults.lock.Lock()
+	defer s.faults.lock.Unlock()
+
+	delete(s.faults.faults, route)
+}
+
+// Remove every fault from every route
+func (s *BeaconMockServer) ClearFaults() {
+	s.faults.lock.Lock()
+	defer s.faults.lock.Unlock()
+
+	s.faults.faults = map[string][]*activeFault{}
+}
+
+// Middleware that injects faults into API requests before they reach their handlers
+func (s *BeaconMockServer) injectFaults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fault := s.getFault(r)
+		if fault == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		s.logger.Warn("Injecting fault", "path", r.URL.Path, "type", fault.Type)
+		switch fault.Type {
+		case FaultType_Latency:
+			select {
+			case <-time.After(fault.Latency):
+				next.ServeHTTP(w, r)
+			case <-r.Context().Done():
+			case <-s.faults.stop:
+			}
+		case FaultType_Status:
+			writeResponse(s.logger, w, fault.StatusCode, formatError(fault.StatusCode, "injected fault"))
+		case FaultType_MalformedJson:
+			writeResponse(s.logger, w, http.StatusOK, []byte(`{"data":{`))
+		case FaultType_Drop:
+			s.dropConnection(w)
+		case FaultType_Timeout:
+			select {
+			case <-r.Context().Done():
+			case <-s.faults.stop:
+			}
+			s.dropConnection(w)
+		}
+	})
+}
+
+// Get the fault to inject into a request, if any, consuming one of its uses
+func (s *BeaconMockServer) getFault(r *http.Request) *Fault {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return nil
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return nil
+	}
+
+	s.faults.lock.Lock()
+	defer s.faults.lock.Unlock()
+
+	for _, key := range []string{strings.TrimPrefix(template, "/eth/"), AllRoutes} {
+		faults := s.faults.faults[key]
+		for i, fault := range faults {
+			if fault.Probability > 0 && s.faults.random.Float64() >= fault.Probability {
+				continue
+			}
+			if fault.Count > 0 {
+				fault.remaining--
+				if fault.remaining == 0 {
+					s.faults.faults[key] = append(faults[:i:i], faults[i+1:]...)
+				}
+			}
+			injected := fault.Fault
+			return &injected
+		}
+	}
+	return nil
+}
+
+// Check if a route is the pattern of one of the server's API routes, such as api.ValidatorsRoute
+func (s *BeaconMockServer) isApiRoute(route string) bool {
+	found := false
+	_ = s.router.Walk(func(muxRoute *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, err := muxRoute.GetPathTemplate()
+		if err == nil && template == "/eth/"+route {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
+
+// Close a request's connection without responding
+func (s *BeaconMockServer) dropConnection(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		s.logger.Error("Can't drop a connection that doesn't support hijacking")
+		return
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		s.logger.Error("Error hijacking connection", "error", err)
+		return
+	}
+	conn.Close()
+}
+
+// Stop holding open any requests with timeout or latency faults
+func (f *faultInjector) close() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	select {
+	case <-f.stop:
+	default:
+		close(f.stop)
+	}
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+func (s *BeaconMockServer) forceFinalization(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	epochString, exists := args["epoch"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing epoch"))
+		return
+	}
+
+	// Input validation
+	epoch, err := strconv.ParseUint(epochString[0], 10, 64)
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("invalid epoch [%s]: %w", epochString[0], err))
+		return
+	}
+
+	// Finalize the epoch
+	err = s.manager.ForceFinalization(epoch)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	handleSuccess(s.logger, w, nil)
+}
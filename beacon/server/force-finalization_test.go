@@ -0,0 +1,69 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test forcing finalization of an epoch
+func TestForceFinalization(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Stall finality and run through 4 epochs
+	d.SetFinalityStalled(true)
+	slotsPerEpoch := server.manager.GetConfig().SlotsPerEpoch
+	for i := uint64(0); i < 4*slotsPerEpoch; i++ {
+		d.CommitBlock(true)
+	}
+	t.Log("Committed 4 epochs of blocks with finality stalled")
+
+	// Force finalization
+	sendForceFinalizationRequest(t, 3)
+
+	// Make sure the response is correct
+	parsedResponse := getFinalityCheckpointsResponse(t)
+	require.Equal(t, uint64(3), uint64(parsedResponse.Data.CurrentJustified.Epoch))
+	require.Equal(t, uint64(3), uint64(parsedResponse.Data.Finalized.Epoch))
+	require.Equal(t, d.GetFinalityCheckpoints().Finalized.Root, parsedResponse.Data.Finalized.Root)
+	t.Logf("Received correct response - justified: %d, finalized: %d", parsedResponse.Data.CurrentJustified.Epoch, parsedResponse.Data.Finalized.Epoch)
+}
+
+func sendForceFinalizationRequest(t *testing.T, epoch uint64) {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.ForceFinalizationRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	query.Add("epoch", strconv.FormatUint(epoch, 10))
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+}
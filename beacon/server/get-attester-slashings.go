@@ -0,0 +1,12 @@
+package server
+
+import (
+	"net/http"
+)
+
+// Handle a get attester slashings request
+func (s *BeaconMockServer) getAttesterSlashings(w http.ResponseWriter, r *http.Request) {
+	// Log the request
+	s.processApiRequest(w, r, nil)
+	handleSuccess(s.logger, w, s.manager.GetAttesterSlashingsResponse())
+}
@@ -7,8 +7,12 @@ import (
 
 // Handle a get beacon genesis request
 func (s *BeaconMockServer) getBeaconGenesis(w http.ResponseWriter, r *http.Request) {
-	// Get the request vars
-	_ = s.processApiRequest(w, r, nil)
+	// Log the request
+	s.processApiRequest(w, r, nil)
+	if acceptsSsz(r) {
+		handleSszSuccess(s.logger, w, s.manager.GetBeaconGenesisSsz())
+		return
+	}
 	response, err := s.manager.Beacon_Genesis(context.Background())
 	if err != nil {
 		handleServerError(s.logger, w, err)
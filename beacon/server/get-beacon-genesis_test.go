@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/binary"
 	"fmt"
 	"io"
 	"net/http"
@@ -44,6 +45,20 @@ func TestBeaconGenesis(t *testing.T) {
 	)
 }
 
+// Test getting the Beacon genesis as SSZ
+func TestBeaconGenesisSsz(t *testing.T) {
+	// Send a request
+	_, data := getSszResponse(t, api.BeaconGenesisRoute)
+
+	// Make sure the response is correct
+	cfg := server.manager.GetConfig()
+	require.Len(t, data, 44)
+	require.Equal(t, uint64(cfg.GenesisTime.Unix()), binary.LittleEndian.Uint64(data[0:8]))
+	require.Equal(t, []byte(cfg.GenesisValidatorsRoot), data[8:40])
+	require.Equal(t, []byte(cfg.GenesisForkVersion), data[40:44])
+	t.Log("Received correct SSZ response")
+}
+
 func getBeaconGenesisResponse(t *testing.T) client.GenesisResponse {
 	// Create the request
 	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, api.BeaconGenesisRoute), nil)
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/nodeset-org/osha/beacon/api"
+)
+
+// Handle a get block header request
+func (s *BeaconMockServer) getBlockHeader(w http.ResponseWriter, r *http.Request) {
+	// Log the request
+	s.processApiRequest(w, r, nil)
+	vars := mux.Vars(r)
+	id, exists := vars[api.BlockID]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing block ID"))
+		return
+	}
+
+	// Get the block
+	block, err := s.manager.GetBlock(id)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	if block == nil {
+		handleNotFound(s.logger, w, fmt.Errorf("block [%s] not found", id))
+		return
+	}
+
+	// Write the response
+	response := s.manager.GetBlockHeaderResponse(block)
+	handleSuccess(s.logger, w, response)
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test getting block headers by slot, root, and name
+func TestBlockHeader(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Commit a few blocks
+	for i := 0; i < 3; i++ {
+		d.CommitBlock(true)
+	}
+	t.Log("Committed 3 blocks")
+
+	// Get the header for slot 1
+	block := d.GetBlockBySlot(1)
+	parsedResponse := getBlockHeaderResponse(t, "1")
+	require.Equal(t, block.Root, parsedResponse.Data.Root)
+	require.True(t, parsedResponse.Data.Canonical)
+	require.Equal(t, uint64(1), uint64(parsedResponse.Data.Header.Message.Slot))
+	require.Equal(t, strconv.FormatUint(block.ProposerIndex, 10), parsedResponse.Data.Header.Message.ProposerIndex)
+	require.Equal(t, d.GetBlockBySlot(0).Root, parsedResponse.Data.Header.Message.ParentRoot)
+	require.Equal(t, block.StateRoot, parsedResponse.Data.Header.Message.StateRoot)
+	require.Equal(t, block.BodyRoot, parsedResponse.Data.Header.Message.BodyRoot)
+	t.Logf("Received correct response for slot 1 - root: %s", parsedResponse.Data.Root.Hex())
+
+	// Get the same header by its root
+	parsedResponse = getBlockHeaderResponse(t, block.Root.Hex())
+	require.Equal(t, uint64(1), uint64(parsedResponse.Data.Header.Message.Slot))
+	t.Log("Received correct response for the block root")
+
+	// Get the head
+	parsedResponse = getBlockHeaderResponse(t, "head")
+	require.Equal(t, uint64(2), uint64(parsedResponse.Data.Header.Message.Slot))
+	require.Equal(t, d.GetBlockBySlot(2).Root, parsedResponse.Data.Root)
+	t.Log("Received correct response for the head")
+}
+
+// Round trip a block header request
+func getBlockHeaderResponse(t *testing.T, blockID string) api.BlockHeaderResponse {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, fmt.Sprintf(api.BlockHeaderRouteTemplate, blockID)), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.BlockHeaderResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/nodeset-org/osha/beacon/ssz"
+	"github.com/stretchr/testify/require"
+)
+
+// Test getting a full block
+func TestBlock(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Commit a block, miss a slot, then commit another
+	d.CommitBlock(true)
+	d.CommitBlock(false)
+	d.CommitBlock(true)
+	t.Log("Committed 2 blocks with a missed slot between them")
+
+	// Get the latest block
+	config := server.manager.GetConfig()
+	block := d.GetBlockBySlot(2)
+	parsedResponse := getBlockResponse(t, "2")
+	require.Equal(t, "deneb", parsedResponse.Version)
+	require.Equal(t, uint64(2), uint64(parsedResponse.Data.Message.Slot))
+	require.Equal(t, d.GetBlockBySlot(0).Root, parsedResponse.Data.Message.ParentRoot)
+	require.Equal(t, block.StateRoot, parsedResponse.Data.Message.StateRoot)
+	require.Equal(t, block.ExecutionBlockNumber, uint64(parsedResponse.Data.Message.Body.ExecutionPayload.BlockNumber))
+	require.Equal(t, uint64(config.GenesisTime.Unix())+2*config.SecondsPerSlot, uint64(parsedResponse.Data.Message.Body.ExecutionPayload.Timestamp))
+	t.Logf("Received correct response - slot: %d, execution block: %d", parsedResponse.Data.Message.Slot, parsedResponse.Data.Message.Body.ExecutionPayload.BlockNumber)
+
+	// Link it to an Execution block
+	hash := common.HexToHash("0x1234")
+	timestamp := uint64(config.GenesisTime.Unix()) + 2*config.SecondsPerSlot + 5
+	err := d.SetExecutionBlockDetails(2, block.ExecutionBlockNumber, hash, timestamp, common.Address{})
+	require.NoError(t, err)
+	parsedResponse = getBlockResponse(t, "2")
+	require.Equal(t, hash, parsedResponse.Data.Message.Body.ExecutionPayload.BlockHash)
+	require.Equal(t, timestamp, uint64(parsedResponse.Data.Message.Body.ExecutionPayload.Timestamp))
+	require.Equal(t, api.Eth1Data{}, parsedResponse.Data.Message.Body.Eth1Data)
+	t.Log("Payload uses the linked Execution block's details")
+
+	// Make sure the missed slot isn't found
+	require.Equal(t, http.StatusNotFound, getBlockStatusCode(t, "1"))
+	t.Log("Missed slot returned 404 as expected")
+
+	// Make sure future slots aren't found
+	require.Equal(t, http.StatusNotFound, getBlockStatusCode(t, "100"))
+	t.Log("Future slot returned 404 as expected")
+}
+
+// Test getting the withdrawals in a block's execution payload
+func TestBlockWithdrawals(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database with a validator that has excess balance
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+	v, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 0)
+	v.Balance = 32.5e9
+	d.CommitBlock(true)
+	t.Log("Committed a block")
+
+	// Check the withdrawals
+	parsedResponse := getBlockResponse(t, "head")
+	withdrawals := parsedResponse.Data.Message.Body.ExecutionPayload.Withdrawals
+	require.Len(t, withdrawals, 1)
+	require.Equal(t, v.Index, uint64(withdrawals[0].ValidatorIndex))
+	require.Equal(t, v.GetWithdrawalAddress(), withdrawals[0].Address)
+	require.Equal(t, uint64(0.5e9), uint64(withdrawals[0].Amount))
+	t.Logf("Received correct response - withdrew %d gwei to %s", withdrawals[0].Amount, withdrawals[0].Address.Hex())
+}
+
+// Test getting a block as SSZ
+func TestBlockSsz(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+	d.CommitBlock(true)
+	d.CommitBlock(true)
+	block := d.GetBlockBySlot(1)
+	t.Log("Committed 2 blocks")
+
+	// Get the block as SSZ
+	response, data := getSszResponse(t, fmt.Sprintf(api.BlockRouteTemplate, "1"))
+	require.Equal(t, "deneb", response.Header.Get(api.ConsensusVersionHeader))
+
+	// The message comes after its offset and the signature, and starts with the slot, proposer index, parent root, and
+	// state root
+	messageOffset := binary.LittleEndian.Uint32(data[0:4])
+	require.Equal(t, uint32(ssz.OffsetSize+96), messageOffset)
+	message := data[messageOffset:]
+	require.Equal(t, block.Slot, binary.LittleEndian.Uint64(message[0:8]))
+	require.Equal(t, block.ProposerIndex, binary.LittleEndian.Uint64(message[8:16]))
+	require.Equal(t, block.ParentRoot[:], message[16:48])
+	require.Equal(t, block.StateRoot[:], message[48:80])
+	t.Logf("Received correct SSZ block - size: %d bytes", len(data))
+
+	// JSON responses should have the version header too
+	jsonResponse := sendBlockRequest(t, "1")
+	jsonResponse.Body.Close()
+	require.Equal(t, api.JsonContentType, jsonResponse.Header.Get("Content-Type"))
+	require.Equal(t, "deneb", jsonResponse.Header.Get(api.ConsensusVersionHeader))
+	t.Log("JSON response had the consensus version header")
+}
+
+// Round trip a block request
+func getBlockResponse(t *testing.T, blockID string) api.BlockResponse {
+	// Send the request
+	response := sendBlockRequest(t, blockID)
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.BlockResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
+
+// Send a block request and get the status code of the response
+func getBlockStatusCode(t *testing.T, blockID string) int {
+	response := sendBlockRequest(t, blockID)
+	response.Body.Close()
+	return response.StatusCode
+}
+
+// Send a block request
+func sendBlockRequest(t *testing.T, blockID string) *http.Response {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, fmt.Sprintf(api.BlockRouteTemplate, blockID)), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+	return response
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/rocket-pool/node-manager-core/beacon/client"
+)
+
+// Handle a get BLS-to-execution changes request
+func (s *BeaconMockServer) getBlsToExecutionChanges(w http.ResponseWriter, r *http.Request) {
+	// Log the request
+	s.processApiRequest(w, r, nil)
+
+	// Get the changes
+	changes := s.manager.GetBlsToExecutionChanges()
+	response := api.BlsToExecutionChangesResponse{
+		Data: make([]client.BLSToExecutionChangeRequest, len(changes)),
+	}
+	for i, change := range changes {
+		response.Data[i] = client.BLSToExecutionChangeRequest{
+			Message: client.BLSToExecutionChangeMessage{
+				ValidatorIndex:     strconv.FormatUint(change.ValidatorIndex, 10),
+				FromBLSPubkey:      change.FromBlsPubkey[:],
+				ToExecutionAddress: change.ToExecutionAddress[:],
+			},
+			Signature: change.Signature[:],
+		}
+	}
+	handleSuccess(s.logger, w, response)
+}
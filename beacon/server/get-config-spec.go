@@ -1,18 +1,16 @@
 package server
 
 import (
-	"context"
 	"net/http"
 )
 
 // Handle a get config spec request
 func (s *BeaconMockServer) getConfigSpec(w http.ResponseWriter, r *http.Request) {
-	// Get the request vars
-	_ = s.processApiRequest(w, r, nil)
-	response, err := s.manager.Config_Spec(context.Background())
-	if err != nil {
-		handleServerError(s.logger, w, err)
+	// Log the request
+	s.processApiRequest(w, r, nil)
+	if acceptsSsz(r) {
+		handleSszSuccess(s.logger, w, s.manager.GetConfigSpecSsz())
 		return
 	}
-	handleSuccess(s.logger, w, response)
+	handleSuccess(s.logger, w, s.manager.GetConfigSpecResponse())
 }
@@ -1,16 +1,20 @@
 package server
 
 import (
+	"context"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"testing"
 
 	"github.com/goccy/go-json"
 
 	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	"github.com/nodeset-org/osha/beacon/ssz"
 
-	"github.com/rocket-pool/node-manager-core/beacon/client"
 	"github.com/rocket-pool/node-manager-core/utils"
 	"github.com/stretchr/testify/require"
 )
@@ -31,20 +35,54 @@ func TestConfigSpec(t *testing.T) {
 
 	// Make sure the response is correct
 	cfg := server.manager.GetConfig()
-	require.Equal(t, cfg.CapellaForkVersion, parsedResponse.Data.CapellaForkVersion)
-	require.Equal(t, cfg.SecondsPerSlot, uint64(parsedResponse.Data.SecondsPerSlot))
-	require.Equal(t, cfg.SlotsPerEpoch, uint64(parsedResponse.Data.SlotsPerEpoch))
-	require.Equal(t, cfg.EpochsPerSyncCommitteePeriod, uint64(parsedResponse.Data.EpochsPerSyncCommitteePeriod))
-	t.Logf(
-		"Received correct response - seconds per slot: %d, slots per epoch: %d, epochs per sync committee: %d, capella fork: %s",
-		uint64(parsedResponse.Data.SecondsPerSlot),
-		uint64(parsedResponse.Data.SlotsPerEpoch),
-		uint64(parsedResponse.Data.EpochsPerSyncCommitteePeriod),
-		utils.EncodeHexWithPrefix(parsedResponse.Data.CapellaForkVersion),
-	)
+	require.Equal(t, utils.EncodeHexWithPrefix(cfg.CapellaForkVersion), parsedResponse.Data["CAPELLA_FORK_VERSION"])
+	require.Equal(t, utils.EncodeHexWithPrefix(cfg.ElectraForkVersion), parsedResponse.Data["ELECTRA_FORK_VERSION"])
+	require.Equal(t, strconv.FormatUint(cfg.ElectraForkEpoch, 10), parsedResponse.Data["ELECTRA_FORK_EPOCH"])
+	require.Equal(t, strconv.FormatUint(cfg.SecondsPerSlot, 10), parsedResponse.Data["SECONDS_PER_SLOT"])
+	require.Equal(t, strconv.FormatUint(cfg.SlotsPerEpoch, 10), parsedResponse.Data["SLOTS_PER_EPOCH"])
+	require.Equal(t, strconv.FormatUint(cfg.EpochsPerSyncCommitteePeriod, 10), parsedResponse.Data["EPOCHS_PER_SYNC_COMMITTEE_PERIOD"])
+	require.Equal(t, strconv.FormatUint(db.MaxEffectiveBalanceElectra, 10), parsedResponse.Data["MAX_EFFECTIVE_BALANCE_ELECTRA"])
+	require.Equal(t, "0x04000000", parsedResponse.Data["DOMAIN_VOLUNTARY_EXIT"])
+	require.Equal(t, cfg.DepositContract.Hex(), parsedResponse.Data["DEPOSIT_CONTRACT_ADDRESS"])
+	t.Logf("Received correct response with %d values", len(parsedResponse.Data))
+
+	// Make sure the client's response type can still be parsed from it
+	clientResponse, err := server.manager.Config_Spec(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, cfg.CapellaForkVersion, clientResponse.Data.CapellaForkVersion)
+	require.Equal(t, cfg.SlotsPerEpoch, uint64(clientResponse.Data.SlotsPerEpoch))
+	t.Log("Client response was parsed correctly")
+}
+
+// Test getting the config spec as SSZ
+func TestConfigSpecSsz(t *testing.T) {
+	// Send a request
+	_, data := getSszResponse(t, api.ConfigSpecRoute)
+	spec := getConfigSpecResponse(t).Data
+
+	// The list has an offset for each key-value pair
+	count := int(binary.LittleEndian.Uint32(data[0:4])) / ssz.OffsetSize
+	require.Equal(t, len(spec), count)
+
+	// Each pair is a container of the key and value, sorted by key
+	previousKey := ""
+	for i := 0; i < count; i++ {
+		start := binary.LittleEndian.Uint32(data[i*ssz.OffsetSize:])
+		end := uint32(len(data))
+		if i < count-1 {
+			end = binary.LittleEndian.Uint32(data[(i+1)*ssz.OffsetSize:])
+		}
+		element := data[start:end]
+		valueOffset := binary.LittleEndian.Uint32(element[4:8])
+		key := string(element[2*ssz.OffsetSize : valueOffset])
+		require.Greater(t, key, previousKey)
+		require.Equal(t, spec[key], string(element[valueOffset:]))
+		previousKey = key
+	}
+	t.Logf("Received correct SSZ response with %d values", count)
 }
 
-func getConfigSpecResponse(t *testing.T) client.Eth2ConfigResponse {
+func getConfigSpecResponse(t *testing.T) api.ConfigSpecResponse {
 	// Create the request
 	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, api.ConfigSpecRoute), nil)
 	if err != nil {
@@ -69,7 +107,7 @@ func getConfigSpecResponse(t *testing.T) client.Eth2ConfigResponse {
 	if err != nil {
 		t.Fatalf("error reading the response body: %v", err)
 	}
-	var parsedResponse client.Eth2ConfigResponse
+	var parsedResponse api.ConfigSpecResponse
 	err = json.Unmarshal(bytes, &parsedResponse)
 	if err != nil {
 		t.Fatalf("error deserializing response: %v", err)
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/nodeset-org/osha/beacon/api"
+)
+
+// Handle a get debug state request. The mock only provides the state as SSZ, since the JSON form is too large to be
+// useful for testing.
+func (s *BeaconMockServer) getDebugState(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	if args == nil {
+		return
+	}
+	if !acceptsSsz(r) {
+		handleNotAcceptable(s.logger, w, fmt.Errorf("states are only available as SSZ, use an Accept header of [%s]", api.SszContentType))
+		return
+	}
+	state := s.getState(w, r)
+	if state == nil {
+		return
+	}
+
+	// Get the response
+	w.Header().Set(api.ConsensusVersionHeader, s.manager.GetForkName(state.Slot))
+	handleSszSuccess(s.logger, w, s.manager.GetStateSsz(state))
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/nodeset-org/osha/beacon/ssz"
+	"github.com/stretchr/testify/require"
+)
+
+// Test getting a state as SSZ
+func TestDebugState(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+	d.CommitBlock(true)
+	d.CommitBlock(true)
+	block := d.GetBlockBySlot(1)
+	state := d.GetState(1)
+	t.Log("Committed 2 blocks")
+
+	// Get the state as SSZ
+	response, data := getSszResponse(t, fmt.Sprintf(api.DebugStateRouteTemplate, "1"))
+	require.Equal(t, "deneb", response.Header.Get(api.ConsensusVersionHeader))
+
+	// The genesis time and validators root come before the slot, fork, and latest block header. The block was proposed
+	// in the state's slot, so the header doesn't have a state root yet.
+	require.Equal(t, state.Slot, binary.LittleEndian.Uint64(data[40:48]))
+	fork := server.manager.GetConfig().GetFork(0)
+	require.Equal(t, []byte(fork.CurrentVersion), data[52:56])
+	header := data[64:176]
+	require.Equal(t, block.Slot, binary.LittleEndian.Uint64(header[0:8]))
+	require.Equal(t, block.ProposerIndex, binary.LittleEndian.Uint64(header[8:16]))
+	require.Equal(t, block.ParentRoot[:], header[16:48])
+	require.Equal(t, make([]byte, 32), header[48:80])
+	require.Equal(t, block.BodyRoot[:], header[80:112])
+
+	// The validators and balances come after the block and state roots, the historical roots' offset, the Eth1 data,
+	// the Eth1 data votes' offset, and the deposit index
+	validatorsOffsetPosition := 176 + 2*8192*32 + ssz.OffsetSize + 72 + ssz.OffsetSize + 8
+	validatorsOffset := binary.LittleEndian.Uint32(data[validatorsOffsetPosition:])
+	balancesOffset := binary.LittleEndian.Uint32(data[validatorsOffsetPosition+ssz.OffsetSize:])
+	validators := data[validatorsOffset:balancesOffset]
+	require.Len(t, validators, len(state.Validators)*121)
+	require.Equal(t, state.Validators[0].Pubkey[:], validators[0:48])
+	require.Equal(t, state.Validators[0].Balance, binary.LittleEndian.Uint64(data[balancesOffset:]))
+	t.Logf("Received correct SSZ state - size: %d bytes", len(data))
+
+	// JSON isn't supported
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, fmt.Sprintf(api.DebugStateRouteTemplate, "1")), nil)
+	require.NoError(t, err)
+	jsonResponse, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	jsonResponse.Body.Close()
+	require.Equal(t, http.StatusNotAcceptable, jsonResponse.StatusCode)
+	t.Log("JSON request was rejected")
+}
@@ -7,8 +7,8 @@ import (
 
 // Handle a get deposit contract request
 func (s *BeaconMockServer) getDepositContract(w http.ResponseWriter, r *http.Request) {
-	// Get the request vars
-	_ = s.processApiRequest(w, r, nil)
+	// Log the request
+	s.processApiRequest(w, r, nil)
 	response, err := s.manager.Config_DepositContract(context.Background())
 	if err != nil {
 		handleServerError(s.logger, w, err)
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+// Handle an event stream subscription, writing events as server-sent events until the client disconnects or the
+// subscription is closed
+func (s *BeaconMockServer) getEvents(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars, which can be repeated or comma-separated
+	args := s.processApiRequest(w, r, nil)
+	topics := []string{}
+	for _, value := range args["topics"] {
+		topics = append(topics, strings.Split(value, ",")...)
+	}
+
+	// Subscribe to the topics
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		handleServerError(s.logger, w, fmt.Errorf("response writer doesn't support streaming"))
+		return
+	}
+	subscription, err := s.manager.SubscribeEvents(topics)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	defer subscription.Close()
+
+	// Start the stream
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	s.logger.Info("Started event stream", "topics", topics)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			s.logger.Info("Event stream client disconnected")
+			return
+		case event, ok := <-subscription.Events():
+			if !ok {
+				s.logger.Info("Event stream closed")
+				return
+			}
+			bytes, err := json.Marshal(event.Data)
+			if err != nil {
+				s.logger.Error("Error serializing event", "topic", event.Topic, log.Err(err))
+				return
+			}
+			_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Topic, bytes)
+			if err != nil {
+				s.logger.Info("Event stream client disconnected", log.Err(err))
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/rocket-pool/node-manager-core/beacon/client"
+	"github.com/stretchr/testify/require"
+)
+
+// Test streaming events
+func TestEvents(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database, letting validators exit right away
+	config := db.NewDefaultConfig()
+	config.ShardCommitteePeriod = 0
+	d := idb.ProvisionDatabaseWithConfigForTesting(t, logger, config)
+	server.manager.SetDatabase(d)
+	v, key := idb.AddActiveValidatorWithKeyForTesting(t, d, 0)
+
+	// Open the stream
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	response := sendEventsRequest(t, ctx, "head,block", api.FinalizedCheckpointTopic, api.VoluntaryExitTopic)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+	reader := bufio.NewReader(response.Body)
+	t.Log("Opened event stream")
+
+	// Commit a block
+	sendCommitBlockRequest(t, true)
+	block := d.GetBlockBySlot(0)
+	var blockEvent api.BlockEvent
+	readEvent(t, reader, api.BlockTopic, &blockEvent)
+	require.Equal(t, uint64(0), uint64(blockEvent.Slot))
+	require.Equal(t, block.Root, blockEvent.Block)
+	var headEvent api.HeadEvent
+	readEvent(t, reader, api.HeadTopic, &headEvent)
+	require.Equal(t, block.Root, headEvent.Block)
+	require.Equal(t, block.StateRoot, headEvent.State)
+	require.True(t, headEvent.EpochTransition)
+	t.Log("Received block and head events")
+
+	// Finish the epoch and finalize it
+	for d.GetCurrentSlot() < server.manager.GetConfig().SlotsPerEpoch {
+		d.CommitBlock(false)
+	}
+	sendForceFinalizationRequest(t, 0)
+	var finalizedEvent api.FinalizedCheckpointEvent
+	readEvent(t, reader, api.FinalizedCheckpointTopic, &finalizedEvent)
+	require.Equal(t, uint64(0), uint64(finalizedEvent.Epoch))
+	require.Equal(t, block.Root, finalizedEvent.Block)
+	require.Equal(t, block.StateRoot, finalizedEvent.State)
+	t.Log("Received finalized checkpoint event")
+
+	// Exit a validator
+	request := createVoluntaryExitRequest(t, key, v.Index, 0)
+	sendVoluntaryExitRequest(t, request, http.StatusOK)
+	var exitEvent client.VoluntaryExitRequest
+	readEvent(t, reader, api.VoluntaryExitTopic, &exitEvent)
+	require.Equal(t, request, exitEvent)
+	t.Log("Received voluntary exit event")
+}
+
+// Test subscribing to invalid topics
+func TestInvalidEventTopics(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response := sendEventsRequest(t, ctx)
+	response.Body.Close()
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	response = sendEventsRequest(t, ctx, api.HeadTopic, "unknown")
+	response.Body.Close()
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	t.Log("Invalid topics were rejected")
+}
+
+// Test that subscribers that don't keep up get disconnected instead of blocking the chain
+func TestSlowEventSubscriber(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Subscribe without reading anything from one of them
+	slow, err := server.manager.SubscribeEvents([]string{api.BlockTopic})
+	require.NoError(t, err)
+	other, err := server.manager.SubscribeEvents([]string{api.FinalizedCheckpointTopic})
+	require.NoError(t, err)
+	defer other.Close()
+	for i := 0; i < 100; i++ {
+		server.manager.CommitBlock(true)
+	}
+
+	// Drain the buffered events, which ends when the channel is closed
+	count := 0
+	for range slow.Events() {
+		count++
+	}
+	require.Less(t, count, 100)
+	t.Logf("Slow subscriber was disconnected after %d events", count)
+
+	// Subscribers that kept up are unaffected
+	event, ok := <-other.Events()
+	require.True(t, ok)
+	require.Equal(t, api.FinalizedCheckpointTopic, event.Topic)
+	t.Log("Other subscriber is still connected")
+
+	// Closing a disconnected subscription is harmless
+	slow.Close()
+}
+
+// Open an event stream for the given topics
+func sendEventsRequest(t *testing.T, ctx context.Context, topics ...string) *http.Response {
+	// Create the request
+	query := url.Values{}
+	for _, topic := range topics {
+		query.Add("topics", topic)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s?%s", port, api.EventsRoute, query.Encode()), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+	return response
+}
+
+// Read the next event from a stream, check its topic, and deserialize its data
+func readEvent(t *testing.T, reader *bufio.Reader, expectedTopic string, data any) {
+	var topic string
+	var body string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("error reading event: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			break
+		}
+		name, value, _ := strings.Cut(line, ": ")
+		switch name {
+		case "event":
+			topic = value
+		case "data":
+			body = value
+		}
+	}
+	require.Equal(t, expectedTopic, topic)
+	err := json.Unmarshal([]byte(body), data)
+	if err != nil {
+		t.Fatalf("error deserializing event: %v", err)
+	}
+	t.Logf("Read %s event", topic)
+}
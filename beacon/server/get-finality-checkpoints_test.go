@@ -0,0 +1,85 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test getting the finality checkpoints
+func TestFinalityCheckpoints(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Run through 4 epochs
+	slotsPerEpoch := server.manager.GetConfig().SlotsPerEpoch
+	for i := uint64(0); i < 4*slotsPerEpoch; i++ {
+		d.CommitBlock(true)
+	}
+	t.Log("Committed 4 epochs of blocks")
+
+	// Send a finality checkpoints request
+	parsedResponse := getFinalityCheckpointsResponse(t)
+
+	// Make sure the response is correct
+	checkpoints := d.GetFinalityCheckpoints()
+	require.Equal(t, uint64(2), uint64(parsedResponse.Data.PreviousJustified.Epoch))
+	require.Equal(t, uint64(3), uint64(parsedResponse.Data.CurrentJustified.Epoch))
+	require.Equal(t, uint64(2), uint64(parsedResponse.Data.Finalized.Epoch))
+	require.Equal(t, checkpoints.PreviousJustified.Root, parsedResponse.Data.PreviousJustified.Root)
+	require.Equal(t, checkpoints.CurrentJustified.Root, parsedResponse.Data.CurrentJustified.Root)
+	require.Equal(t, checkpoints.Finalized.Root, parsedResponse.Data.Finalized.Root)
+	t.Logf("Received correct response - justified: %d, finalized: %d", parsedResponse.Data.CurrentJustified.Epoch, parsedResponse.Data.Finalized.Epoch)
+}
+
+// Round trip a finality checkpoints request
+func getFinalityCheckpointsResponse(t *testing.T) api.FinalityCheckpointsResponse {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, fmt.Sprintf(api.FinalityCheckpointsRouteTemplate, "head")), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.FinalityCheckpointsResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/stretchr/testify/require"
+)
+
+// Test getting the fork schedule
+func TestForkSchedule(t *testing.T) {
+	// Send a request
+	parsedResponse := getForkScheduleResponse(t)
+
+	// Make sure the response is correct
+	schedule := server.manager.GetConfig().GetForkSchedule()
+	require.Len(t, parsedResponse.Data, len(schedule))
+	for i, fork := range schedule {
+		require.Equal(t, fork.PreviousVersion, parsedResponse.Data[i].PreviousVersion)
+		require.Equal(t, fork.CurrentVersion, parsedResponse.Data[i].CurrentVersion)
+		require.Equal(t, fork.Epoch, uint64(parsedResponse.Data[i].Epoch))
+	}
+	t.Logf("Received correct response with %d forks", len(parsedResponse.Data))
+}
+
+func getForkScheduleResponse(t *testing.T) api.ForkScheduleResponse {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, api.ForkScheduleRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.ForkScheduleResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
@@ -0,0 +1,28 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Handle a get node health request
+func (s *BeaconMockServer) getNodeHealth(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	syncingStatus := 0
+	syncingStatusString, exists := args["syncing_status"]
+	if exists {
+		// Input validation
+		var err error
+		syncingStatus, err = strconv.Atoi(syncingStatusString[0])
+		if err != nil || syncingStatus < 100 || syncingStatus > 599 {
+			handleInputError(s.logger, w, fmt.Errorf("invalid syncing status [%s]", syncingStatusString[0]))
+			return
+		}
+	}
+
+	// Respond with the status code and no body
+	status := s.manager.GetNodeHealthStatus(syncingStatus)
+	writeResponse(s.logger, w, status, []byte{})
+}
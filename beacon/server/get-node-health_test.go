@@ -0,0 +1,97 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test the node health as the sync status and health override change
+func TestNodeHealth(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// The node starts healthy, then reports syncing once it falls behind
+	require.Equal(t, http.StatusOK, getNodeHealthStatusCode(t, ""))
+	sendSetHighestSlotRequest(t, 10)
+	require.Equal(t, http.StatusPartialContent, getNodeHealthStatusCode(t, ""))
+	require.Equal(t, http.StatusOK, getNodeHealthStatusCode(t, "200"))
+	require.Equal(t, http.StatusBadRequest, getNodeHealthStatusCode(t, "abc"))
+	t.Log("Health followed the sync status")
+
+	// Override the status
+	sendSetNodeHealthRequest(t, http.StatusServiceUnavailable, http.StatusOK)
+	require.Equal(t, http.StatusServiceUnavailable, getNodeHealthStatusCode(t, ""))
+	sendSetNodeHealthRequest(t, http.StatusOK, http.StatusOK)
+	require.Equal(t, http.StatusOK, getNodeHealthStatusCode(t, ""))
+	t.Log("Health used the override")
+
+	// Clear the override, and make sure invalid ones are rejected
+	sendSetNodeHealthRequest(t, 0, http.StatusOK)
+	require.Equal(t, http.StatusPartialContent, getNodeHealthStatusCode(t, ""))
+	sendSetNodeHealthRequest(t, http.StatusTeapot, http.StatusBadRequest)
+	t.Log("Health override was cleared and invalid overrides were rejected")
+}
+
+// Get the status code of a node health request, optionally with a custom syncing status
+func getNodeHealthStatusCode(t *testing.T, syncingStatus string) int {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, api.NodeHealthRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	if syncingStatus != "" {
+		query := request.URL.Query()
+		query.Add("syncing_status", syncingStatus)
+		request.URL.RawQuery = query.Encode()
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	defer response.Body.Close()
+	t.Logf("Received %d status code", response.StatusCode)
+	return response.StatusCode
+}
+
+// Send a request to set the node health status
+func sendSetNodeHealthRequest(t *testing.T, status int, expectedStatus int) {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.SetNodeHealthRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	query.Add("status", strconv.Itoa(status))
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, expectedStatus, response.StatusCode)
+	t.Logf("Received %d status code", response.StatusCode)
+}
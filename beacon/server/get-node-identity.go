@@ -0,0 +1,15 @@
+package server
+
+import (
+	"net/http"
+)
+
+// Handle a get node identity request
+func (s *BeaconMockServer) getNodeIdentity(w http.ResponseWriter, r *http.Request) {
+	// Log the request
+	s.processApiRequest(w, r, nil)
+
+	// Get the response
+	response := s.manager.GetNodeIdentityResponse()
+	handleSuccess(s.logger, w, response)
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test getting and setting the node identity
+func TestNodeIdentity(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Check the default identity
+	parsedResponse := getNodeIdentityResponse(t)
+	defaultIdentity := d.GetNodeInfo().Identity
+	require.Equal(t, defaultIdentity.PeerID, parsedResponse.Data.PeerID)
+	require.Equal(t, defaultIdentity.Enr, parsedResponse.Data.Enr)
+	require.Equal(t, defaultIdentity.P2PAddresses, parsedResponse.Data.P2PAddresses)
+	require.Len(t, parsedResponse.Data.Metadata.Attnets, 8)
+	t.Logf("Received the default identity with peer ID [%s]", parsedResponse.Data.PeerID)
+
+	// Change it
+	peerID := db.GetMockPeerID(100)
+	sendSetNodeIdentityRequest(t, peerID, "enr:-custom", []string{"/ip4/1.2.3.4/tcp/9000", "/ip6/::1/tcp/9000"}, http.StatusOK)
+	parsedResponse = getNodeIdentityResponse(t)
+	require.Equal(t, peerID, parsedResponse.Data.PeerID)
+	require.Equal(t, "enr:-custom", parsedResponse.Data.Enr)
+	require.Equal(t, []string{"/ip4/1.2.3.4/tcp/9000", "/ip6/::1/tcp/9000"}, parsedResponse.Data.P2PAddresses)
+	require.Empty(t, parsedResponse.Data.DiscoveryAddresses)
+	t.Logf("Received the new identity with peer ID [%s]", parsedResponse.Data.PeerID)
+
+	// Make sure an empty ENR is rejected
+	sendSetNodeIdentityRequest(t, peerID, "", nil, http.StatusBadRequest)
+}
+
+// Round trip a node identity request
+func getNodeIdentityResponse(t *testing.T) api.NodeIdentityResponse {
+	// Send the request
+	response, err := http.Get(fmt.Sprintf("http://localhost:%d/eth/%s", port, api.NodeIdentityRoute))
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.NodeIdentityResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
+
+// Send a request to set the node identity
+func sendSetNodeIdentityRequest(t *testing.T, peerID string, enr string, p2pAddresses []string, expectedStatus int) {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.SetNodeIdentityRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	query.Add("peer_id", peerID)
+	query.Add("enr", enr)
+	query["p2p_address"] = p2pAddresses
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, expectedStatus, response.StatusCode)
+	t.Logf("Received %d status code", response.StatusCode)
+}
@@ -0,0 +1,15 @@
+package server
+
+import (
+	"net/http"
+)
+
+// Handle a get node peer count request
+func (s *BeaconMockServer) getNodePeerCount(w http.ResponseWriter, r *http.Request) {
+	// Log the request
+	s.processApiRequest(w, r, nil)
+
+	// Get the response
+	response := s.manager.GetNodePeerCountResponse()
+	handleSuccess(s.logger, w, response)
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test getting the node's peer count
+func TestNodePeerCount(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Check the default count
+	parsedResponse := getNodePeerCountResponse(t)
+	require.Equal(t, db.DefaultPeerCount, uint64(parsedResponse.Data.Connected))
+	require.Equal(t, uint64(0), uint64(parsedResponse.Data.Connecting))
+	require.Equal(t, uint64(0), uint64(parsedResponse.Data.Disconnected))
+	require.Equal(t, uint64(0), uint64(parsedResponse.Data.Disconnecting))
+	t.Logf("Received the default peer count of %d", parsedResponse.Data.Connected)
+
+	// Change it
+	sendSetPeerCountRequest(t, 3)
+	parsedResponse = getNodePeerCountResponse(t)
+	require.Equal(t, uint64(3), uint64(parsedResponse.Data.Connected))
+	t.Logf("Received the new peer count of %d", parsedResponse.Data.Connected)
+}
+
+// Round trip a node peer count request
+func getNodePeerCountResponse(t *testing.T) api.PeerCountResponse {
+	// Send the request
+	response, err := http.Get(fmt.Sprintf("http://localhost:%d/eth/%s", port, api.NodePeerCountRoute))
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.PeerCountResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
@@ -0,0 +1,21 @@
+package server
+
+import (
+	"net/http"
+)
+
+// Handle a get node peers request
+func (s *BeaconMockServer) getNodePeers(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	states := getListValues(args["state"])
+	directions := getListValues(args["direction"])
+
+	// Get the response
+	response, err := s.manager.GetNodePeersResponse(states, directions)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	handleSuccess(s.logger, w, response)
+}
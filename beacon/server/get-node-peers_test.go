@@ -0,0 +1,143 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test getting the node's peers
+func TestNodePeers(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Get all of the peers
+	parsedResponse := getNodePeersResponse(t, nil, nil)
+	require.Len(t, parsedResponse.Data, int(db.DefaultPeerCount))
+	require.Equal(t, db.DefaultPeerCount, uint64(parsedResponse.Meta.Count))
+	peerIDs := map[string]bool{}
+	for _, peer := range parsedResponse.Data {
+		require.Equal(t, "connected", peer.State)
+		peerIDs[peer.PeerID] = true
+	}
+	require.Len(t, peerIDs, int(db.DefaultPeerCount))
+	t.Log("Received the default peers")
+
+	// Filter them
+	parsedResponse = getNodePeersResponse(t, []string{"connected"}, []string{"inbound"})
+	require.Len(t, parsedResponse.Data, int(db.DefaultPeerCount)/2)
+	for _, peer := range parsedResponse.Data {
+		require.Equal(t, "inbound", peer.Direction)
+	}
+	parsedResponse = getNodePeersResponse(t, []string{"disconnected,connecting"}, nil)
+	require.Empty(t, parsedResponse.Data)
+	require.Equal(t, http.StatusBadRequest, getNodePeersStatusCode(t, "state=gone"))
+	require.Equal(t, http.StatusBadRequest, getNodePeersStatusCode(t, "direction=sideways"))
+	t.Log("Filtered the peers")
+
+	// Drop all of the peers
+	sendSetPeerCountRequest(t, 0)
+	parsedResponse = getNodePeersResponse(t, nil, nil)
+	require.Empty(t, parsedResponse.Data)
+	require.Equal(t, uint64(0), uint64(parsedResponse.Meta.Count))
+	t.Log("Received no peers after setting the count to 0")
+
+	// Counts above the maximum should be rejected
+	code, _ := sendJsonAdminRequest(t, http.MethodPost, api.SetPeerCountRoute, `{"count": 1000000000000}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Equal(t, uint64(0), uint64(server.manager.GetNodePeerCountResponse().Data.Connected))
+	t.Log("Peer count above the maximum was rejected")
+}
+
+// Round trip a node peers request
+func getNodePeersResponse(t *testing.T, states []string, directions []string) api.PeersResponse {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, api.NodePeersRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	query["state"] = states
+	query["direction"] = directions
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.PeersResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
+
+// Get the status code of a node peers request with a raw query
+func getNodePeersStatusCode(t *testing.T, query string) int {
+	response, err := http.Get(fmt.Sprintf("http://localhost:%d/eth/%s?%s", port, api.NodePeersRoute, query))
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	defer response.Body.Close()
+	t.Logf("Received status code %d for query [%s]", response.StatusCode, query)
+	return response.StatusCode
+}
+
+// Send a request to set the node's peer count
+func sendSetPeerCountRequest(t *testing.T, count uint64) {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.SetPeerCountRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	query.Add("count", strconv.FormatUint(count, 10))
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+}
@@ -0,0 +1,15 @@
+package server
+
+import (
+	"net/http"
+)
+
+// Handle a get node version request
+func (s *BeaconMockServer) getNodeVersion(w http.ResponseWriter, r *http.Request) {
+	// Log the request
+	s.processApiRequest(w, r, nil)
+
+	// Get the response
+	response := s.manager.GetNodeVersionResponse()
+	handleSuccess(s.logger, w, response)
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test getting and setting the node version
+func TestNodeVersion(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Check the default version
+	parsedResponse := getNodeVersionResponse(t)
+	require.Equal(t, db.DefaultNodeVersion, parsedResponse.Data.Version)
+	t.Logf("Received the default version [%s]", parsedResponse.Data.Version)
+
+	// Change it
+	version := "Lighthouse/v5.3.0-d6ba8c3/x86_64-linux"
+	sendSetNodeVersionRequest(t, version)
+	parsedResponse = getNodeVersionResponse(t)
+	require.Equal(t, version, parsedResponse.Data.Version)
+	t.Logf("Received the new version [%s]", parsedResponse.Data.Version)
+}
+
+// Round trip a node version request
+func getNodeVersionResponse(t *testing.T) api.NodeVersionResponse {
+	// Send the request
+	response, err := http.Get(fmt.Sprintf("http://localhost:%d/eth/%s", port, api.NodeVersionRoute))
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.NodeVersionResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
+
+// Send a request to set the node version
+func sendSetNodeVersionRequest(t *testing.T, version string) {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.SetNodeVersionRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	query.Add("version", version)
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test queueing a consolidation and getting the pending consolidations
+func TestPendingConsolidations(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database with Electra active
+	config := db.NewDefaultConfig()
+	config.ElectraForkEpoch = 0
+	d := idb.ProvisionDatabaseWithConfigForTesting(t, logger, config)
+	server.manager.SetDatabase(d)
+	source, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 0)
+	target, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 1)
+	target.WithdrawalCredentials[0] = db.CompoundingWithdrawalPrefix
+	sourceIndex := strconv.FormatUint(source.Index, 10)
+	targetIndex := strconv.FormatUint(target.Index, 10)
+
+	// Queue a consolidation
+	require.Equal(t, http.StatusBadRequest, sendAddPendingConsolidationRequest(t, targetIndex, sourceIndex))
+	require.Equal(t, http.StatusOK, sendAddPendingConsolidationRequest(t, sourceIndex, targetIndex))
+	t.Log("Rejected a consolidation into a non-compounding validator and queued a valid one")
+
+	// Get the queue
+	parsedResponse := getPendingConsolidationsResponse(t, "head")
+	require.Len(t, parsedResponse.Data, 1)
+	require.Equal(t, sourceIndex, parsedResponse.Data[0].SourceIndex)
+	require.Equal(t, targetIndex, parsedResponse.Data[0].TargetIndex)
+	t.Log("Received correct response")
+}
+
+func sendAddPendingConsolidationRequest(t *testing.T, source string, target string) int {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.AddPendingConsolidationRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	query.Add("source", source)
+	query.Add("target", target)
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	defer response.Body.Close()
+	t.Logf("Sent request, received status code %d", response.StatusCode)
+	return response.StatusCode
+}
+
+func getPendingConsolidationsResponse(t *testing.T, stateID string) api.PendingConsolidationsResponse {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, fmt.Sprintf(api.PendingConsolidationsRouteTemplate, stateID)), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.PendingConsolidationsResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
@@ -1,12 +1,8 @@
 package server
 
 import (
-	"context"
-	"fmt"
 	"net/http"
 
-	"github.com/gorilla/mux"
-
 	"github.com/nodeset-org/osha/beacon/api"
 )
 
@@ -17,18 +13,17 @@ func (s *BeaconMockServer) getPendingDeposits(w http.ResponseWriter, r *http.Req
 	if args == nil {
 		return
 	}
-	vars := mux.Vars(r)
-	state, exists := vars[api.StateID]
-	if !exists {
-		handleInputError(s.logger, w, fmt.Errorf("missing state ID"))
+	state := s.getState(w, r)
+	if state == nil {
 		return
 	}
 
 	// Get the response
-	response, err := s.manager.Beacon_PendingDeposits(context.Background(), state)
-	if err != nil {
-		handleInputError(s.logger, w, err)
+	w.Header().Set(api.ConsensusVersionHeader, s.manager.GetForkName(state.Slot))
+	if acceptsSsz(r) {
+		handleSszSuccess(s.logger, w, s.manager.GetPendingDepositsSsz(state))
 		return
 	}
+	response := s.manager.GetPendingDepositsResponse(state)
 	handleSuccess(s.logger, w, response)
 }
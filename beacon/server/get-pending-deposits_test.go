@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/binary"
 	"fmt"
 	"io"
 	"net/http"
@@ -61,6 +62,15 @@ func TestPendingDeposits(t *testing.T) {
 		comparePendingDeposit(t, local, deposit)
 	}
 	t.Log("Pending deposits matched")
+
+	// Get them as SSZ, which is a list of 192-byte PendingDeposit containers
+	response, data := getSszResponse(t, fmt.Sprintf(api.PendingDepositsRouteTemplate, "head"))
+	require.Equal(t, "deneb", response.Header.Get(api.ConsensusVersionHeader))
+	require.Len(t, data, 2*192)
+	require.Equal(t, validator1.Pubkey[:], data[192:240])
+	require.Equal(t, pendingDeposit2.Amount, binary.LittleEndian.Uint64(data[272:280]))
+	require.Equal(t, pendingDeposit2.Slot, binary.LittleEndian.Uint64(data[376:384]))
+	t.Log("SSZ pending deposits matched")
 }
 
 // Round trip a validator pending deposits request
@@ -107,3 +117,41 @@ func comparePendingDeposit(t *testing.T, local *db.Deposit, remote client.Pendin
 	require.Equal(t, native.Signature, remote.Signature)
 	require.Equal(t, native.Slot, remote.Slot)
 }
+
+// Test that processed deposits are removed from the pending deposits
+func TestPendingDepositsProcessed(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Add a deposit for a new validator
+	pendingDeposit := idb.CreateDepositForTesting(t, server.manager.GetConfig(), 0, d.GetValidatorByIndex(0).WithdrawalCredentials, 32e9)
+	pubkey := pendingDeposit.Pubkey
+	d.AddPendingDeposit(pendingDeposit)
+	parsedResponse := getPendingDepositsResponse(t)
+	require.Len(t, parsedResponse.Data, 1)
+	t.Log("Deposit is pending")
+
+	// Run to the next epoch
+	slotsPerEpoch := server.manager.GetConfig().SlotsPerEpoch
+	for i := uint64(0); i < slotsPerEpoch; i++ {
+		d.CommitBlock(true)
+	}
+
+	// Make sure the deposit was processed
+	parsedResponse = getPendingDepositsResponse(t)
+	require.Empty(t, parsedResponse.Data)
+	v := getValidatorResponse(t, pubkey.HexWithPrefix())
+	require.Equal(t, "3", v.Data.Index)
+	require.Equal(t, uint64(32e9), uint64(v.Data.Balance))
+	t.Log("Deposit was processed into a new validator")
+}
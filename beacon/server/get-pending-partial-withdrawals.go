@@ -0,0 +1,29 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/nodeset-org/osha/beacon/api"
+)
+
+// Handle a get pending partial withdrawals request
+func (s *BeaconMockServer) getPendingPartialWithdrawals(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	if args == nil {
+		return
+	}
+	state := s.getState(w, r)
+	if state == nil {
+		return
+	}
+
+	// Get the response
+	w.Header().Set(api.ConsensusVersionHeader, s.manager.GetForkName(state.Slot))
+	if acceptsSsz(r) {
+		handleSszSuccess(s.logger, w, s.manager.GetPendingPartialWithdrawalsSsz(state))
+		return
+	}
+	response := s.manager.GetPendingPartialWithdrawalsResponse(state)
+	handleSuccess(s.logger, w, response)
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test queueing a partial withdrawal and getting the pending partial withdrawals
+func TestPendingPartialWithdrawals(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database with Electra active
+	config := db.NewDefaultConfig()
+	config.ElectraForkEpoch = 0
+	d := idb.ProvisionDatabaseWithConfigForTesting(t, logger, config)
+	server.manager.SetDatabase(d)
+	v, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 0)
+	v.WithdrawalCredentials[0] = db.CompoundingWithdrawalPrefix
+	v.Balance = 40e9
+	index := strconv.FormatUint(v.Index, 10)
+
+	// Queue a withdrawal
+	require.Equal(t, http.StatusOK, sendAddPendingPartialWithdrawalRequest(t, index, 5e9))
+	require.Equal(t, http.StatusBadRequest, sendAddPendingPartialWithdrawalRequest(t, "0", 5e9))
+	t.Log("Queued a withdrawal and rejected one for a pending validator")
+
+	// Get the queue
+	parsedResponse := getPendingPartialWithdrawalsResponse(t, "head")
+	require.Len(t, parsedResponse.Data, 1)
+	withdrawal := parsedResponse.Data[0]
+	require.Equal(t, index, withdrawal.ValidatorIndex)
+	require.Equal(t, uint64(5e9), uint64(withdrawal.Amount))
+	require.Equal(t, d.GetPendingPartialWithdrawals()[0].WithdrawableEpoch, uint64(withdrawal.WithdrawableEpoch))
+	t.Logf("Received correct response - withdrawable epoch: %d", uint64(withdrawal.WithdrawableEpoch))
+}
+
+func sendAddPendingPartialWithdrawalRequest(t *testing.T, id string, amount uint64) int {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.AddPendingPartialWithdrawalRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	query.Add("id", id)
+	query.Add("amount", strconv.FormatUint(amount, 10))
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	defer response.Body.Close()
+	t.Logf("Sent request, received status code %d", response.StatusCode)
+	return response.StatusCode
+}
+
+func getPendingPartialWithdrawalsResponse(t *testing.T, stateID string) api.PendingPartialWithdrawalsResponse {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, fmt.Sprintf(api.PendingPartialWithdrawalsRouteTemplate, stateID)), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.PendingPartialWithdrawalsResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
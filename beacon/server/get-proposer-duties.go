@@ -0,0 +1,33 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/nodeset-org/osha/beacon/api"
+)
+
+// Handle a get proposer duties request
+func (s *BeaconMockServer) getProposerDuties(w http.ResponseWriter, r *http.Request) {
+	// Log the request
+	s.processApiRequest(w, r, nil)
+	vars := mux.Vars(r)
+	epochString, exists := vars[api.Epoch]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing epoch"))
+		return
+	}
+
+	// Input validation
+	epoch, err := strconv.ParseUint(epochString, 10, 64)
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("invalid epoch [%s]: %w", epochString, err))
+		return
+	}
+
+	// Write the response
+	response := s.manager.GetProposerDutiesResponse(epoch)
+	handleSuccess(s.logger, w, response)
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test getting proposer duties, including a forced proposer
+func TestProposerDuties(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+	v0, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 0)
+	v1, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 1)
+	activeIndices := []string{strconv.FormatUint(v0.Index, 10), strconv.FormatUint(v1.Index, 10)}
+
+	// Get the duties for epoch 1
+	slotsPerEpoch := server.manager.GetConfig().SlotsPerEpoch
+	parsedResponse := getProposerDutiesResponse(t, 1)
+	require.Len(t, parsedResponse.Data, int(slotsPerEpoch))
+	for i, duty := range parsedResponse.Data {
+		require.Equal(t, slotsPerEpoch+uint64(i), uint64(duty.Slot))
+		require.Contains(t, activeIndices, duty.ValidatorIndex)
+	}
+	t.Log("All proposers are active validators")
+
+	// Force validator 0 to propose in the first slot of epoch 1
+	sendSetProposerRequest(t, "0", slotsPerEpoch)
+	parsedResponse = getProposerDutiesResponse(t, 1)
+	require.Equal(t, "0", parsedResponse.Data[0].ValidatorIndex)
+	require.Equal(t, d.GetValidatorByIndex(0).Pubkey[:], []byte(parsedResponse.Data[0].Pubkey))
+	t.Logf("Received correct response - slot %d proposer: %s", parsedResponse.Data[0].Slot, parsedResponse.Data[0].ValidatorIndex)
+
+	// The first epoch depends on the genesis block
+	d.CommitBlock(true)
+	genesis := d.GetBlockBySlot(0)
+	require.Equal(t, genesis.Root, getProposerDutiesResponse(t, 0).DependentRoot)
+
+	// Missing the last slot of the epoch should make the next epoch depend on the latest block before it
+	for d.GetCurrentSlot() < slotsPerEpoch-2 {
+		d.CommitBlock(true)
+	}
+	d.CommitBlock(true)
+	d.CommitBlock(false)
+	latest := d.GetBlockBySlot(slotsPerEpoch - 2)
+	require.Nil(t, d.GetBlockBySlot(slotsPerEpoch-1))
+	require.Equal(t, latest.Root, getProposerDutiesResponse(t, 1).DependentRoot)
+	t.Log("Dependent roots skip missed slots")
+}
+
+// Round trip a proposer duties request
+func getProposerDutiesResponse(t *testing.T, epoch uint64) api.ProposerDutiesResponse {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, fmt.Sprintf(api.ProposerDutiesRouteTemplate, strconv.FormatUint(epoch, 10))), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.ProposerDutiesResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
+
+func sendSetProposerRequest(t *testing.T, id string, slot uint64) {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.SetProposerRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	query.Add("id", id)
+	query.Add("slot", strconv.FormatUint(slot, 10))
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+}
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/manager"
+)
+
+func (s *BeaconMockServer) getRecordedRequests(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars, each of which optionally filters the requests
+	args := s.processApiRequest(w, r, nil)
+	matchers := []manager.RequestMatcher{}
+	if method, exists := args["method"]; exists {
+		matchers = append(matchers, manager.MatchMethod(method[0]))
+	}
+	if route, exists := args["route"]; exists {
+		matchers = append(matchers, manager.MatchRoute(route[0]))
+	}
+	if _, exists := args["id"]; exists {
+		matchers = append(matchers, manager.MatchValidatorIDs(getListValues(args["id"])...))
+	}
+
+	// Get the response
+	response := api.RecordedRequestsResponse{
+		Data: s.manager.FindRecordedRequests(matchers...),
+	}
+	handleSuccess(s.logger, w, response)
+}
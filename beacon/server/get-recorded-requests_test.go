@@ -0,0 +1,206 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/nodeset-org/osha/beacon/manager"
+	"github.com/stretchr/testify/require"
+)
+
+// Test recording requests and matching them
+func TestRecordedRequests(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+	defer server.ClearFaults()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+	sendClearRecordedRequestsRequest(t)
+
+	// Make some requests
+	getValidatorsResponse(t, []string{"0,1"})
+	getValidatorsResponsePost(t, []string{"2", "1"})
+	getValidatorBalancesResponse(t, http.MethodPost, "genesis", []string{"0"})
+	sendAddFaultRequest(t, api.SyncingRoute, map[string]string{
+		"type":   string(FaultType_Status),
+		"status": "503",
+		"count":  "1",
+	}, http.StatusOK)
+	require.Equal(t, http.StatusServiceUnavailable, getFaultTestStatusCode(t, api.SyncingRoute))
+	t.Log("Sent the requests")
+
+	// Check the journal, which doesn't include admin requests
+	requests := server.manager.GetRecordedRequests()
+	require.Len(t, requests, 4)
+	require.Equal(t, http.MethodGet, requests[0].Method)
+	require.Equal(t, api.ValidatorsRoute, requests[0].Route)
+	require.Equal(t, "head", requests[0].PathVars[api.StateID])
+	require.Equal(t, []string{"0,1"}, requests[0].Query["id"])
+	require.Equal(t, http.StatusOK, requests[0].StatusCode)
+	require.Equal(t, http.MethodPost, requests[1].Method)
+	require.Contains(t, requests[1].Body, `"ids":["2","1"]`)
+	require.Equal(t, api.ValidatorBalancesRoute, requests[2].Route)
+	require.Equal(t, "genesis", requests[2].PathVars[api.StateID])
+	require.Equal(t, api.SyncingRoute, requests[3].Route)
+	require.Equal(t, http.StatusServiceUnavailable, requests[3].StatusCode)
+	require.False(t, requests[3].Timestamp.Before(requests[0].Timestamp))
+	t.Log("Journal recorded the requests")
+
+	// Use the matchers
+	require.NoError(t, server.manager.ExpectRecordedRequests(1, manager.MatchMethod(http.MethodPost), manager.MatchRoute(api.ValidatorsRoute), manager.MatchValidatorIDs("1", "2")))
+	require.NoError(t, server.manager.ExpectRecordedRequests(1, manager.MatchRoute(api.ValidatorsRoute), manager.MatchValidatorIDs("1", "0")))
+	require.NoError(t, server.manager.ExpectRecordedRequests(1, manager.MatchRoute(api.ValidatorBalancesRoute), manager.MatchValidatorIDs("0")))
+	require.NoError(t, server.manager.ExpectRecordedRequests(2, manager.MatchPathVar(api.StateID, "head")))
+	require.NoError(t, server.manager.ExpectRecordedRequests(0, manager.MatchQuery("id", "2")))
+	err := server.manager.ExpectRecordedRequests(1, manager.MatchMethod(http.MethodPost), manager.MatchValidatorIDs("1"))
+	require.Error(t, err)
+	t.Logf("Matchers worked, mismatch error was: %v", err)
+
+	// Get them through the admin route
+	parsedResponse := getRecordedRequestsResponse(t, map[string][]string{
+		"method": {http.MethodPost},
+	})
+	require.Len(t, parsedResponse.Data, 2)
+	parsedResponse = getRecordedRequestsResponse(t, map[string][]string{
+		"route": {api.ValidatorsRoute},
+		"id":    {"1", "2"},
+	})
+	require.Len(t, parsedResponse.Data, 1)
+	require.Equal(t, http.MethodPost, parsedResponse.Data[0].Method)
+	t.Log("Admin route returned the matching requests")
+
+	// Snapshots revert the journal
+	server.manager.TakeSnapshot("journal")
+	getValidatorsResponse(t, nil)
+	require.Len(t, server.manager.GetRecordedRequests(), 5)
+	err = server.manager.RevertToSnapshot("journal")
+	require.NoError(t, err)
+	require.Len(t, server.manager.GetRecordedRequests(), 4)
+	t.Log("Reverting the snapshot reverted the journal")
+
+	// Clear it
+	sendClearRecordedRequestsRequest(t)
+	require.Empty(t, server.manager.GetRecordedRequests())
+	t.Log("Journal was cleared")
+}
+
+// Test limiting the number of recorded requests and the size of their bodies
+func TestRecordedRequestsCapacity(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+	defer server.manager.SetRecordedRequestsCapacity(manager.DefaultJournalCapacity)
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+	sendClearRecordedRequestsRequest(t)
+
+	// Only the newest requests should be kept
+	code, _ := sendJsonAdminRequest(t, http.MethodPost, api.SetRecordedRequestsCapacityRoute, `{"capacity": 2}`)
+	require.Equal(t, http.StatusOK, code)
+	for _, id := range []string{"0", "1", "2"} {
+		getValidatorsResponse(t, []string{id})
+	}
+	requests := server.manager.GetRecordedRequests()
+	require.Len(t, requests, 2)
+	require.Equal(t, []string{"1"}, requests[0].Query["id"])
+	require.Equal(t, []string{"2"}, requests[1].Query["id"])
+	t.Log("Journal dropped the oldest request")
+
+	// Shrinking it should drop the oldest ones too
+	server.manager.SetRecordedRequestsCapacity(1)
+	requests = server.manager.GetRecordedRequests()
+	require.Len(t, requests, 1)
+	require.Equal(t, []string{"2"}, requests[0].Query["id"])
+	t.Log("Shrinking the journal dropped the oldest request")
+
+	// Long bodies should be truncated
+	server.manager.RecordRequest(api.RecordedRequest{
+		Body: strings.Repeat("a", manager.MaxRecordedBodySize+1),
+	})
+	requests = server.manager.GetRecordedRequests()
+	require.Len(t, requests[0].Body, manager.MaxRecordedBodySize)
+	require.True(t, requests[0].BodyTruncated)
+	t.Log("Long body was truncated")
+
+	// Invalid capacities should be rejected
+	code, errorResponse := sendJsonAdminRequest(t, http.MethodPost, api.SetRecordedRequestsCapacityRoute, `{"capacity": -1}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Contains(t, errorResponse.Message, "invalid capacity [-1]")
+	t.Log("Invalid capacity was rejected")
+}
+
+// Round trip a request for the recorded requests
+func getRecordedRequestsResponse(t *testing.T, args map[string][]string) api.RecordedRequestsResponse {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.GetRecordedRequestsRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	for key, values := range args {
+		query[key] = values
+	}
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.RecordedRequestsResponse
+	err = json.Unmarshal(body, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
+
+// Send a request to clear the recorded requests
+func sendClearRecordedRequestsRequest(t *testing.T) {
+	// Send the request
+	response, err := http.Get(fmt.Sprintf("http://localhost:%d/admin/%s", port, api.ClearRecordedRequestsRoute))
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+}
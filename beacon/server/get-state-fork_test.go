@@ -0,0 +1,91 @@
+package server
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/rocket-pool/node-manager-core/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// Test getting the fork of the head and genesis states
+func TestStateFork(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+	d.CommitBlock(true)
+
+	// Get the head's fork
+	cfg := server.manager.GetConfig()
+	fork := cfg.GetFork(d.GetCurrentEpoch())
+	parsedResponse := getStateForkResponse(t, "head")
+	require.Equal(t, fork.PreviousVersion, parsedResponse.Data.PreviousVersion)
+	require.Equal(t, fork.CurrentVersion, parsedResponse.Data.CurrentVersion)
+	require.Equal(t, fork.Epoch, uint64(parsedResponse.Data.Epoch))
+	require.Equal(t, cfg.GetForkVersion(0), parsedResponse.Data.CurrentVersion)
+	t.Logf("Received correct response for the head - current version: %s", utils.EncodeHexWithPrefix(parsedResponse.Data.CurrentVersion))
+
+	// Get the head's fork as SSZ
+	response, data := getSszResponse(t, fmt.Sprintf(api.StateForkRouteTemplate, "head"))
+	require.Equal(t, "deneb", response.Header.Get(api.ConsensusVersionHeader))
+	require.Len(t, data, 16)
+	require.Equal(t, []byte(fork.PreviousVersion), data[0:4])
+	require.Equal(t, []byte(fork.CurrentVersion), data[4:8])
+	require.Equal(t, fork.Epoch, binary.LittleEndian.Uint64(data[8:16]))
+	t.Log("Received correct SSZ response for the head")
+
+	// Genesis should be finalized
+	parsedResponse = getStateForkResponse(t, "genesis")
+	require.True(t, parsedResponse.Finalized)
+	t.Log("Genesis state is finalized")
+}
+
+func getStateForkResponse(t *testing.T, stateID string) api.StateForkResponse {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, fmt.Sprintf(api.StateForkRouteTemplate, stateID)), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.StateForkResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
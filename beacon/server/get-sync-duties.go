@@ -0,0 +1,41 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/nodeset-org/osha/beacon/api"
+)
+
+// Handle a get sync committee duties request
+func (s *BeaconMockServer) getSyncDuties(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	var ids []string
+	args := s.processApiRequest(w, r, &ids)
+	if args == nil {
+		return
+	}
+	vars := mux.Vars(r)
+	epochString, exists := vars[api.Epoch]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing epoch"))
+		return
+	}
+
+	// Input validation
+	epoch, err := strconv.ParseUint(epochString, 10, 64)
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("invalid epoch [%s]: %w", epochString, err))
+		return
+	}
+
+	// Write the response
+	response, err := s.manager.GetSyncDutiesResponse(getListValues(ids), epoch)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	handleSuccess(s.logger, w, response)
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test getting sync committee duties, including a forced member
+func TestSyncDuties(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+	v0, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 0)
+	v1, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 1)
+	ids := []string{"0", strconv.FormatUint(v0.Index, 10), strconv.FormatUint(v1.Index, 10)}
+
+	// Only the active validators should be in the committee
+	parsedResponse := getSyncDutiesResponse(t, ids, 0)
+	require.Len(t, parsedResponse.Data, 2)
+	positions := 0
+	for _, duty := range parsedResponse.Data {
+		require.NotEqual(t, "0", duty.ValidatorIndex)
+		positions += len(duty.SyncCommitteeIndices)
+	}
+	require.Equal(t, 512, positions)
+	t.Log("Active validators fill the committee")
+
+	// Force validator 0 into the committee
+	sendAddToSyncCommitteeRequest(t, "0")
+	parsedResponse = getSyncDutiesResponse(t, ids, 0)
+	require.Len(t, parsedResponse.Data, 3)
+	require.Equal(t, "0", parsedResponse.Data[0].ValidatorIndex)
+	require.Equal(t, uint64(0), uint64(parsedResponse.Data[0].SyncCommitteeIndices[0]))
+	t.Logf("Received correct response - validator 0 has %d committee positions", len(parsedResponse.Data[0].SyncCommitteeIndices))
+}
+
+// Round trip a sync duties request
+func getSyncDutiesResponse(t *testing.T, ids []string, epoch uint64) api.SyncDutiesResponse {
+	// Create the request
+	body, err := json.Marshal(ids)
+	if err != nil {
+		t.Fatalf("error serializing IDs: %v", err)
+	}
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:%d/eth/%s", port, fmt.Sprintf(api.SyncDutiesRouteTemplate, strconv.FormatUint(epoch, 10))), bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	responseBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.SyncDutiesResponse
+	err = json.Unmarshal(responseBytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
+
+func sendAddToSyncCommitteeRequest(t *testing.T, id string) {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.AddToSyncCommitteeRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	query.Add("id", id)
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+}
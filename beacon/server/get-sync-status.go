@@ -7,8 +7,8 @@ import (
 
 // Handle a get sync status request
 func (s *BeaconMockServer) getSyncStatus(w http.ResponseWriter, r *http.Request) {
-	// Get the request vars
-	_ = s.processApiRequest(w, r, nil)
+	// Log the request
+	s.processApiRequest(w, r, nil)
 	response, err := s.manager.Node_Syncing(context.Background())
 	if err != nil {
 		handleServerError(s.logger, w, err)
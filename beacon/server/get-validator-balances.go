@@ -0,0 +1,39 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// Handle a get validator balances request
+func (s *BeaconMockServer) getValidatorBalances(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars; POST requests provide the IDs as a JSON array in the body
+	var args url.Values
+	var ids []string
+	switch r.Method {
+	case http.MethodGet:
+		args = s.processApiRequest(w, r, nil)
+		ids = getListValues(args["id"])
+	case http.MethodPost:
+		args = s.processApiRequest(w, r, &ids)
+		if args == nil {
+			return
+		}
+		ids = getListValues(ids)
+	default:
+		handleInvalidMethod(s.logger, w)
+		return
+	}
+	state := s.getState(w, r)
+	if state == nil {
+		return
+	}
+
+	// Get the response
+	response, err := s.manager.GetValidatorBalancesResponse(state, ids)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	handleSuccess(s.logger, w, response)
+}
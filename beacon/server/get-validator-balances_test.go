@@ -0,0 +1,116 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test getting validator balances
+func TestValidatorBalances(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Change a balance in a new slot
+	v1 := d.GetValidatorByIndex(1)
+	oldBalance := v1.Balance
+	d.CommitBlock(false)
+	v1.Balance -= 1e9
+	t.Logf("Changed validator 1's balance from %d to %d", oldBalance, v1.Balance)
+
+	// Get all of the balances
+	parsedResponse := getValidatorBalancesResponse(t, http.MethodGet, "head", nil)
+	require.Len(t, parsedResponse.Data, 3)
+	for i, balance := range parsedResponse.Data {
+		v := d.GetValidatorByIndex(uint(i))
+		require.Equal(t, fmt.Sprint(i), balance.Index)
+		require.Equal(t, v.Balance, uint64(balance.Balance))
+	}
+	t.Log("Received all of the balances")
+
+	// Get specific balances by index and pubkey
+	v2 := d.GetValidatorByIndex(2)
+	parsedResponse = getValidatorBalancesResponse(t, http.MethodGet, "head", []string{"1," + v2.Pubkey.HexWithPrefix()})
+	require.Len(t, parsedResponse.Data, 2)
+	require.Equal(t, "1", parsedResponse.Data[0].Index)
+	require.Equal(t, v1.Balance, uint64(parsedResponse.Data[0].Balance))
+	require.Equal(t, "2", parsedResponse.Data[1].Index)
+	t.Log("Received the balances by index and pubkey")
+
+	// Get the old balance from the genesis state via POST
+	parsedResponse = getValidatorBalancesResponse(t, http.MethodPost, "genesis", []string{"1"})
+	require.Len(t, parsedResponse.Data, 1)
+	require.Equal(t, oldBalance, uint64(parsedResponse.Data[0].Balance))
+	require.True(t, parsedResponse.Finalized)
+	t.Log("Received the old balance from the genesis state via POST")
+}
+
+// Round trip a validator balances request, using the query for GET or the body for POST
+func getValidatorBalancesResponse(t *testing.T, method string, stateID string, ids []string) api.ValidatorBalancesResponse {
+	// Create the request
+	url := fmt.Sprintf("http://localhost:%d/eth/%s", port, fmt.Sprintf(api.ValidatorBalancesRouteTemplate, stateID))
+	var request *http.Request
+	var err error
+	if method == http.MethodPost {
+		reqBodyBytes, err := json.Marshal(ids)
+		if err != nil {
+			t.Fatalf("error serializing request body: %v", err)
+		}
+		request, err = http.NewRequest(method, url, bytes.NewReader(reqBodyBytes))
+		if err != nil {
+			t.Fatalf("error creating request: %v", err)
+		}
+	} else {
+		request, err = http.NewRequest(method, url, nil)
+		if err != nil {
+			t.Fatalf("error creating request: %v", err)
+		}
+		query := request.URL.Query()
+		query["id"] = ids
+		request.URL.RawQuery = query.Encode()
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.ValidatorBalancesResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
@@ -10,20 +10,13 @@ import (
 
 // Handle a get validator request
 func (s *BeaconMockServer) getValidator(w http.ResponseWriter, r *http.Request) {
-	// Get the request vars
-	_ = s.processApiRequest(w, r, nil)
+	// Log the request
+	s.processApiRequest(w, r, nil)
+	state := s.getState(w, r)
+	if state == nil {
+		return
+	}
 	vars := mux.Vars(r)
-	/*
-		state, exists := vars[api.StateID]
-		if !exists {
-			handleInputError(s.logger, w, fmt.Errorf("missing state ID"))
-			return
-		}
-		if state != "head" {
-			handleInputError(s.logger, w, fmt.Errorf("unsupported state ID [%s], only 'head' is supported", state))
-			return
-		}
-	*/
 
 	id, exists := vars[api.ValidatorID]
 	if !exists {
@@ -32,11 +25,15 @@ func (s *BeaconMockServer) getValidator(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Get the validator
-	validator, err := s.manager.GetValidator(id)
+	validator, err := s.manager.GetStateValidator(state, id)
 	if err != nil {
 		handleInputError(s.logger, w, err)
 		return
 	}
+	if validator == nil {
+		handleNotFound(s.logger, w, fmt.Errorf("validator [%s] not found", id))
+		return
+	}
 
 	// Write the response
 	response := api.ValidatorResponse{
@@ -1,7 +1,6 @@
 package server
 
 import (
-	"context"
 	"fmt"
 	"io"
 	"log/slog"
@@ -10,8 +9,6 @@ import (
 	"strings"
 
 	"github.com/goccy/go-json"
-	"github.com/gorilla/mux"
-
 	"github.com/nodeset-org/osha/beacon/api"
 	"github.com/rocket-pool/node-manager-core/log"
 )
@@ -20,35 +17,41 @@ import (
 func (s *BeaconMockServer) getValidators(w http.ResponseWriter, r *http.Request) {
 	// Get the request vars
 	args := s.processApiRequest(w, r, nil)
-	vars := mux.Vars(r)
-	state, exists := vars[api.StateID]
-	if !exists {
-		handleInputError(s.logger, w, fmt.Errorf("missing state ID"))
+	state := s.getState(w, r)
+	if state == nil {
 		return
 	}
-	/*
-		if state != "head" {
-			handleInputError(s.logger, w, fmt.Errorf("unsupported state ID [%s], only 'head' is supported", state))
-			return
-		}
-	*/
 
 	var ids []string
+	var statuses []string
 	switch r.Method {
 	case http.MethodGet:
 		ids = s.getValidatorIDsFromRequestArgs(args)
+		statuses = getListValues(args["status"])
 	case http.MethodPost:
-		ids = s.getValidatorIDsFromRequestBody(w, r)
-		if ids == nil {
+		requestBody := s.getValidatorsRequestBody(w, r)
+		if requestBody == nil {
 			return
 		}
+		ids = getListValues(requestBody.IDs)
+		statuses = getListValues(requestBody.Statuses)
 	default:
 		handleInvalidMethod(s.logger, w)
 		return
 	}
 
 	// Get the response
-	response, err := s.manager.Beacon_Validators(context.Background(), state, ids)
+	w.Header().Set(api.ConsensusVersionHeader, s.manager.GetForkName(state.Slot))
+	if acceptsSsz(r) {
+		data, err := s.manager.GetValidatorsSsz(state, ids, statuses)
+		if err != nil {
+			handleInputError(s.logger, w, err)
+			return
+		}
+		handleSszSuccess(s.logger, w, data)
+		return
+	}
+	response, err := s.manager.GetValidatorsResponse(state, ids, statuses)
 	if err != nil {
 		handleInputError(s.logger, w, err)
 		return
@@ -59,11 +62,11 @@ func (s *BeaconMockServer) getValidators(w http.ResponseWriter, r *http.Request)
 // Get all of the validator IDs from the request query for a GET request
 func (s *BeaconMockServer) getValidatorIDsFromRequestArgs(args url.Values) []string {
 	ids := args["id"]
-	return getValidatorIDs(ids)
+	return getListValues(ids)
 }
 
-// Get all of the validator IDs from the request body for a POST request
-func (s *BeaconMockServer) getValidatorIDsFromRequestBody(w http.ResponseWriter, r *http.Request) []string {
+// Get the validator IDs and statuses from the request body for a POST request
+func (s *BeaconMockServer) getValidatorsRequestBody(w http.ResponseWriter, r *http.Request) *api.ValidatorsRequest {
 	// Read the body
 	bodyBytes, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -79,26 +82,26 @@ func (s *BeaconMockServer) getValidatorIDsFromRequestBody(w http.ResponseWriter,
 		handleInputError(s.logger, w, fmt.Errorf("error deserializing request body: %w", err))
 		return nil
 	}
-
-	return getValidatorIDs(requestBody.IDs)
+	return &requestBody
 }
 
-// Get all of the validator IDs from a list of them, handling the case where they're comma-separated
-func getValidatorIDs(ids []string) []string {
-	if len(ids) == 0 {
+// Get all of the values from a list of them, such as validator IDs or statuses, handling the case where they're
+// comma-separated
+func getListValues(values []string) []string {
+	if len(values) == 0 {
 		return []string{}
 	}
 
-	fullIds := make([]string, 0, len(ids))
-	for _, id := range ids {
-		elements := strings.Split(id, ",")
+	fullValues := make([]string, 0, len(values))
+	for _, value := range values {
+		elements := strings.Split(value, ",")
 		for _, element := range elements {
 			trimmed := strings.TrimSpace(element)
 			if trimmed == "" {
 				continue
 			}
-			fullIds = append(fullIds, trimmed)
+			fullValues = append(fullValues, trimmed)
 		}
 	}
-	return fullIds
+	return fullValues
 }
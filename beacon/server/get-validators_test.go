@@ -2,6 +2,7 @@ package server
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,6 +12,8 @@ import (
 	"github.com/goccy/go-json"
 	"github.com/nodeset-org/osha/beacon/api"
 	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/nodeset-org/osha/beacon/ssz"
+	"github.com/rocket-pool/node-manager-core/beacon"
 	"github.com/rocket-pool/node-manager-core/beacon/client"
 	"github.com/stretchr/testify/require"
 )
@@ -41,6 +44,45 @@ func TestAllValidators(t *testing.T) {
 	t.Log("Validators matched")
 }
 
+// Test getting validators as SSZ
+func TestValidatorsSsz(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Get the validators as SSZ
+	response, data := getSszResponse(t, fmt.Sprintf(api.ValidatorsRouteTemplate, "head")+"?id=0,2")
+	require.Equal(t, "deneb", response.Header.Get(api.ConsensusVersionHeader))
+
+	// The list starts with an offset for each validator
+	firstOffset := binary.LittleEndian.Uint32(data[0:4])
+	require.Equal(t, uint32(2*ssz.OffsetSize), firstOffset)
+	secondOffset := binary.LittleEndian.Uint32(data[4:8])
+	first := data[firstOffset:secondOffset]
+	second := data[secondOffset:]
+
+	// Each validator has its index, balance, status offset, and spec Validator, followed by its status
+	for i, element := range [][]byte{first, second} {
+		validator := d.GetValidatorByIndex(uint(i * 2))
+		require.Equal(t, validator.Index, binary.LittleEndian.Uint64(element[0:8]))
+		require.Equal(t, validator.Balance, binary.LittleEndian.Uint64(element[8:16]))
+		statusOffset := binary.LittleEndian.Uint32(element[16:20])
+		require.Equal(t, string(validator.Status), string(element[statusOffset:]))
+		require.Equal(t, validator.Pubkey[:], element[20:68])
+		require.Equal(t, validator.WithdrawalCredentials[:], element[68:100])
+	}
+	t.Logf("Received correct SSZ validators - size: %d bytes", len(data))
+}
+
 // Test getting 1 validator by index
 func TestValidatorsByIndex_1(t *testing.T) {
 	// Take a snapshot
@@ -162,10 +204,67 @@ func TestValidatorsByIndex_Post(t *testing.T) {
 	t.Log("Validators matched")
 }
 
+// Test getting validators from historical states
+func TestValidatorsByState(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Change a balance after a few blocks
+	for i := 0; i < 3; i++ {
+		d.CommitBlock(true)
+	}
+	v := d.GetValidatorByIndex(0)
+	oldBalance := v.Balance
+	v.Balance -= 1e9
+	d.CommitBlock(true)
+	t.Logf("Committed 4 blocks, changed validator 0's balance from %d to %d", oldBalance, v.Balance)
+
+	// Get the validator at the old slot, by slot and by state root
+	parsedResponse := getStateValidatorsResponse(t, "2", []string{"0"})
+	require.Len(t, parsedResponse.Data, 1)
+	require.Equal(t, oldBalance, uint64(parsedResponse.Data[0].Balance))
+	parsedResponse = getStateValidatorsResponse(t, d.GetBlockBySlot(2).StateRoot.Hex(), []string{"0"})
+	require.Equal(t, oldBalance, uint64(parsedResponse.Data[0].Balance))
+	t.Log("Received the old balance for slot 2")
+
+	// Get the validator at the head
+	parsedResponse = getStateValidatorsResponse(t, "head", []string{"0"})
+	require.Equal(t, v.Balance, uint64(parsedResponse.Data[0].Balance))
+	t.Log("Received the new balance for the head")
+
+	// Make sure the named states resolve
+	for _, stateID := range []string{"genesis", "finalized", "justified"} {
+		parsedResponse = getStateValidatorsResponse(t, stateID, []string{"0"})
+		require.Equal(t, oldBalance, uint64(parsedResponse.Data[0].Balance))
+		t.Logf("Resolved state [%s]", stateID)
+	}
+
+	// Check bad state IDs
+	require.Equal(t, http.StatusNotFound, getValidatorsStatusCode(t, "100"))
+	require.Equal(t, http.StatusNotFound, getValidatorsStatusCode(t, "0x0000000000000000000000000000000000000000000000000000000000000001"))
+	require.Equal(t, http.StatusBadRequest, getValidatorsStatusCode(t, "latest"))
+	t.Log("Received the correct status codes for missing and invalid states")
+}
+
 // Round trip a validators status request
 func getValidatorsResponse(t *testing.T, ids []string) client.ValidatorsResponse {
+	return getStateValidatorsResponse(t, "head", ids)
+}
+
+// Round trip a validators request for a specific state
+func getStateValidatorsResponse(t *testing.T, stateID string, ids []string) client.ValidatorsResponse {
 	// Create the request
-	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, fmt.Sprintf(api.ValidatorsRouteTemplate, "head")), nil)
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, fmt.Sprintf(api.ValidatorsRouteTemplate, stateID)), nil)
 	if err != nil {
 		t.Fatalf("error creating request: %v", err)
 	}
@@ -243,3 +342,129 @@ func getValidatorsResponsePost(t *testing.T, ids []string) client.ValidatorsResp
 	t.Log("Parsed response")
 	return parsedResponse
 }
+
+// Get the status code of a validators request for a specific state
+func getValidatorsStatusCode(t *testing.T, stateID string) int {
+	response, err := http.Get(fmt.Sprintf("http://localhost:%d/eth/%s", port, fmt.Sprintf(api.ValidatorsRouteTemplate, stateID)))
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	defer response.Body.Close()
+	t.Logf("Received status code %d for state [%s]", response.StatusCode, stateID)
+	return response.StatusCode
+}
+
+// Test filtering validators by their statuses
+func TestValidatorsByStatus(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Set up one validator per group
+	v0 := d.GetValidatorByIndex(0)
+	v1 := d.GetValidatorByIndex(1)
+	v2 := d.GetValidatorByIndex(2)
+	v1.SetStatus(beacon.ValidatorState_ActiveOngoing)
+	v2.SetStatus(beacon.ValidatorState_ExitedUnslashed)
+	t.Log("Set validator 1 to active and validator 2 to exited")
+
+	// Filter by groups
+	parsedResponse := getValidatorsByStatusResponse(t, http.MethodGet, []string{"active"})
+	require.Len(t, parsedResponse.Data, 1)
+	compareValidators(t, v1, &parsedResponse.Data[0])
+	parsedResponse = getValidatorsByStatusResponse(t, http.MethodGet, []string{"pending,exited"})
+	require.Len(t, parsedResponse.Data, 2)
+	compareValidators(t, v0, &parsedResponse.Data[0])
+	compareValidators(t, v2, &parsedResponse.Data[1])
+	parsedResponse = getValidatorsByStatusResponse(t, http.MethodGet, []string{"withdrawal"})
+	require.Empty(t, parsedResponse.Data)
+	t.Log("Filtered by status groups")
+
+	// Filter by individual statuses, via POST
+	parsedResponse = getValidatorsByStatusResponse(t, http.MethodPost, []string{string(beacon.ValidatorState_ActiveOngoing), string(beacon.ValidatorState_PendingInitialized)})
+	require.Len(t, parsedResponse.Data, 2)
+	compareValidators(t, v0, &parsedResponse.Data[0])
+	compareValidators(t, v1, &parsedResponse.Data[1])
+	parsedResponse = getValidatorsByStatusResponse(t, http.MethodPost, []string{"exited"})
+	require.Len(t, parsedResponse.Data, 1)
+	compareValidators(t, v2, &parsedResponse.Data[0])
+	t.Log("Filtered by individual statuses via POST")
+
+	// Make sure invalid statuses are rejected
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s?status=retired", port, fmt.Sprintf(api.ValidatorsRouteTemplate, "head")), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	defer response.Body.Close()
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	t.Logf("Received %d status code for an invalid status", response.StatusCode)
+}
+
+// Round trip a validators request filtered by status, using the query for GET or the body for POST
+func getValidatorsByStatusResponse(t *testing.T, method string, statuses []string) client.ValidatorsResponse {
+	// Create the request
+	url := fmt.Sprintf("http://localhost:%d/eth/%s", port, fmt.Sprintf(api.ValidatorsRouteTemplate, "head"))
+	var request *http.Request
+	var err error
+	if method == http.MethodPost {
+		reqBody := api.ValidatorsRequest{
+			Statuses: statuses,
+		}
+		reqBodyBytes, err := json.Marshal(reqBody)
+		if err != nil {
+			t.Fatalf("error serializing request body: %v", err)
+		}
+		request, err = http.NewRequest(method, url, bytes.NewReader(reqBodyBytes))
+		if err != nil {
+			t.Fatalf("error creating request: %v", err)
+		}
+	} else {
+		request, err = http.NewRequest(method, url, nil)
+		if err != nil {
+			t.Fatalf("error creating request: %v", err)
+		}
+		query := request.URL.Query()
+		query["status"] = statuses
+		request.URL.RawQuery = query.Encode()
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse client.ValidatorsResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
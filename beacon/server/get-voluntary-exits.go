@@ -0,0 +1,32 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/rocket-pool/node-manager-core/beacon/client"
+	"github.com/rocket-pool/node-manager-core/utils"
+)
+
+// Handle a get voluntary exits request
+func (s *BeaconMockServer) getVoluntaryExits(w http.ResponseWriter, r *http.Request) {
+	// Log the request
+	s.processApiRequest(w, r, nil)
+
+	// Get the exits
+	exits := s.manager.GetVoluntaryExits()
+	response := api.VoluntaryExitsResponse{
+		Data: make([]client.VoluntaryExitRequest, len(exits)),
+	}
+	for i, exit := range exits {
+		response.Data[i] = client.VoluntaryExitRequest{
+			Message: client.VoluntaryExitMessage{
+				Epoch:          utils.Uinteger(exit.Epoch),
+				ValidatorIndex: strconv.FormatUint(exit.ValidatorIndex, 10),
+			},
+			Signature: exit.Signature[:],
+		}
+	}
+	handleSuccess(s.logger, w, response)
+}
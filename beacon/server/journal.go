@@ -0,0 +1,99 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/nodeset-org/osha/beacon/api"
+)
+
+// A response writer that keeps track of the status code written to it
+type recordingResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+// Write the status code header
+func (w *recordingResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write part of the body, which implicitly writes a 200 status code if one wasn't written yet
+func (w *recordingResponseWriter) Write(data []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// Flush the response to the client, for streaming routes
+func (w *recordingResponseWriter) Flush() {
+	flusher, ok := w.ResponseWriter.(http.Flusher)
+	if ok {
+		flusher.Flush()
+	}
+}
+
+// Take over the connection, for faults that drop it
+func (w *recordingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer doesn't support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
+// Middleware that records API requests in the manager's journal once their responses are done. Requests that never
+// got a response, such as ones whose connections were dropped, are recorded with a status code of 0.
+func (s *BeaconMockServer) recordRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timestamp := time.Now()
+
+		// Read the body and put it back for the handler
+		body := []byte{}
+		if r.Body != nil {
+			var err error
+			body, err = io.ReadAll(r.Body)
+			if err != nil {
+				handleInputError(s.logger, w, fmt.Errorf("error reading request body: %w", err))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		// Handle the request
+		recorder := &recordingResponseWriter{
+			ResponseWriter: w,
+		}
+		next.ServeHTTP(recorder, r)
+
+		// Record it
+		route := ""
+		if currentRoute := mux.CurrentRoute(r); currentRoute != nil {
+			template, err := currentRoute.GetPathTemplate()
+			if err == nil {
+				route = strings.TrimPrefix(template, "/eth/")
+			}
+		}
+		s.manager.RecordRequest(api.RecordedRequest{
+			Method:     r.Method,
+			Route:      route,
+			Path:       r.URL.Path,
+			PathVars:   mux.Vars(r),
+			Query:      r.URL.Query(),
+			Body:       string(body),
+			StatusCode: recorder.statusCode,
+			Timestamp:  timestamp,
+		})
+	})
+}
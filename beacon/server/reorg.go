@@ -0,0 +1,42 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+func (s *BeaconMockServer) reorg(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	depthString, exists := args["depth"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing depth"))
+		return
+	}
+	blocksString, exists := args["blocks"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing blocks"))
+		return
+	}
+
+	// Input validation
+	depth, err := strconv.ParseUint(depthString[0], 10, 64)
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("invalid depth [%s]: %w", depthString[0], err))
+		return
+	}
+	blocks, err := strconv.ParseUint(blocksString[0], 10, 64)
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("invalid blocks [%s]: %w", blocksString[0], err))
+		return
+	}
+
+	// Reorg the chain
+	_, err = s.manager.Reorg(depth, blocks)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	handleSuccess(s.logger, w, nil)
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test reorging the chain
+func TestReorg(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+	for i := 0; i < 3; i++ {
+		sendCommitBlockRequest(t, true)
+	}
+	oldHead := d.GetHeadBlock()
+
+	// Open the stream
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	response := sendEventsRequest(t, ctx, api.ChainReorgTopic, api.BlockTopic)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	reader := bufio.NewReader(response.Body)
+
+	// Replace the last 2 slots with a single block
+	sendReorgRequest(t, 2, 1, http.StatusOK)
+	newHead := d.GetHeadBlock()
+	require.Equal(t, uint64(1), newHead.Slot)
+	require.NotEqual(t, oldHead.Root, newHead.Root)
+	require.Nil(t, d.GetBlockBySlot(2))
+	require.Equal(t, uint64(3), d.GetCurrentSlot())
+	t.Log("Chain was reorged")
+
+	// Check the events
+	var reorgEvent api.ChainReorgEvent
+	readEvent(t, reader, api.ChainReorgTopic, &reorgEvent)
+	require.Equal(t, uint64(1), uint64(reorgEvent.Slot))
+	require.Equal(t, uint64(2), uint64(reorgEvent.Depth))
+	require.Equal(t, oldHead.Root, reorgEvent.OldHeadBlock)
+	require.Equal(t, oldHead.StateRoot, reorgEvent.OldHeadState)
+	require.Equal(t, newHead.Root, reorgEvent.NewHeadBlock)
+	require.Equal(t, newHead.StateRoot, reorgEvent.NewHeadState)
+	var blockEvent api.BlockEvent
+	readEvent(t, reader, api.BlockTopic, &blockEvent)
+	require.Equal(t, newHead.Root, blockEvent.Block)
+	t.Log("Received chain reorg and block events")
+
+	// Invalid reorgs
+	sendReorgRequest(t, 0, 0, http.StatusBadRequest)
+	sendReorgRequest(t, 1, 2, http.StatusBadRequest)
+	sendReorgRequest(t, 4, 0, http.StatusBadRequest)
+	t.Log("Invalid reorgs were rejected")
+}
+
+func sendReorgRequest(t *testing.T, depth uint64, blocks uint64, expectedStatus int) {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.ReorgRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	query.Add("depth", strconv.FormatUint(depth, 10))
+	query.Add("blocks", strconv.FormatUint(blocks, 10))
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, expectedStatus, response.StatusCode)
+	t.Logf("Received %d status code", response.StatusCode)
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/goccy/go-json"
 	"github.com/nodeset-org/osha/beacon/api"
@@ -23,6 +25,22 @@ func handleInputError(logger *slog.Logger, w http.ResponseWriter, err error) {
 	writeResponse(logger, w, code, bytes)
 }
 
+// Handles a request for a resource that doesn't exist
+func handleNotFound(logger *slog.Logger, w http.ResponseWriter, err error) {
+	msg := err.Error()
+	code := http.StatusNotFound
+	bytes := formatError(code, msg)
+	writeResponse(logger, w, code, bytes)
+}
+
+// Handles a request for a content type the route can't provide
+func handleNotAcceptable(logger *slog.Logger, w http.ResponseWriter, err error) {
+	msg := err.Error()
+	code := http.StatusNotAcceptable
+	bytes := formatError(code, msg)
+	writeResponse(logger, w, code, bytes)
+}
+
 // Write an error if the auth header couldn't be decoded
 func handleServerError(logger *slog.Logger, w http.ResponseWriter, err error) {
 	msg := err.Error()
@@ -48,8 +66,52 @@ func handleSuccess(logger *slog.Logger, w http.ResponseWriter, message any) {
 	writeResponse(logger, w, http.StatusOK, bytes)
 }
 
+// The request completed successfully, and the response is SSZ-encoded
+func handleSszSuccess(logger *slog.Logger, w http.ResponseWriter, data []byte) {
+	logger.Debug("SSZ response", slog.Int("size", len(data)))
+	writeContentResponse(logger, w, http.StatusOK, api.SszContentType, data)
+}
+
+// Check if the client prefers an SSZ response over JSON, based on the quality values in its Accept header. JSON is used
+// unless SSZ is explicitly requested with at least the same quality.
+func acceptsSsz(r *http.Request) bool {
+	sszQuality := 0.0
+	jsonQuality := 0.0
+	for _, header := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(header, ",") {
+			// Get the quality of the media range
+			params := strings.Split(mediaRange, ";")
+			mediaType := strings.TrimSpace(params[0])
+			quality := 1.0
+			for _, param := range params[1:] {
+				value, isQuality := strings.CutPrefix(strings.TrimSpace(param), "q=")
+				if !isQuality {
+					continue
+				}
+				parsed, err := strconv.ParseFloat(value, 64)
+				if err == nil {
+					quality = parsed
+				}
+			}
+
+			switch mediaType {
+			case api.SszContentType:
+				sszQuality = max(sszQuality, quality)
+			case api.JsonContentType, "application/*", "*/*":
+				jsonQuality = max(jsonQuality, quality)
+			}
+		}
+	}
+	return sszQuality > 0 && sszQuality >= jsonQuality
+}
+
 // Writes a response to an HTTP request back to the client and logs it
 func writeResponse(logger *slog.Logger, w http.ResponseWriter, statusCode int, message []byte) {
+	writeContentResponse(logger, w, statusCode, api.JsonContentType, message)
+}
+
+// Writes a response with the given content type to an HTTP request back to the client and logs it
+func writeContentResponse(logger *slog.Logger, w http.ResponseWriter, statusCode int, contentType string, message []byte) {
 	// Prep the log attributes
 	codeMsg := fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode))
 	attrs := []any{
@@ -68,7 +130,7 @@ func writeResponse(logger *slog.Logger, w http.ResponseWriter, statusCode int, m
 	}
 
 	// Write it to the client
-	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Content-Type", contentType)
 	w.WriteHeader(statusCode)
 	_, writeErr := w.Write(message)
 	if writeErr != nil {
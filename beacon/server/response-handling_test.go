@@ -0,0 +1,69 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/stretchr/testify/require"
+)
+
+// Test choosing between SSZ and JSON responses based on the Accept header
+func TestAcceptsSsz(t *testing.T) {
+	tests := map[string]bool{
+		"":                             false,
+		"*/*":                          false,
+		"application/json":             false,
+		"application/octet-stream":     true,
+		"application/octet-stream;q=0": false,
+		"application/octet-stream;q=1,application/json;q=0.9": true,
+		"application/octet-stream;q=0.5,application/json":     false,
+		"application/json;q=0.5, application/octet-stream":    true,
+		"application/octet-stream;q=0.9, */*;q=0.1":           true,
+		"application/octet-stream;q=0.5, application/*":       false,
+	}
+	for accept, expected := range tests {
+		request, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+		if err != nil {
+			t.Fatalf("error creating request: %v", err)
+		}
+		if accept != "" {
+			request.Header.Set("Accept", accept)
+		}
+		require.Equal(t, expected, acceptsSsz(request), "Accept header [%s]", accept)
+	}
+	t.Log("Chose the right encoding for each Accept header")
+}
+
+// Send a GET request for an SSZ response to the given route, returning the response and its body
+func getSszResponse(t *testing.T, route string) (*http.Response, []byte) {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, route), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	request.Header.Set("Accept", api.SszContentType)
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the response
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, api.SszContentType, response.Header.Get("Content-Type"))
+	t.Logf("Received OK status code with an SSZ body")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	return response, bytes
+}
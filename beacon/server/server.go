@@ -28,6 +28,7 @@ type BeaconMockServer struct {
 	server  http.Server
 	router  *mux.Router
 	manager *manager.BeaconMockManager
+	faults  *faultInjector
 }
 
 func NewBeaconMockServer(logger *slog.Logger, ip string, port uint16, config *db.Config) (*BeaconMockServer, error) {
@@ -44,10 +45,16 @@ func NewBeaconMockServer(logger *slog.Logger, ip string, port uint16, config *db
 			Handler: router,
 		},
 		manager: manager.NewBeaconMockManager(logger, config),
+		faults:  newFaultInjector(),
 	}
 
+	// Close any open event streams when shutting down, since they would otherwise keep the server from stopping
+	server.server.RegisterOnShutdown(server.manager.CloseEventSubscriptions)
+	server.server.RegisterOnShutdown(server.faults.close)
+
 	// Register each route
 	apiRouter := router.PathPrefix("/eth").Subrouter()
+	apiRouter.Use(server.recordRequests, server.injectFaults)
 	server.registerApiRoutes(apiRouter)
 	adminRouter := router.PathPrefix("/admin").Subrouter()
 	server.registerAdminRoutes(adminRouter)
@@ -95,9 +102,15 @@ func (s *BeaconMockServer) GetPort() uint16 {
 	return s.port
 }
 
+// Get the manager that holds the server's chain state
+func (s *BeaconMockServer) GetManager() *manager.BeaconMockManager {
+	return s.manager
+}
+
 // API routes
 func (s *BeaconMockServer) registerApiRoutes(apiRouter *mux.Router) {
 	apiRouter.HandleFunc("/"+api.ValidatorsRoute, s.getValidators)
+	apiRouter.HandleFunc("/"+api.ValidatorBalancesRoute, s.getValidatorBalances)
 	apiRouter.HandleFunc("/"+api.ValidatorRoute, func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
@@ -114,6 +127,46 @@ func (s *BeaconMockServer) registerApiRoutes(apiRouter *mux.Router) {
 			handleInvalidMethod(s.logger, w)
 		}
 	})
+	apiRouter.HandleFunc("/"+api.NodeVersionRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getNodeVersion(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.NodeHealthRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getNodeHealth(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.NodePeersRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getNodePeers(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.NodePeerCountRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getNodePeerCount(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.NodeIdentityRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getNodeIdentity(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
 	apiRouter.HandleFunc("/"+api.DepositContractRoute, func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
@@ -138,6 +191,22 @@ func (s *BeaconMockServer) registerApiRoutes(apiRouter *mux.Router) {
 			handleInvalidMethod(s.logger, w)
 		}
 	})
+	apiRouter.HandleFunc("/"+api.ForkScheduleRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getForkSchedule(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.StateForkRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getStateFork(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
 	apiRouter.HandleFunc("/"+api.FinalityCheckpointsRoute, func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
@@ -154,14 +223,120 @@ func (s *BeaconMockServer) registerApiRoutes(apiRouter *mux.Router) {
 			handleInvalidMethod(s.logger, w)
 		}
 	})
+	apiRouter.HandleFunc("/"+api.PendingPartialWithdrawalsRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getPendingPartialWithdrawals(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.PendingConsolidationsRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getPendingConsolidations(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.BlockHeaderRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getBlockHeader(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.BlockRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getBlock(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.DebugStateRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getDebugState(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.VoluntaryExitsRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getVoluntaryExits(w, r)
+		case http.MethodPost:
+			s.submitVoluntaryExit(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.BlsToExecutionChangesRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getBlsToExecutionChanges(w, r)
+		case http.MethodPost:
+			s.submitBlsToExecutionChanges(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.ProposerSlashingsRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getProposerSlashings(w, r)
+		case http.MethodPost:
+			s.submitProposerSlashing(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.AttesterSlashingsRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getAttesterSlashings(w, r)
+		case http.MethodPost:
+			s.submitAttesterSlashing(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.EventsRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getEvents(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.ProposerDutiesRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getProposerDuties(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	apiRouter.HandleFunc("/"+api.SyncDutiesRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			s.getSyncDuties(w, r)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
 }
 
-// Admin routes
+// Admin routes, which accept query parameters with GET or a JSON body with POST
 func (s *BeaconMockServer) registerAdminRoutes(adminRouter *mux.Router) {
 	adminRouter.HandleFunc("/"+api.AddValidatorRoute, func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
 			s.addValidator(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.addValidator)
 		default:
 			handleInvalidMethod(s.logger, w)
 		}
@@ -170,6 +345,8 @@ func (s *BeaconMockServer) registerAdminRoutes(adminRouter *mux.Router) {
 		switch r.Method {
 		case http.MethodGet:
 			s.commitBlock(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.commitBlock)
 		default:
 			handleInvalidMethod(s.logger, w)
 		}
@@ -178,6 +355,8 @@ func (s *BeaconMockServer) registerAdminRoutes(adminRouter *mux.Router) {
 		switch r.Method {
 		case http.MethodGet:
 			s.setBalance(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.setBalance)
 		default:
 			handleInvalidMethod(s.logger, w)
 		}
@@ -186,6 +365,8 @@ func (s *BeaconMockServer) registerAdminRoutes(adminRouter *mux.Router) {
 		switch r.Method {
 		case http.MethodGet:
 			s.setStatus(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.setStatus)
 		default:
 			handleInvalidMethod(s.logger, w)
 		}
@@ -194,6 +375,8 @@ func (s *BeaconMockServer) registerAdminRoutes(adminRouter *mux.Router) {
 		switch r.Method {
 		case http.MethodGet:
 			s.slash(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.slash)
 		default:
 			handleInvalidMethod(s.logger, w)
 		}
@@ -202,6 +385,188 @@ func (s *BeaconMockServer) registerAdminRoutes(adminRouter *mux.Router) {
 		switch r.Method {
 		case http.MethodGet:
 			s.setHighestSlot(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.setHighestSlot)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.SetFinalityStalledRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.setFinalityStalled(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.setFinalityStalled)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.ForceFinalizationRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.forceFinalization(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.forceFinalization)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.SetProposerRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.setProposer(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.setProposer)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.AddToSyncCommitteeRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.addToSyncCommittee(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.addToSyncCommittee)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.SetRewardsRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.setRewards(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.setRewards)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.SetParticipationRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.setParticipation(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.setParticipation)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.AddPendingPartialWithdrawalRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.addPendingPartialWithdrawal(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.addPendingPartialWithdrawal)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.AddPendingConsolidationRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.addPendingConsolidation(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.addPendingConsolidation)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.ReorgRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.reorg(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.reorg)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.SetNodeVersionRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.setNodeVersion(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.setNodeVersion)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.SetNodeHealthRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.setNodeHealth(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.setNodeHealth)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.SetPeerCountRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.setPeerCount(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.setPeerCount)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.SetNodeIdentityRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.setNodeIdentity(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.setNodeIdentity)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.AddFaultRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.addFault(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.addFault)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.ClearFaultsRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.clearFaults(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.clearFaults)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.GetRecordedRequestsRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.getRecordedRequests(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.getRecordedRequests)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.ClearRecordedRequestsRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.clearRecordedRequests(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.clearRecordedRequests)
+		default:
+			handleInvalidMethod(s.logger, w)
+		}
+	})
+	adminRouter.HandleFunc("/"+api.SetRecordedRequestsCapacityRoute, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.setRecordedRequestsCapacity(w, r)
+		case http.MethodPost:
+			s.handleJsonAdminRequest(w, r, s.setRecordedRequestsCapacity)
 		default:
 			handleInvalidMethod(s.logger, w)
 		}
@@ -236,3 +601,24 @@ func (s *BeaconMockServer) processApiRequest(w http.ResponseWriter, r *http.Requ
 
 	return args
 }
+
+// Get the state referenced by the state ID in the request's path.
+// Writes an error response and returns nil if the ID is missing, invalid, or refers to a state that doesn't exist.
+func (s *BeaconMockServer) getState(w http.ResponseWriter, r *http.Request) *db.State {
+	vars := mux.Vars(r)
+	id, exists := vars[api.StateID]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing state ID"))
+		return nil
+	}
+	state, err := s.manager.GetState(id)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return nil
+	}
+	if state == nil {
+		handleNotFound(s.logger, w, fmt.Errorf("state [%s] not found", id))
+		return nil
+	}
+	return state
+}
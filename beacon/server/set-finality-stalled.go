@@ -0,0 +1,28 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+func (s *BeaconMockServer) setFinalityStalled(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	stalledString, exists := args["stalled"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing stalled arg"))
+		return
+	}
+
+	// Input validation
+	stalled, err := strconv.ParseBool(stalledString[0])
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("error parsing stalled arg [%s]: %w", stalledString[0], err))
+		return
+	}
+
+	// Stall or resume finality
+	s.manager.SetFinalityStalled(stalled)
+	handleSuccess(s.logger, w, nil)
+}
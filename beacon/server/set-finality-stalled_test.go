@@ -0,0 +1,75 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test stalling finality
+func TestSetFinalityStalled(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+
+	// Stall finality and run through 4 epochs
+	sendSetFinalityStalledRequest(t, true)
+	slotsPerEpoch := server.manager.GetConfig().SlotsPerEpoch
+	for i := uint64(0); i < 4*slotsPerEpoch; i++ {
+		d.CommitBlock(true)
+	}
+	t.Log("Committed 4 epochs of blocks with finality stalled")
+
+	// Make sure the response is correct
+	parsedResponse := getFinalityCheckpointsResponse(t)
+	require.Equal(t, uint64(0), uint64(parsedResponse.Data.CurrentJustified.Epoch))
+	require.Equal(t, uint64(0), uint64(parsedResponse.Data.Finalized.Epoch))
+	t.Log("Finality didn't advance")
+
+	// Resume finality and run another epoch
+	sendSetFinalityStalledRequest(t, false)
+	for i := uint64(0); i < slotsPerEpoch; i++ {
+		d.CommitBlock(true)
+	}
+	parsedResponse = getFinalityCheckpointsResponse(t)
+	require.Equal(t, uint64(4), uint64(parsedResponse.Data.CurrentJustified.Epoch))
+	require.Equal(t, uint64(3), uint64(parsedResponse.Data.Finalized.Epoch))
+	t.Logf("Received correct response - justified: %d, finalized: %d", parsedResponse.Data.CurrentJustified.Epoch, parsedResponse.Data.Finalized.Epoch)
+}
+
+func sendSetFinalityStalledRequest(t *testing.T, stalled bool) {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.SetFinalityStalledRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	query.Add("stalled", strconv.FormatBool(stalled))
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+func (s *BeaconMockServer) setNodeHealth(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	statusString, exists := args["status"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing status"))
+		return
+	}
+
+	// Input validation
+	status, err := strconv.Atoi(statusString[0])
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("invalid status [%s]: %w", statusString[0], err))
+		return
+	}
+
+	// Set the health status
+	err = s.manager.SetNodeHealth(status)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	handleSuccess(s.logger, w, nil)
+}
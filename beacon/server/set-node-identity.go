@@ -0,0 +1,36 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/nodeset-org/osha/beacon/db"
+)
+
+func (s *BeaconMockServer) setNodeIdentity(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	peerID, exists := args["peer_id"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing peer_id"))
+		return
+	}
+	enr, exists := args["enr"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing enr"))
+		return
+	}
+
+	// Set the identity
+	err := s.manager.SetNodeIdentity(db.NodeIdentity{
+		PeerID:             peerID[0],
+		Enr:                enr[0],
+		P2PAddresses:       getListValues(args["p2p_address"]),
+		DiscoveryAddresses: getListValues(args["discovery_address"]),
+	})
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	handleSuccess(s.logger, w, nil)
+}
@@ -0,0 +1,20 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func (s *BeaconMockServer) setNodeVersion(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	version, exists := args["version"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing version"))
+		return
+	}
+
+	// Set the version
+	s.manager.SetNodeVersion(version[0])
+	handleSuccess(s.logger, w, nil)
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+func (s *BeaconMockServer) setParticipation(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	id, exists := args["id"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing validator ID"))
+		return
+	}
+	participationString, exists := args["participation"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing participation"))
+		return
+	}
+
+	// Input validation
+	participation, err := strconv.ParseFloat(participationString[0], 64)
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("invalid participation [%s]: %w", participationString[0], err))
+		return
+	}
+
+	// Get the validator
+	validator, err := s.manager.GetValidator(id[0])
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	if validator == nil {
+		handleInputError(s.logger, w, fmt.Errorf("validator [%s] not found", id[0]))
+		return
+	}
+
+	// Set the participation
+	err = s.manager.SetParticipation(validator.Index, participation)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	handleSuccess(s.logger, w, nil)
+}
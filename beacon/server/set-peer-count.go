@@ -0,0 +1,32 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+func (s *BeaconMockServer) setPeerCount(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	countString, exists := args["count"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing count"))
+		return
+	}
+
+	// Input validation
+	count, err := strconv.ParseUint(countString[0], 10, 64)
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("invalid count [%s]: %w", countString[0], err))
+		return
+	}
+
+	// Set the peer count
+	err = s.manager.SetPeerCount(count)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	handleSuccess(s.logger, w, nil)
+}
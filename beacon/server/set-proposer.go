@@ -0,0 +1,48 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+func (s *BeaconMockServer) setProposer(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	id, exists := args["id"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing validator ID"))
+		return
+	}
+	slotString, exists := args["slot"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing slot"))
+		return
+	}
+
+	// Input validation
+	slot, err := strconv.ParseUint(slotString[0], 10, 64)
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("invalid slot [%s]: %w", slotString[0], err))
+		return
+	}
+
+	// Get the validator
+	validator, err := s.manager.GetValidator(id[0])
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	if validator == nil {
+		handleInputError(s.logger, w, fmt.Errorf("validator [%s] not found", id[0]))
+		return
+	}
+
+	// Set the proposer
+	err = s.manager.SetProposer(slot, validator.Index)
+	if err != nil {
+		handleInputError(s.logger, w, err)
+		return
+	}
+	handleSuccess(s.logger, w, nil)
+}
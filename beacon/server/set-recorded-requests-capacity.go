@@ -0,0 +1,28 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+func (s *BeaconMockServer) setRecordedRequestsCapacity(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	capacityString, exists := args["capacity"]
+	if !exists {
+		handleInputError(s.logger, w, fmt.Errorf("missing capacity"))
+		return
+	}
+
+	// Input validation
+	capacity, err := strconv.ParseUint(capacityString[0], 10, 31)
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("invalid capacity [%s]: %w", capacityString[0], err))
+		return
+	}
+
+	// Set the capacity
+	s.manager.SetRecordedRequestsCapacity(int(capacity))
+	handleSuccess(s.logger, w, nil)
+}
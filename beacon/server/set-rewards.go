@@ -0,0 +1,50 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+func (s *BeaconMockServer) setRewards(w http.ResponseWriter, r *http.Request) {
+	// Get the request vars
+	args := s.processApiRequest(w, r, nil)
+	enabledString, enabledExists := args["enabled"]
+	aprString, aprExists := args["apr"]
+	if !enabledExists && !aprExists {
+		handleInputError(s.logger, w, fmt.Errorf("missing enabled or apr arg"))
+		return
+	}
+
+	// Input validation
+	var enabled bool
+	var apr float64
+	var err error
+	if enabledExists {
+		enabled, err = strconv.ParseBool(enabledString[0])
+		if err != nil {
+			handleInputError(s.logger, w, fmt.Errorf("error parsing enabled arg [%s]: %w", enabledString[0], err))
+			return
+		}
+	}
+	if aprExists {
+		apr, err = strconv.ParseFloat(aprString[0], 64)
+		if err != nil {
+			handleInputError(s.logger, w, fmt.Errorf("invalid apr [%s]: %w", aprString[0], err))
+			return
+		}
+	}
+
+	// Update the rewards settings
+	if aprExists {
+		err = s.manager.SetRewardsApr(apr)
+		if err != nil {
+			handleInputError(s.logger, w, err)
+			return
+		}
+	}
+	if enabledExists {
+		s.manager.SetRewardsEnabled(enabled)
+	}
+	handleSuccess(s.logger, w, nil)
+}
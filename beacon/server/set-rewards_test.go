@@ -0,0 +1,94 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// Test enabling rewards and marking a validator offline
+func TestSetRewards(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+	online, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 0)
+	offline, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 1)
+	startingBalance := online.Balance
+
+	// Enable rewards and mark one validator offline
+	sendSetRewardsRequest(t, true, 0.05)
+	sendSetParticipationRequest(t, strconv.FormatUint(offline.Index, 10), 0)
+	require.True(t, d.GetRewardsEnabled())
+	require.Equal(t, 0.05, d.GetRewardsApr())
+	require.Equal(t, float64(0), d.GetParticipation(offline.Index))
+
+	// Run through an epoch of missed slots
+	for i := uint64(0); i < server.manager.GetConfig().SlotsPerEpoch; i++ {
+		d.CommitBlock(false)
+	}
+	require.Greater(t, online.Balance, startingBalance)
+	require.Less(t, offline.Balance, startingBalance)
+	t.Logf("Balances updated - online: %d, offline: %d", online.Balance, offline.Balance)
+}
+
+func sendSetRewardsRequest(t *testing.T, enabled bool, apr float64) {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.SetRewardsRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	query.Add("enabled", strconv.FormatBool(enabled))
+	query.Add("apr", strconv.FormatFloat(apr, 'f', -1, 64))
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+}
+
+func sendSetParticipationRequest(t *testing.T, id string, participation float64) {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/admin/%s", port, api.SetParticipationRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	query := request.URL.Query()
+	query.Add("id", id)
+	query.Add("participation", strconv.FormatFloat(participation, 'f', -1, 64))
+	request.URL.RawQuery = query.Encode()
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+}
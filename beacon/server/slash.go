@@ -14,17 +14,16 @@ func (s *BeaconMockServer) slash(w http.ResponseWriter, r *http.Request) {
 		handleInputError(s.logger, w, fmt.Errorf("missing validator ID"))
 		return
 	}
-	penaltyString, exists := args["penalty"]
-	if !exists {
-		handleInputError(s.logger, w, fmt.Errorf("missing penalty"))
-		return
-	}
 
-	// Input validation
-	penalty, err := strconv.ParseUint(penaltyString[0], 10, 64)
-	if err != nil {
-		handleInputError(s.logger, w, fmt.Errorf("invalid penalty [%s]: %w", penaltyString[0], err))
-		return
+	// The penalty is optional; if it's provided, it replaces the spec's penalties and rewards
+	var penalty *uint64
+	if penaltyString, exists := args["penalty"]; exists {
+		parsed, err := strconv.ParseUint(penaltyString[0], 10, 64)
+		if err != nil {
+			handleInputError(s.logger, w, fmt.Errorf("invalid penalty [%s]: %w", penaltyString[0], err))
+			return
+		}
+		penalty = &parsed
 	}
 
 	// Get the validator
@@ -33,9 +32,17 @@ func (s *BeaconMockServer) slash(w http.ResponseWriter, r *http.Request) {
 		handleInputError(s.logger, w, err)
 		return
 	}
+	if validator == nil {
+		handleInputError(s.logger, w, fmt.Errorf("validator [%s] not found", id[0]))
+		return
+	}
 
 	// Slash the validator
-	err = validator.Slash(penalty)
+	if penalty != nil {
+		err = s.manager.SlashValidatorWithPenalty(validator.Index, *penalty)
+	} else {
+		err = s.manager.SlashValidator(validator.Index)
+	}
 	if err != nil {
 		handleInputError(s.logger, w, err)
 		return
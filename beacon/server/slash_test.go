@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
 	idb "github.com/nodeset-org/osha/beacon/internal/db"
 	"github.com/rocket-pool/node-manager-core/beacon"
 	"github.com/stretchr/testify/require"
@@ -80,3 +81,56 @@ func sendSlashRequest(t *testing.T, id string, penalty uint64) {
 	require.Equal(t, http.StatusOK, response.StatusCode)
 	t.Logf("Received OK status code")
 }
+
+// Test slashing a validator without a custom penalty, which applies the spec's penalties
+func TestSlashSpecPenalty(t *testing.T) {
+	penalty := uint64(32e9) / db.MinSlashingPenaltyQuotientBellatrix
+	reward := uint64(32e9) / db.WhistleblowerRewardQuotient
+
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+	v1 := d.GetValidatorByIndex(1)
+	id := v1.Pubkey.HexWithPrefix()
+
+	// Make the validator active
+	sendSetStatusRequest(t, id, beacon.ValidatorState_ActiveOngoing)
+	t.Log("Marked the validator as active")
+
+	// Make another validator the proposer so it gets the whistleblower reward
+	proposerID := d.GetValidatorByIndex(0).Pubkey.HexWithPrefix()
+	sendSetProposerRequest(t, proposerID, d.GetCurrentSlot())
+
+	// Send the slash request
+	code, _ := sendJsonAdminRequest(t, http.MethodPost, api.SlashRoute, fmt.Sprintf(`{"id": "%s"}`, id))
+	require.Equal(t, http.StatusOK, code)
+
+	// Get the validator's status now
+	parsedResponse := getValidatorResponse(t, id)
+
+	// Make sure the response is correct
+	require.Equal(t, string(beacon.ValidatorState_ActiveSlashed), parsedResponse.Data.Status)
+	require.Equal(t, uint64(32e9)-penalty, uint64(parsedResponse.Data.Balance))
+	require.True(t, parsedResponse.Data.Validator.Slashed)
+	require.NotEqual(t, db.FarFutureEpoch, uint64(parsedResponse.Data.Validator.ExitEpoch))
+	require.Equal(t, d.GetCurrentSlot()/server.manager.GetConfig().SlotsPerEpoch+db.EpochsPerSlashingsVector, uint64(parsedResponse.Data.Validator.WithdrawableEpoch))
+	t.Logf("Received correct response - status: %s, balance: %d, slashed: %t", parsedResponse.Data.Status, parsedResponse.Data.Balance, parsedResponse.Data.Validator.Slashed)
+
+	// Make sure the proposer got the whistleblower reward
+	parsedResponse = getValidatorResponse(t, proposerID)
+	require.Equal(t, uint64(32e9)+reward, uint64(parsedResponse.Data.Balance))
+	t.Logf("Proposer received the whistleblower reward - balance: %d", parsedResponse.Data.Balance)
+
+	// Validators can't be slashed twice
+	code, _ = sendJsonAdminRequest(t, http.MethodPost, api.SlashRoute, fmt.Sprintf(`{"id": "%s"}`, id))
+	require.Equal(t, http.StatusBadRequest, code)
+}
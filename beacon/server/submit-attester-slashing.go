@@ -0,0 +1,26 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/nodeset-org/osha/beacon/api"
+)
+
+// Handle a attester slashing submission
+func (s *BeaconMockServer) submitAttesterSlashing(w http.ResponseWriter, r *http.Request) {
+	// Get the request body
+	var request api.AttesterSlashing
+	args := s.processApiRequest(w, r, &request)
+	if args == nil {
+		return
+	}
+
+	// Submit the slashing
+	err := s.manager.SubmitAttesterSlashing(request)
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("invalid attester slashing: %w", err))
+		return
+	}
+	handleSuccess(s.logger, w, nil)
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// Test submitting attester slashings
+func TestSubmitAttesterSlashing(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+	v0, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 0)
+	v1, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 1)
+	v2, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 2)
+
+	// Attestations for different targets without a surround vote can't be slashed
+	slashing := api.AttesterSlashing{
+		Attestation1: createIndexedAttestation([]uint64{v0.Index, v1.Index}, 1, 2, common.HexToHash("0x01")),
+		Attestation2: createIndexedAttestation([]uint64{v1.Index, v2.Index}, 2, 3, common.HexToHash("0x01")),
+	}
+	sendAttesterSlashingRequest(t, slashing, http.StatusBadRequest)
+	require.False(t, v1.Slashed)
+
+	// Submit a valid double vote
+	slashing.Attestation2 = createIndexedAttestation([]uint64{v1.Index, v2.Index}, 1, 2, common.HexToHash("0x02"))
+	sendAttesterSlashingRequest(t, slashing, http.StatusOK)
+	require.False(t, v0.Slashed)
+	require.True(t, v1.Slashed)
+	require.False(t, v2.Slashed)
+	require.Equal(t, beacon.ValidatorState_ActiveSlashed, v1.Status)
+	t.Log("Only the validator in both attestations was slashed")
+
+	// Make sure the slashing is in the pool
+	parsedResponse := getAttesterSlashingsResponse(t)
+	require.Len(t, parsedResponse.Data, 1)
+	require.Equal(t, slashing, parsedResponse.Data[0])
+	t.Log("Slashing is in the pool")
+
+	// Submit a surround vote
+	slashing = api.AttesterSlashing{
+		Attestation1: createIndexedAttestation([]uint64{v0.Index, v2.Index}, 1, 4, common.HexToHash("0x01")),
+		Attestation2: createIndexedAttestation([]uint64{v2.Index}, 2, 3, common.HexToHash("0x01")),
+	}
+	sendAttesterSlashingRequest(t, slashing, http.StatusOK)
+	require.False(t, v0.Slashed)
+	require.True(t, v2.Slashed)
+	require.Len(t, getAttesterSlashingsResponse(t).Data, 2)
+	t.Log("Surround vote was slashed")
+}
+
+// Create an indexed attestation with a placeholder signature
+func createIndexedAttestation(indices []uint64, sourceEpoch uint64, targetEpoch uint64, blockRoot common.Hash) api.IndexedAttestation {
+	attestingIndices := make([]utils.Uinteger, len(indices))
+	for i, index := range indices {
+		attestingIndices[i] = utils.Uinteger(index)
+	}
+	return api.IndexedAttestation{
+		AttestingIndices: attestingIndices,
+		Data: api.AttestationData{
+			Slot:            utils.Uinteger(targetEpoch * server.manager.GetConfig().SlotsPerEpoch),
+			BeaconBlockRoot: blockRoot,
+			Source: api.Checkpoint{
+				Epoch: utils.Uinteger(sourceEpoch),
+			},
+			Target: api.Checkpoint{
+				Epoch: utils.Uinteger(targetEpoch),
+			},
+		},
+		Signature: make(utils.ByteArray, beacon.ValidatorSignatureLength),
+	}
+}
+
+// Submit an attester slashing and check the status code
+func sendAttesterSlashingRequest(t *testing.T, slashing api.AttesterSlashing, expectedStatus int) {
+	// Create the request
+	body, err := json.Marshal(slashing)
+	if err != nil {
+		t.Fatalf("error serializing slashing: %v", err)
+	}
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:%d/eth/%s", port, api.AttesterSlashingsRoute), bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	defer response.Body.Close()
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, expectedStatus, response.StatusCode)
+	t.Logf("Received %d status code", response.StatusCode)
+}
+
+// Round trip an attester slashing pool request
+func getAttesterSlashingsResponse(t *testing.T) api.AttesterSlashingsResponse {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, api.AttesterSlashingsRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.AttesterSlashingsResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
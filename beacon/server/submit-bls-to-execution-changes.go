@@ -0,0 +1,34 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rocket-pool/node-manager-core/beacon/client"
+)
+
+// Handle a BLS-to-execution change submission
+func (s *BeaconMockServer) submitBlsToExecutionChanges(w http.ResponseWriter, r *http.Request) {
+	// Get the request body
+	var request []client.BLSToExecutionChangeRequest
+	args := s.processApiRequest(w, r, &request)
+	if args == nil {
+		return
+	}
+
+	// Submit each change
+	failures := []string{}
+	for i, change := range request {
+		err := s.manager.Beacon_BlsToExecutionChanges_Post(context.Background(), change)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("change %d: %s", i, err.Error()))
+		}
+	}
+	if len(failures) > 0 {
+		handleInputError(s.logger, w, fmt.Errorf("invalid BLS-to-execution changes: %s", strings.Join(failures, "; ")))
+		return
+	}
+	handleSuccess(s.logger, w, nil)
+}
@@ -0,0 +1,210 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/nodeset-org/osha/keys"
+	"github.com/rocket-pool/node-manager-core/beacon/client"
+	"github.com/rocket-pool/node-manager-core/node/validator"
+	"github.com/stretchr/testify/require"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// Test changing a validator's BLS withdrawal credentials to an execution address
+func TestSubmitBlsToExecutionChange(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database with a validator that has BLS credentials
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+	v, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 0)
+	withdrawalKey := getWithdrawalKey(t, 1)
+	v.WithdrawalCredentials = getBlsWithdrawalCredentials(withdrawalKey)
+	address := common.HexToAddress("0x90de5e7000000000000000000000000000000001")
+
+	// Submit the change
+	request := createBlsToExecutionChangeRequest(t, withdrawalKey, v.Index, address)
+	sendBlsToExecutionChangesRequest(t, request, http.StatusOK)
+	parsedResponse := getBlsToExecutionChangesResponse(t)
+	require.Len(t, parsedResponse.Data, 1)
+	require.Equal(t, request.Message.ValidatorIndex, parsedResponse.Data[0].Message.ValidatorIndex)
+	require.Equal(t, db.BlsWithdrawalPrefix, v.WithdrawalCredentials[0])
+	t.Log("Change is in the pool and hasn't been applied yet")
+
+	// Run to the next epoch
+	for i := uint64(0); i < server.manager.GetConfig().SlotsPerEpoch; i++ {
+		d.CommitBlock(true)
+	}
+	expectedCredentials := common.Hash{}
+	expectedCredentials[0] = db.Eth1AddressWithdrawalPrefix
+	copy(expectedCredentials[12:], address[:])
+	require.Equal(t, expectedCredentials, v.WithdrawalCredentials)
+	require.Empty(t, getBlsToExecutionChangesResponse(t).Data)
+	t.Logf("Change was applied - new credentials: %s", v.WithdrawalCredentials.Hex())
+
+	// Changing them again should fail now that they aren't BLS credentials
+	sendBlsToExecutionChangesRequest(t, request, http.StatusBadRequest)
+	t.Log("Second change was rejected")
+}
+
+// Test submitting invalid BLS-to-execution changes
+func TestSubmitInvalidBlsToExecutionChange(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database with a validator that has BLS credentials
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+	v, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 0)
+	withdrawalKey := getWithdrawalKey(t, 1)
+	v.WithdrawalCredentials = getBlsWithdrawalCredentials(withdrawalKey)
+	originalCredentials := v.WithdrawalCredentials
+	address := common.HexToAddress("0x90de5e7000000000000000000000000000000001")
+
+	// Wrong withdrawal key
+	request := createBlsToExecutionChangeRequest(t, getWithdrawalKey(t, 2), v.Index, address)
+	sendBlsToExecutionChangesRequest(t, request, http.StatusBadRequest)
+	t.Log("Change from the wrong withdrawal key was rejected")
+
+	// Bad signature
+	request = createBlsToExecutionChangeRequest(t, withdrawalKey, v.Index, address)
+	otherRequest := createBlsToExecutionChangeRequest(t, getWithdrawalKey(t, 2), v.Index, address)
+	request.Signature = otherRequest.Signature
+	sendBlsToExecutionChangesRequest(t, request, http.StatusBadRequest)
+	t.Log("Change with a bad signature was rejected")
+
+	// Make sure nothing changed
+	for i := uint64(0); i < server.manager.GetConfig().SlotsPerEpoch; i++ {
+		d.CommitBlock(true)
+	}
+	require.Equal(t, originalCredentials, v.WithdrawalCredentials)
+	t.Log("Credentials weren't changed")
+}
+
+// Get a BLS key to use as a withdrawal key
+func getWithdrawalKey(t *testing.T, index uint) *eth2types.BLSPrivateKey {
+	keygen, err := keys.NewKeyGeneratorWithDefaults()
+	if err != nil {
+		t.Fatalf("error creating key generator: %v", err)
+	}
+	key, err := keygen.GetBlsPrivateKey(index)
+	if err != nil {
+		t.Fatalf("error getting BLS key %d: %v", index, err)
+	}
+	return key
+}
+
+// Get the BLS withdrawal credentials for a withdrawal key
+func getBlsWithdrawalCredentials(key *eth2types.BLSPrivateKey) common.Hash {
+	credentials := common.Hash(sha256.Sum256(key.PublicKey().Marshal()))
+	credentials[0] = db.BlsWithdrawalPrefix
+	return credentials
+}
+
+// Create a signed BLS-to-execution change request
+func createBlsToExecutionChangeRequest(t *testing.T, withdrawalKey *eth2types.BLSPrivateKey, index uint64, address common.Address) client.BLSToExecutionChangeRequest {
+	config := server.manager.GetConfig()
+	domain, err := config.ComputeDomain(eth2types.DomainBlsToExecutionChange, config.GenesisForkVersion)
+	if err != nil {
+		t.Fatalf("error computing domain: %v", err)
+	}
+	indexString := strconv.FormatUint(index, 10)
+	signature, err := validator.GetSignedWithdrawalCredsChangeMessage(withdrawalKey, indexString, address, domain)
+	if err != nil {
+		t.Fatalf("error signing change: %v", err)
+	}
+	return client.BLSToExecutionChangeRequest{
+		Message: client.BLSToExecutionChangeMessage{
+			ValidatorIndex:     indexString,
+			FromBLSPubkey:      withdrawalKey.PublicKey().Marshal(),
+			ToExecutionAddress: address[:],
+		},
+		Signature: signature[:],
+	}
+}
+
+// Submit a BLS-to-execution change and check the status code
+func sendBlsToExecutionChangesRequest(t *testing.T, change client.BLSToExecutionChangeRequest, expectedStatus int) {
+	// Create the request
+	body, err := json.Marshal([]client.BLSToExecutionChangeRequest{change})
+	if err != nil {
+		t.Fatalf("error serializing change: %v", err)
+	}
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:%d/eth/%s", port, api.BlsToExecutionChangesRoute), bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	defer response.Body.Close()
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, expectedStatus, response.StatusCode)
+	t.Logf("Received %d status code", response.StatusCode)
+}
+
+// Round trip a BLS-to-execution change pool request
+func getBlsToExecutionChangesResponse(t *testing.T) api.BlsToExecutionChangesResponse {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, api.BlsToExecutionChangesRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.BlsToExecutionChangesResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/nodeset-org/osha/beacon/api"
+)
+
+// Handle a proposer slashing submission
+func (s *BeaconMockServer) submitProposerSlashing(w http.ResponseWriter, r *http.Request) {
+	// Get the request body
+	var request api.ProposerSlashing
+	args := s.processApiRequest(w, r, &request)
+	if args == nil {
+		return
+	}
+
+	// Submit the slashing
+	err := s.manager.SubmitProposerSlashing(request)
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("invalid proposer slashing: %w", err))
+		return
+	}
+	handleSuccess(s.logger, w, nil)
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// Test submitting proposer slashings
+func TestSubmitProposerSlashing(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	d := idb.ProvisionDatabaseForTesting(t, logger)
+	server.manager.SetDatabase(d)
+	v, _ := idb.AddActiveValidatorWithKeyForTesting(t, d, 0)
+
+	// Headers for the same block can't be slashed
+	slashing := api.ProposerSlashing{
+		SignedHeader1: createSignedBlockHeader(v.Index, common.HexToHash("0x01")),
+		SignedHeader2: createSignedBlockHeader(v.Index, common.HexToHash("0x01")),
+	}
+	sendProposerSlashingRequest(t, slashing, http.StatusBadRequest)
+	require.False(t, v.Slashed)
+
+	// Submit a valid slashing
+	slashing.SignedHeader2 = createSignedBlockHeader(v.Index, common.HexToHash("0x02"))
+	sendProposerSlashingRequest(t, slashing, http.StatusOK)
+	require.True(t, v.Slashed)
+	require.Equal(t, beacon.ValidatorState_ActiveSlashed, v.Status)
+	t.Log("Validator was slashed")
+
+	// Make sure the slashing is in the pool
+	parsedResponse := getProposerSlashingsResponse(t)
+	require.Len(t, parsedResponse.Data, 1)
+	require.Equal(t, slashing, parsedResponse.Data[0])
+	t.Log("Slashing is in the pool")
+
+	// The proposer can't be slashed again
+	sendProposerSlashingRequest(t, slashing, http.StatusBadRequest)
+}
+
+// Create a signed block header for the given proposer with a placeholder signature
+func createSignedBlockHeader(proposerIndex uint64, bodyRoot common.Hash) api.SignedBeaconBlockHeader {
+	return api.SignedBeaconBlockHeader{
+		Message: api.BlockHeaderMessage{
+			Slot:          1,
+			ProposerIndex: strconv.FormatUint(proposerIndex, 10),
+			BodyRoot:      bodyRoot,
+		},
+		Signature: make(utils.ByteArray, beacon.ValidatorSignatureLength),
+	}
+}
+
+// Submit a proposer slashing and check the status code
+func sendProposerSlashingRequest(t *testing.T, slashing api.ProposerSlashing, expectedStatus int) {
+	// Create the request
+	body, err := json.Marshal(slashing)
+	if err != nil {
+		t.Fatalf("error serializing slashing: %v", err)
+	}
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:%d/eth/%s", port, api.ProposerSlashingsRoute), bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	defer response.Body.Close()
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, expectedStatus, response.StatusCode)
+	t.Logf("Received %d status code", response.StatusCode)
+}
+
+// Round trip a proposer slashing pool request
+func getProposerSlashingsResponse(t *testing.T) api.ProposerSlashingsResponse {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, api.ProposerSlashingsRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.ProposerSlashingsResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/rocket-pool/node-manager-core/beacon/client"
+)
+
+// Handle a voluntary exit submission
+func (s *BeaconMockServer) submitVoluntaryExit(w http.ResponseWriter, r *http.Request) {
+	// Get the request body
+	var request client.VoluntaryExitRequest
+	args := s.processApiRequest(w, r, &request)
+	if args == nil {
+		return
+	}
+
+	// Submit the exit
+	err := s.manager.Beacon_VoluntaryExits_Post(context.Background(), request)
+	if err != nil {
+		handleInputError(s.logger, w, fmt.Errorf("invalid voluntary exit: %w", err))
+		return
+	}
+	handleSuccess(s.logger, w, nil)
+}
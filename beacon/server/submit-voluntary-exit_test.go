@@ -0,0 +1,188 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/nodeset-org/osha/beacon/api"
+	"github.com/nodeset-org/osha/beacon/db"
+	idb "github.com/nodeset-org/osha/beacon/internal/db"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/rocket-pool/node-manager-core/beacon/client"
+	"github.com/rocket-pool/node-manager-core/node/validator"
+	"github.com/rocket-pool/node-manager-core/utils"
+	"github.com/stretchr/testify/require"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// Test submitting a valid voluntary exit
+func TestSubmitVoluntaryExit(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	config := db.NewDefaultConfig()
+	config.ShardCommitteePeriod = 1
+	d := idb.ProvisionDatabaseWithConfigForTesting(t, logger, config)
+	server.manager.SetDatabase(d)
+	v, key := idb.AddActiveValidatorWithKeyForTesting(t, d, 0)
+
+	// Validators can't exit until they've been active for the shard committee period
+	request := createVoluntaryExitRequest(t, key, v.Index, 0)
+	sendVoluntaryExitRequest(t, request, http.StatusBadRequest)
+	require.Equal(t, beacon.ValidatorState_ActiveOngoing, v.Status)
+	t.Log("Exit before the shard committee period was rejected")
+
+	// Submit the exit
+	for i := uint64(0); i < config.SlotsPerEpoch; i++ {
+		d.CommitBlock(false)
+	}
+	sendVoluntaryExitRequest(t, request, http.StatusOK)
+
+	// Make sure the validator is exiting
+	require.Equal(t, beacon.ValidatorState_ActiveExiting, v.Status)
+	require.NotEqual(t, db.FarFutureEpoch, v.ExitEpoch)
+	require.Equal(t, v.ExitEpoch+db.MinValidatorWithdrawabilityDelay, v.WithdrawableEpoch)
+	t.Logf("Validator is exiting - exit epoch: %d, withdrawable epoch: %d", v.ExitEpoch, v.WithdrawableEpoch)
+
+	// Make sure the exit is in the pool
+	parsedResponse := getVoluntaryExitsResponse(t)
+	require.Len(t, parsedResponse.Data, 1)
+	require.Equal(t, request.Message.ValidatorIndex, parsedResponse.Data[0].Message.ValidatorIndex)
+	require.Equal(t, request.Signature, parsedResponse.Data[0].Signature)
+	t.Log("Exit is in the pool")
+}
+
+// Test submitting invalid voluntary exits
+func TestSubmitInvalidVoluntaryExit(t *testing.T) {
+	// Take a snapshot
+	server.manager.TakeSnapshot("test")
+	defer func() {
+		err := server.manager.RevertToSnapshot("test")
+		if err != nil {
+			t.Fatalf("error reverting to snapshot: %v", err)
+		}
+	}()
+
+	// Provision the database
+	config := db.NewDefaultConfig()
+	config.ShardCommitteePeriod = 0
+	d := idb.ProvisionDatabaseWithConfigForTesting(t, logger, config)
+	server.manager.SetDatabase(d)
+	v, key := idb.AddActiveValidatorWithKeyForTesting(t, d, 0)
+	_, otherKey := idb.AddActiveValidatorWithKeyForTesting(t, d, 1)
+
+	// Bad signature
+	request := createVoluntaryExitRequest(t, otherKey, v.Index, 0)
+	sendVoluntaryExitRequest(t, request, http.StatusBadRequest)
+	t.Log("Exit with a bad signature was rejected")
+
+	// Unknown validator
+	request = createVoluntaryExitRequest(t, key, 99, 0)
+	sendVoluntaryExitRequest(t, request, http.StatusBadRequest)
+	t.Log("Exit for an unknown validator was rejected")
+
+	// Inactive validator
+	request = createVoluntaryExitRequest(t, key, 0, 0)
+	sendVoluntaryExitRequest(t, request, http.StatusBadRequest)
+	t.Log("Exit for an inactive validator was rejected")
+
+	// Make sure nothing changed
+	require.Equal(t, beacon.ValidatorState_ActiveOngoing, v.Status)
+	require.Empty(t, getVoluntaryExitsResponse(t).Data)
+	t.Log("No exits were accepted")
+}
+
+// Create a signed voluntary exit request
+func createVoluntaryExitRequest(t *testing.T, key *eth2types.BLSPrivateKey, index uint64, epoch uint64) client.VoluntaryExitRequest {
+	config := server.manager.GetConfig()
+	domain, err := config.ComputeDomain(eth2types.DomainVoluntaryExit, config.CapellaForkVersion)
+	if err != nil {
+		t.Fatalf("error computing domain: %v", err)
+	}
+	indexString := strconv.FormatUint(index, 10)
+	signature, err := validator.GetSignedExitMessage(key, indexString, epoch, domain)
+	if err != nil {
+		t.Fatalf("error signing exit: %v", err)
+	}
+	return client.VoluntaryExitRequest{
+		Message: client.VoluntaryExitMessage{
+			Epoch:          utils.Uinteger(epoch),
+			ValidatorIndex: indexString,
+		},
+		Signature: signature[:],
+	}
+}
+
+// Submit a voluntary exit and check the status code
+func sendVoluntaryExitRequest(t *testing.T, exit client.VoluntaryExitRequest, expectedStatus int) {
+	// Create the request
+	body, err := json.Marshal(exit)
+	if err != nil {
+		t.Fatalf("error serializing exit: %v", err)
+	}
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:%d/eth/%s", port, api.VoluntaryExitsRoute), bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	defer response.Body.Close()
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, expectedStatus, response.StatusCode)
+	t.Logf("Received %d status code", response.StatusCode)
+}
+
+// Round trip a voluntary exit pool request
+func getVoluntaryExitsResponse(t *testing.T) api.VoluntaryExitsResponse {
+	// Create the request
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/eth/%s", port, api.VoluntaryExitsRoute), nil)
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	t.Logf("Created request")
+
+	// Send the request
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("error sending request: %v", err)
+	}
+	t.Logf("Sent request")
+
+	// Check the status code
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	t.Logf("Received OK status code")
+
+	// Read the body
+	defer response.Body.Close()
+	bytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error reading the response body: %v", err)
+	}
+	var parsedResponse api.VoluntaryExitsResponse
+	err = json.Unmarshal(bytes, &parsedResponse)
+	if err != nil {
+		t.Fatalf("error deserializing response: %v", err)
+	}
+
+	t.Log("Parsed response")
+	return parsedResponse
+}
@@ -0,0 +1,127 @@
+package ssz
+
+import (
+	"encoding/binary"
+)
+
+const (
+	// The size of an offset to a variable-size field or list element, in bytes
+	OffsetSize int = 4
+)
+
+// A field of a container being serialized
+type field struct {
+	data     []byte
+	variable bool
+}
+
+// Builds the SSZ serialization of a container. Fields are added in the order they're declared in the container's
+// definition; fixed-size fields are written in place and variable-size fields are written after them, referenced by
+// offsets.
+type Container struct {
+	fields []field
+}
+
+// Create a new container serializer
+func NewContainer() *Container {
+	return &Container{
+		fields: []field{},
+	}
+}
+
+// Add a uint8 field
+func (c *Container) Uint8(value uint8) *Container {
+	return c.Fixed([]byte{value})
+}
+
+// Add a uint64 field
+func (c *Container) Uint64(value uint64) *Container {
+	return c.Fixed(binary.LittleEndian.AppendUint64(nil, value))
+}
+
+// Add a boolean field
+func (c *Container) Bool(value bool) *Container {
+	if value {
+		return c.Uint8(1)
+	}
+	return c.Uint8(0)
+}
+
+// Add a fixed-size field that's already been serialized, such as a byte vector or a fixed-size container
+func (c *Container) Fixed(data []byte) *Container {
+	c.fields = append(c.fields, field{
+		data: data,
+	})
+	return c
+}
+
+// Add a variable-size field that's already been serialized, such as a list or a variable-size container
+func (c *Container) Variable(data []byte) *Container {
+	c.fields = append(c.fields, field{
+		data:     data,
+		variable: true,
+	})
+	return c
+}
+
+// Get the serialized container
+func (c *Container) Bytes() []byte {
+	// Get the size of the fixed part, which includes the offsets of the variable fields
+	fixedSize := 0
+	for _, field := range c.fields {
+		if field.variable {
+			fixedSize += OffsetSize
+		} else {
+			fixedSize += len(field.data)
+		}
+	}
+
+	// Write the fixed part, then the variable fields
+	data := make([]byte, 0, fixedSize)
+	variableData := []byte{}
+	for _, field := range c.fields {
+		if !field.variable {
+			data = append(data, field.data...)
+			continue
+		}
+		data = binary.LittleEndian.AppendUint32(data, uint32(fixedSize+len(variableData)))
+		variableData = append(variableData, field.data...)
+	}
+	return append(data, variableData...)
+}
+
+// Serialize a list or vector of fixed-size elements that have already been serialized
+func FixedList(elements [][]byte) []byte {
+	data := []byte{}
+	for _, element := range elements {
+		data = append(data, element...)
+	}
+	return data
+}
+
+// Serialize a list of variable-size elements that have already been serialized. Each element is referenced by an
+// offset from the start of the list.
+func VariableList(elements [][]byte) []byte {
+	offsetsSize := len(elements) * OffsetSize
+	data := make([]byte, 0, offsetsSize)
+	elementData := []byte{}
+	for _, element := range elements {
+		data = binary.LittleEndian.AppendUint32(data, uint32(offsetsSize+len(elementData)))
+		elementData = append(elementData, element...)
+	}
+	return append(data, elementData...)
+}
+
+// Serialize a list of uint64 values
+func Uint64List(values []uint64) []byte {
+	data := make([]byte, 0, len(values)*8)
+	for _, value := range values {
+		data = binary.LittleEndian.AppendUint64(data, value)
+	}
+	return data
+}
+
+// Get a zeroed byte vector of the given size, for fields the mock doesn't track
+func Zero(size int) []byte {
+	return make([]byte, size)
+}
@@ -0,0 +1,47 @@
+package ssz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer(t *testing.T) {
+	data := NewContainer().
+		Uint64(1).
+		Variable([]byte{0xaa, 0xbb}).
+		Bool(true).
+		Variable([]byte{}).
+		Fixed([]byte{0xcc, 0xdd}).
+		Bytes()
+
+	expected := []byte{
+		0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Uint64
+		0x13, 0x00, 0x00, 0x00, // Offset of the first variable field (19)
+		0x01,                   // Bool
+		0x15, 0x00, 0x00, 0x00, // Offset of the second variable field (21)
+		0xcc, 0xdd, // Fixed bytes
+		0xaa, 0xbb, // First variable field
+	}
+	require.Equal(t, expected, data)
+	t.Log("Container was serialized correctly")
+}
+
+func TestLists(t *testing.T) {
+	require.Equal(t, []byte{0x01, 0x02, 0x03}, FixedList([][]byte{{0x01}, {0x02, 0x03}}))
+	require.Empty(t, FixedList(nil))
+	t.Log("Fixed-size lists were serialized correctly")
+
+	expected := []byte{
+		0x08, 0x00, 0x00, 0x00, // Offset of the first element
+		0x09, 0x00, 0x00, 0x00, // Offset of the second element
+		0x01,       // First element
+		0x02, 0x03, // Second element
+	}
+	require.Equal(t, expected, VariableList([][]byte{{0x01}, {0x02, 0x03}}))
+	require.Empty(t, VariableList(nil))
+	t.Log("Variable-size lists were serialized correctly")
+
+	require.Equal(t, []byte{0x02, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, Uint64List([]uint64{2, ^uint64(0)}))
+	t.Log("Uint64 lists were serialized correctly")
+}
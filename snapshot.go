@@ -4,4 +4,19 @@ package osha
 type Snapshot struct {
 	name   string
 	states map[IOshaModule]any
+
+	// The last EL block that was scanned for deposit events when the snapshot was taken
+	lastDepositScanBlock uint64
+
+	// The Hardhat snapshots taken before each EL block was mined, as of when the snapshot was taken
+	blockSnapshots map[uint64]blockSnapshot
+}
+
+// A Hardhat snapshot taken right before the EL block for a Beacon slot was mined, so a reorg can revert the EL to it
+type blockSnapshot struct {
+	// The Hardhat snapshot ID
+	hardhatSnapshotID string
+
+	// The last EL block that was scanned for deposit events when the snapshot was taken
+	lastDepositScanBlock uint64
 }
@@ -2,12 +2,19 @@ package osha
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"math/big"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/google/uuid"
@@ -62,6 +69,19 @@ type TestManager struct {
 
 	// Map of registered modules (moduleName -> module)
 	registeredModules map[string]IOshaModule
+
+	// The last EL block that was scanned for deposit contract events
+	lastDepositScanBlock uint64
+
+	// Names of OSHA snapshots whose Hardhat snapshots were deleted when an EL reorg reverted Hardhat past them
+	invalidatedSnapshots map[string]bool
+
+	// Whether Hardhat is snapshotted before each EL block is mined, so reorgs can revert the EL
+	executionReorgsEnabled bool
+
+	// Map of Beacon slots in the current epoch to the Hardhat snapshots taken right before their EL blocks were mined,
+	// used to revert the EL during reorgs
+	blockSnapshots map[uint64]blockSnapshot
 }
 
 // Creates a new TestManager instance
@@ -133,17 +153,20 @@ func NewTestManager() (*TestManager, error) {
 	docker := docker.NewDockerMockManager(logger)
 
 	m := &TestManager{
-		logger:             logger,
-		hardhatRpcClient:   hardhatRpcClient,
-		executionClient:    primaryEc,
-		beaconMockManager:  beaconMockManager,
-		beaconNode:         beaconNode,
-		docker:             docker,
-		chainID:            beaconCfg.ChainID,
-		fsManager:          fsManager,
-		snapshots:          map[string]Snapshot{},
-		hardhatSnapshotMap: map[string]string{},
-		registeredModules:  map[string]IOshaModule{},
+		logger:               logger,
+		hardhatRpcClient:     hardhatRpcClient,
+		executionClient:      primaryEc,
+		beaconMockManager:    beaconMockManager,
+		beaconNode:           beaconNode,
+		docker:               docker,
+		chainID:              beaconCfg.ChainID,
+		fsManager:            fsManager,
+		snapshots:            map[string]Snapshot{},
+		hardhatSnapshotMap:   map[string]string{},
+		invalidatedSnapshots: map[string]bool{},
+		registeredModules:    map[string]IOshaModule{},
+		lastDepositScanBlock: latestBlockHeader.Number.Uint64(),
+		blockSnapshots:       map[uint64]blockSnapshot{},
 	}
 
 	// Create the baseline snapshot
@@ -240,8 +263,10 @@ func (m *TestManager) CreateSnapshot() (string, error) {
 
 	// Create a new snapshot
 	snapshot := Snapshot{
-		name:   snapshotName,
-		states: make(map[IOshaModule]any),
+		name:                 snapshotName,
+		states:               make(map[IOshaModule]any),
+		lastDepositScanBlock: m.lastDepositScanBlock,
+		blockSnapshots:       maps.Clone(m.blockSnapshots),
 	}
 	var hardhatSnapshotName string
 	// Take a snapshot of hardhat
@@ -286,6 +311,9 @@ func (m *TestManager) RevertSnapshot(snapshotName string) error {
 	if !exists {
 		return fmt.Errorf("snapshot %s does not exist", snapshotName)
 	}
+	if m.invalidatedSnapshots[snapshotName] {
+		return fmt.Errorf("snapshot %s was deleted by an EL reorg that reverted Hardhat to before it", snapshotName)
+	}
 
 	// Revert snapshot of Hardhat
 	hardhatSnapshotName, exists := m.hardhatSnapshotMap[snapshotName]
@@ -309,6 +337,8 @@ func (m *TestManager) RevertSnapshot(snapshotName string) error {
 	if err != nil {
 		return fmt.Errorf("error reverting the BN to snapshot %s: %w", snapshotName, err)
 	}
+	m.lastDepositScanBlock = snapshot.lastDepositScanBlock
+	m.blockSnapshots = maps.Clone(snapshot.blockSnapshots)
 
 	// Revert Docker
 	err = m.docker.RevertToSnapshot(snapshotName)
@@ -358,21 +388,15 @@ func (m *TestManager) GetRegisteredModules() []IOshaModule {
 // Commits a new block in the EC and BN, advancing the chain
 func (m *TestManager) CommitBlock() error {
 	// Mine the next block in Hardhat
-	err := m.hardhat_mineBlock()
-	if err != nil {
-		return err
-	}
-
-	// Increase time by the slot duration to prep for the next slot
-	secondsPerSlot := uint(m.beaconMockManager.GetConfig().SecondsPerSlot)
-	err = m.hardhat_increaseTime(secondsPerSlot)
+	slot := m.beaconMockManager.GetCurrentSlot()
+	header, err := m.mineExecutionBlock(slot)
 	if err != nil {
 		return err
 	}
 
-	// Commit the block in the BN
+	// Commit the block in the BN and link it to the EL block
 	m.beaconMockManager.CommitBlock(true)
-	return nil
+	return m.linkExecutionBlock(slot, header)
 }
 
 // Advances the chain by a number of slots.
@@ -389,6 +413,12 @@ func (m *TestManager) AdvanceSlots(slots uint, includeBlocks bool) error {
 		return nil
 	}
 
+	// Add any deposits from automined blocks to the BN
+	err := m.processDepositEvents()
+	if err != nil {
+		return err
+	}
+
 	// Commit slots without blocks
 	for i := uint(0); i < slots; i++ {
 		m.beaconMockManager.CommitBlock(false)
@@ -396,19 +426,141 @@ func (m *TestManager) AdvanceSlots(slots uint, includeBlocks bool) error {
 
 	// Advance the time in Hardhat
 	secondsPerSlot := uint(m.beaconMockManager.GetConfig().SecondsPerSlot)
-	err := m.hardhatRpcClient.Call(nil, "evm_increaseTime", secondsPerSlot*slots)
+	err = m.hardhatRpcClient.Call(nil, "evm_increaseTime", secondsPerSlot*slots)
 	if err != nil {
 		return fmt.Errorf("error advancing time on EL: %w", err)
 	}
 	return nil
 }
 
+// Reorgs the last depth slots of the Beacon chain, replacing them with a new branch that has blocks in its first
+// newBlocks slots and misses the rest.
+// If revertExecution is true, Hardhat is reverted to right before the EL block of the first dropped Beacon block and a
+// new EL block is mined for each new Beacon block. This requires EL reorgs to be enabled with SetExecutionReorgsEnabled
+// before the dropped blocks were committed. Deposits from the reverted EL blocks are removed from the BN. Hardhat deletes
+// any snapshots taken after that point, so reverting to one of them afterwards returns an error.
+// Otherwise, the EL chain is kept and the new Beacon blocks are linked to its existing blocks, mining new ones if needed.
+// Blocks committed after the reorg are linked to new EL blocks after the EL head, even if the new branch has fewer blocks.
+// Withdrawals from the dropped blocks are removed from the EL balances of their recipients.
+func (m *TestManager) Reorg(depth uint64, newBlocks uint64, revertExecution bool) error {
+	if revertExecution && !m.executionReorgsEnabled {
+		return fmt.Errorf("EL reorgs aren't enabled")
+	}
+	dropped, err := m.beaconMockManager.Reorg(depth, newBlocks)
+	if err != nil {
+		return fmt.Errorf("error reorging the BN: %w", err)
+	}
+	currentSlot := m.beaconMockManager.GetCurrentSlot()
+	forkSlot := currentSlot - depth
+
+	if !revertExecution || len(dropped) == 0 {
+		// Take the old withdrawals back
+		for _, block := range dropped {
+			err = m.debitWithdrawals(block.Withdrawals)
+			if err != nil {
+				return fmt.Errorf("error reverting withdrawals for slot %d: %w", block.Slot, err)
+			}
+		}
+
+		// Link the new blocks to the existing EL blocks
+		for slot := forkSlot; slot < currentSlot; slot++ {
+			block := m.beaconMockManager.GetBlockBySlot(slot)
+			if block == nil {
+				continue
+			}
+			header, err := m.executionClient.HeaderByNumber(context.Background(), new(big.Int).SetUint64(block.ExecutionBlockNumber))
+			if errors.Is(err, ethereum.NotFound) {
+				header, err = m.mineExecutionBlock(slot)
+			}
+			if err != nil {
+				return fmt.Errorf("error getting EL block %d: %w", block.ExecutionBlockNumber, err)
+			}
+			err = m.linkExecutionBlock(slot, header)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Keep the EL blocks after the new branch, so later blocks follow the EL head
+		latestBlock, err := m.executionClient.BlockNumber(context.Background())
+		if err != nil {
+			return fmt.Errorf("error getting latest EL block: %w", err)
+		}
+		m.beaconMockManager.SetLatestExecutionBlockNumber(latestBlock)
+		return nil
+	}
+
+	// Revert Hardhat to before the first dropped block
+	firstSlot := dropped[0].Slot
+	snapshot, exists := m.blockSnapshots[firstSlot]
+	if !exists {
+		return fmt.Errorf("no Hardhat snapshot exists for slot %d", firstSlot)
+	}
+	var reverted bool
+	err = m.hardhatRpcClient.Call(&reverted, "evm_revert", snapshot.hardhatSnapshotID)
+	if err != nil {
+		return fmt.Errorf("error reverting Hardhat to slot %d: %w", firstSlot, err)
+	}
+	if !reverted {
+		return fmt.Errorf("Hardhat snapshot for slot %d no longer exists", firstSlot)
+	}
+	maps.DeleteFunc(m.blockSnapshots, func(slot uint64, _ blockSnapshot) bool {
+		return slot >= firstSlot
+	})
+	m.invalidateSnapshotsSince(snapshot.hardhatSnapshotID)
+
+	// Remove the deposits from the reverted blocks, rescanning the ones mined before the snapshot with the new branch
+	m.beaconMockManager.RemovePendingDepositsSince(firstSlot)
+	m.lastDepositScanBlock = snapshot.lastDepositScanBlock
+
+	// Mine the new branch, advancing the time through the missed slots
+	secondsPerSlot := uint(m.beaconMockManager.GetConfig().SecondsPerSlot)
+	for slot := firstSlot; slot < currentSlot; slot++ {
+		if m.beaconMockManager.GetBlockBySlot(slot) == nil {
+			err = m.hardhat_increaseTime(secondsPerSlot)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		header, err := m.mineExecutionBlock(slot)
+		if err != nil {
+			return err
+		}
+		err = m.linkExecutionBlock(slot, header)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Enable or disable EL reorgs. While enabled, Hardhat is snapshotted before each EL block is mined so Reorg can revert
+// the EL; this costs an extra RPC call per block, so it's disabled by default.
+func (m *TestManager) SetExecutionReorgsEnabled(enabled bool) {
+	m.executionReorgsEnabled = enabled
+	if !enabled {
+		clear(m.blockSnapshots)
+	}
+}
+
 // Set the highest slot (the head slot) of the Beacon chain, while keeping the local chain head on the client the same.
 // Useful for simulating an unsynced client.
 func (m *TestManager) SetBeaconHeadSlot(slot uint64) {
 	m.beaconMockManager.SetHighestSlot(slot)
 }
 
+// Stall or resume finality on the Beacon chain. While stalled, the justified and finalized checkpoints won't advance.
+// Useful for simulating a chain that has lost finality.
+func (m *TestManager) SetFinalityStalled(stalled bool) {
+	m.beaconMockManager.SetFinalityStalled(stalled)
+}
+
+// Immediately justify and finalize the given epoch on the Beacon chain, which must have ended
+func (m *TestManager) ForceFinalization(epoch uint64) error {
+	return m.beaconMockManager.ForceFinalization(epoch)
+}
+
 // Toggle automining where each TX will automatically be mine into its own block
 func (m *TestManager) ToggleAutoMine(enabled bool) error {
 	err := m.hardhatRpcClient.Call(nil, "evm_setAutomine", enabled)
@@ -431,6 +583,93 @@ func (m *TestManager) SetMiningInterval(interval uint) error {
 // === Internal Methods ===
 // ========================
 
+// Mine an EL block for the given slot. If EL reorgs are enabled, Hardhat is snapshotted first so it can be reverted
+// during a reorg, and snapshots of slots before the current epoch are forgotten since the BN can't reorg them.
+// Adds any new deposits to the BN and advances the time by a slot. Returns the header of the new block.
+func (m *TestManager) mineExecutionBlock(slot uint64) (*types.Header, error) {
+	// Snapshot Hardhat
+	if m.executionReorgsEnabled {
+		var snapshotID string
+		err := m.hardhatRpcClient.Call(&snapshotID, "evm_snapshot")
+		if err != nil {
+			return nil, fmt.Errorf("error taking snapshot of Hardhat for slot %d: %w", slot, err)
+		}
+		m.blockSnapshots[slot] = blockSnapshot{
+			hardhatSnapshotID:    snapshotID,
+			lastDepositScanBlock: m.lastDepositScanBlock,
+		}
+
+		slotsPerEpoch := m.beaconMockManager.GetConfig().SlotsPerEpoch
+		epochStartSlot := slot / slotsPerEpoch * slotsPerEpoch
+		maps.DeleteFunc(m.blockSnapshots, func(slot uint64, _ blockSnapshot) bool {
+			return slot < epochStartSlot
+		})
+	}
+
+	// Mine the next block in Hardhat
+	err := m.hardhat_mineBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Add any new deposits to the BN
+	err = m.processDepositEvents()
+	if err != nil {
+		return nil, err
+	}
+
+	// Increase time by the slot duration to prep for the next slot
+	secondsPerSlot := uint(m.beaconMockManager.GetConfig().SecondsPerSlot)
+	err = m.hardhat_increaseTime(secondsPerSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the block that was just mined
+	header, err := m.executionClient.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest EL block header: %w", err)
+	}
+	return header, nil
+}
+
+// Mark the OSHA snapshots whose Hardhat snapshots were deleted by reverting Hardhat to the given snapshot as invalid.
+// Hardhat deletes the snapshot it reverts to and every one taken after it; snapshot IDs are increasing numbers.
+func (m *TestManager) invalidateSnapshotsSince(hardhatSnapshotID string) {
+	revertedID, err := hexutil.DecodeUint64(hardhatSnapshotID)
+	for name, id := range m.hardhatSnapshotMap {
+		snapshotID, idErr := hexutil.DecodeUint64(id)
+		if err != nil || idErr != nil || snapshotID >= revertedID {
+			m.invalidatedSnapshots[name] = true
+			delete(m.hardhatSnapshotMap, name)
+		}
+	}
+}
+
+// Link the Beacon block in the given slot to an EL block and send its withdrawals to the EL
+func (m *TestManager) linkExecutionBlock(slot uint64, header *types.Header) error {
+	err := m.beaconMockManager.SetExecutionBlockDetails(slot, header.Number.Uint64(), header.Hash(), header.Time, header.Coinbase)
+	if err != nil {
+		return fmt.Errorf("error setting EL block details for slot %d: %w", slot, err)
+	}
+
+	block := m.beaconMockManager.GetBlockBySlot(slot)
+	err = m.creditWithdrawals(block.Withdrawals)
+	if err != nil {
+		return fmt.Errorf("error processing withdrawals for slot %d: %w", slot, err)
+	}
+	return nil
+}
+
+// Tell Hardhat to set the ETH balance of an address, in wei
+func (m *TestManager) hardhat_setBalance(address common.Address, balance *big.Int) error {
+	err := m.hardhatRpcClient.Call(nil, "hardhat_setBalance", address.Hex(), hexutil.EncodeBig(balance))
+	if err != nil {
+		return fmt.Errorf("error setting EL balance of %s: %w", address.Hex(), err)
+	}
+	return nil
+}
+
 // Tell Hardhat to mine a block
 func (m *TestManager) hardhat_mineBlock() error {
 	err := m.hardhatRpcClient.Call(nil, "evm_mine")
@@ -440,6 +679,70 @@ func (m *TestManager) hardhat_mineBlock() error {
 	return nil
 }
 
+// Scan any EL blocks mined since the last scan for DepositEvent logs from the deposit contract,
+// adding them to the BN as pending deposits in the current slot
+func (m *TestManager) processDepositEvents() error {
+	latestBlock, err := m.executionClient.BlockNumber(context.Background())
+	if err != nil {
+		return fmt.Errorf("error getting latest EL block: %w", err)
+	}
+	if latestBlock <= m.lastDepositScanBlock {
+		return nil
+	}
+
+	// Get the deposit logs
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(m.lastDepositScanBlock + 1),
+		ToBlock:   new(big.Int).SetUint64(latestBlock),
+		Addresses: []common.Address{m.beaconMockManager.GetConfig().DepositContract},
+		Topics:    [][]common.Hash{{db.DepositEventTopic}},
+	}
+	logs, err := m.executionClient.FilterLogs(context.Background(), query)
+	if err != nil {
+		return fmt.Errorf("error getting deposit events for blocks %d to %d: %w", m.lastDepositScanBlock+1, latestBlock, err)
+	}
+
+	// Add them to the BN
+	m.beaconMockManager.AddPendingDepositsFromEventLogs(logs, m.beaconMockManager.GetCurrentSlot())
+	m.lastDepositScanBlock = latestBlock
+	return nil
+}
+
+// Credit the withdrawal addresses on the EL with the amounts withdrawn from the BN
+func (m *TestManager) creditWithdrawals(withdrawals []db.Withdrawal) error {
+	for _, withdrawal := range withdrawals {
+		balance, err := m.executionClient.BalanceAt(context.Background(), withdrawal.Address, nil)
+		if err != nil {
+			return fmt.Errorf("error getting balance of %s: %w", withdrawal.Address.Hex(), err)
+		}
+		amount := new(big.Int).Mul(new(big.Int).SetUint64(withdrawal.Amount), big.NewInt(1e9))
+		err = m.hardhat_setBalance(withdrawal.Address, balance.Add(balance, amount))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove the amounts withdrawn in dropped blocks from the withdrawal addresses on the EL
+func (m *TestManager) debitWithdrawals(withdrawals []db.Withdrawal) error {
+	for _, withdrawal := range withdrawals {
+		balance, err := m.executionClient.BalanceAt(context.Background(), withdrawal.Address, nil)
+		if err != nil {
+			return fmt.Errorf("error getting balance of %s: %w", withdrawal.Address.Hex(), err)
+		}
+		amount := new(big.Int).Mul(new(big.Int).SetUint64(withdrawal.Amount), big.NewInt(1e9))
+		if balance.Cmp(amount) < 0 {
+			amount = balance
+		}
+		err = m.hardhat_setBalance(withdrawal.Address, balance.Sub(balance, amount))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Tell Hardhat to mine a block
 func (m *TestManager) hardhat_increaseTime(seconds uint) error {
 	err := m.hardhatRpcClient.Call(nil, "evm_increaseTime", seconds)